@@ -0,0 +1,94 @@
+package qstr
+
+import (
+	"math"
+)
+
+// clamp01 limits v to the range [0, 1]
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+// Lighten returns c with amount, in the range [0, 1], added to its
+// lightness. The result is clamped to white.
+func (c *HSLColor) Lighten(amount float64) HSLColor {
+	return HSLColor{c.H, c.S, clamp01(c.L + amount)}
+}
+
+// Darken returns c with amount, in the range [0, 1], taken from its
+// lightness. The result is clamped to black.
+func (c *HSLColor) Darken(amount float64) HSLColor {
+	return c.Lighten(-amount)
+}
+
+// Saturate returns c with amount, in the range [0, 1], added to its
+// saturation. The result is clamped to full saturation.
+func (c *HSLColor) Saturate(amount float64) HSLColor {
+	return HSLColor{c.H, clamp01(c.S + amount), c.L}
+}
+
+// Desaturate returns c with amount, in the range [0, 1], taken from its
+// saturation. The result is clamped to gray.
+func (c *HSLColor) Desaturate(amount float64) HSLColor {
+	return c.Saturate(-amount)
+}
+
+// RotateHue returns c with its hue turned by deg degrees. Negative angles
+// turn the other way, and the hue wraps around.
+func (c *HSLColor) RotateHue(deg float64) HSLColor {
+	h := math.Mod(c.H+deg/360, 1)
+	if h < 0 {
+		h++
+	}
+	return HSLColor{h, c.S, c.L}
+}
+
+// Invert returns the complement of c, the color whose RGB channels are the
+// inverses of c's: its hue turned halfway round and its lightness flipped.
+func (c *HSLColor) Invert() HSLColor {
+	inv := c.RotateHue(180)
+	inv.L = 1 - c.L
+	return inv
+}
+
+// Lighten returns c with amount, in the range [0, 1], added to its HSL
+// lightness. See HSLColor.Lighten.
+func (c *RGBColor) Lighten(amount float64) RGBColor {
+	return c.adjustHSL(func(hsl *HSLColor) HSLColor { return hsl.Lighten(amount) })
+}
+
+// Darken returns c with amount, in the range [0, 1], taken from its HSL
+// lightness. See HSLColor.Darken.
+func (c *RGBColor) Darken(amount float64) RGBColor {
+	return c.adjustHSL(func(hsl *HSLColor) HSLColor { return hsl.Darken(amount) })
+}
+
+// Saturate returns c with amount, in the range [0, 1], added to its HSL
+// saturation. See HSLColor.Saturate.
+func (c *RGBColor) Saturate(amount float64) RGBColor {
+	return c.adjustHSL(func(hsl *HSLColor) HSLColor { return hsl.Saturate(amount) })
+}
+
+// Desaturate returns c with amount, in the range [0, 1], taken from its HSL
+// saturation. See HSLColor.Desaturate.
+func (c *RGBColor) Desaturate(amount float64) RGBColor {
+	return c.adjustHSL(func(hsl *HSLColor) HSLColor { return hsl.Desaturate(amount) })
+}
+
+// RotateHue returns c with its hue turned by deg degrees. See
+// HSLColor.RotateHue.
+func (c *RGBColor) RotateHue(deg float64) RGBColor {
+	return c.adjustHSL(func(hsl *HSLColor) HSLColor { return hsl.RotateHue(deg) })
+}
+
+// Invert returns c with each of its channels inverted.
+func (c *RGBColor) Invert() RGBColor {
+	return RGBColor{1 - c.R, 1 - c.G, 1 - c.B}
+}
+
+// adjustHSL returns c adjusted by f in the HSL space
+func (c *RGBColor) adjustHSL(f func(hsl *HSLColor) HSLColor) RGBColor {
+	hsl := c.HSL()
+	adjusted := f(&hsl)
+	return adjusted.RGB()
+}
@@ -0,0 +1,46 @@
+package qstr
+
+import (
+	"math"
+	"testing"
+)
+
+func closeRGB(a, b RGBColor) bool {
+	return math.Abs(a.R-b.R) < 1e-9 && math.Abs(a.G-b.G) < 1e-9 && math.Abs(a.B-b.B) < 1e-9
+}
+
+func TestRGBAdjustments(t *testing.T) {
+	red := RGBColor{1, 0, 0}
+	muted := RGBColor{0.75, 0.25, 0.25}
+	orange := RGBColor{1, 0.25, 0}
+	var adjustList = []struct {
+		Name     string
+		Received RGBColor
+		Expected RGBColor
+	}{
+		{"Lighten", red.Lighten(0.25), RGBColor{1, 0.5, 0.5}},
+		{"Lighten past white", red.Lighten(2), RGBColor{1, 1, 1}},
+		{"Darken", red.Darken(0.25), RGBColor{0.5, 0, 0}},
+		{"Darken past black", red.Darken(2), RGBColor{0, 0, 0}},
+		{"Desaturate", red.Desaturate(1), RGBColor{0.5, 0.5, 0.5}},
+		{"Saturate", muted.Saturate(0.5), RGBColor{1, 0, 0}},
+		{"RotateHue", red.RotateHue(120), RGBColor{0, 1, 0}},
+		{"RotateHue backwards", red.RotateHue(-120), RGBColor{0, 0, 1}},
+		{"Invert", orange.Invert(), RGBColor{0, 0.75, 1}},
+	}
+
+	for _, v := range adjustList {
+		if !closeRGB(v.Received, v.Expected) {
+			t.Errorf("Incorrect %v. Expected: %v, Got: %v.", v.Name, v.Expected, v.Received)
+		}
+	}
+}
+
+func TestHSLInvert(t *testing.T) {
+	c := RGBColor{0.9, 0.3, 0.1}
+	hsl := c.HSL()
+	inv := hsl.Invert()
+	if received, expected := inv.RGB(), c.Invert(); !closeRGB(received, expected) {
+		t.Errorf("Incorrect HSL inversion of %v. Expected: %v, Got: %v.", c, expected, received)
+	}
+}
@@ -0,0 +1,80 @@
+package qstr
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// RGBAColor is a color in the RGB space with an alpha channel. R, G, B, and A
+// are in the range [0, 1], where an A of 0 is fully transparent and 1 fully
+// opaque.
+type RGBAColor struct {
+	// Red, Green, Blue, and Alpha
+	R, G, B, A float64
+}
+
+// HSLAColor is a color in the HSL space with an alpha channel.
+type HSLAColor struct {
+	// Hue, Saturation, Lightness, and Alpha
+	H, S, L, A float64
+}
+
+// NewRGBAColor returns c with the alpha value a.
+func NewRGBAColor(c RGBColor, a float64) RGBAColor {
+	return RGBAColor{c.R, c.G, c.B, a}
+}
+
+// RGB returns c without its alpha channel.
+func (c *RGBAColor) RGB() RGBColor {
+	return RGBColor{c.R, c.G, c.B}
+}
+
+// HSLA converts an RGBAColor into an HSLAColor, keeping its alpha value.
+func (c *RGBAColor) HSLA() HSLAColor {
+	rgb := c.RGB()
+	h := rgb.HSL()
+	return HSLAColor{h.H, h.S, h.L, c.A}
+}
+
+// RGBA converts an HSLAColor into an RGBAColor, keeping its alpha value.
+func (c *HSLAColor) RGBA() RGBAColor {
+	h := HSLColor{c.H, c.S, c.L}
+	return NewRGBAColor(h.RGB(), c.A)
+}
+
+// CapLightness returns c with its lightness trimmed as RGBColor.CapLightness
+// does, keeping its alpha value.
+func (c *RGBAColor) CapLightness(floor float64, ceiling float64) RGBAColor {
+	rgb := c.RGB()
+	return NewRGBAColor(rgb.CapLightness(floor, ceiling), c.A)
+}
+
+// Over returns the opaque color seen when c is drawn over bg.
+func (c *RGBAColor) Over(bg RGBColor) RGBColor {
+	a := clamp01(c.A)
+	return RGBColor{
+		R: c.R*a + bg.R*(1-a),
+		G: c.G*a + bg.G*(1-a),
+		B: c.B*a + bg.B*(1-a),
+	}
+}
+
+// OverLinear is like Over, but blends c and bg in linear light, which is how
+// a translucent color actually combines with what is behind it.
+func (c *RGBAColor) OverLinear(bg RGBColor) RGBColor {
+	return MixLinear(bg, c.RGB(), c.A)
+}
+
+// CSS formats c as a CSS rgba() function, such as rgba(255,0,0,0.5). The
+// alpha value is rounded to three decimals.
+func (c *RGBAColor) CSS() string {
+	a := strconv.FormatFloat(math.Round(clamp01(c.A)*1000)/1000, 'f', -1, 64)
+	return fmt.Sprintf("rgba(%d,%d,%d,%s)", int(c.R*255.0), int(c.G*255.0), int(c.B*255.0), a)
+}
+
+// SpanStr converts an RGBAColor into a string representing an HTML span
+// with inline, semi-transparent coloring.
+func (c *RGBAColor) SpanStr() string {
+	return fmt.Sprintf("<span style=\"color:%s\">", c.CSS())
+}
@@ -0,0 +1,49 @@
+package qstr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRGBAColor(t *testing.T) {
+	c := NewRGBAColor(RGBColor{1, 0, 0}, 0.5)
+	if received := c.CSS(); received != "rgba(255,0,0,0.5)" {
+		t.Errorf("Incorrect CSS of %v. Expected: %v, Got: %v.", c, "rgba(255,0,0,0.5)", received)
+	}
+	if received := c.SpanStr(); received != "<span style=\"color:rgba(255,0,0,0.5)\">" {
+		t.Errorf("Incorrect SpanStr of %v. Expected: %v, Got: %v.", c, "<span style=\"color:rgba(255,0,0,0.5)\">", received)
+	}
+
+	hsla := c.HSLA()
+	if hsla != (HSLAColor{0, 1, 0.5, 0.5}) {
+		t.Errorf("Incorrect HSLA of %v. Expected: %v, Got: %v.", c, HSLAColor{0, 1, 0.5, 0.5}, hsla)
+	}
+	if received := hsla.RGBA(); received != c {
+		t.Errorf("Incorrect RGBA of %v. Expected: %v, Got: %v.", hsla, c, received)
+	}
+
+	capped := c.CapLightness(0.6, 1)
+	if capped.A != 0.5 || capped.RGB() == c.RGB() {
+		t.Errorf("Incorrect capping of %v. Got: %v.", c, capped)
+	}
+}
+
+func TestRGBAOver(t *testing.T) {
+	var overList = []struct {
+		Color    RGBAColor
+		Bg       RGBColor
+		Expected RGBColor
+	}{
+		{RGBAColor{1, 0, 0, 0.5}, RGBColor{0, 0, 1}, RGBColor{0.5, 0, 0.5}},
+		{RGBAColor{1, 0, 0, 1}, RGBColor{0, 0, 1}, RGBColor{1, 0, 0}},
+		{RGBAColor{1, 0, 0, 0}, RGBColor{0, 0, 1}, RGBColor{0, 0, 1}},
+		{RGBAColor{0.2, 0.4, 0.6, 0.25}, RGBColor{1, 1, 1}, RGBColor{0.8, 0.85, 0.9}},
+	}
+
+	for _, v := range overList {
+		received := v.Color.Over(v.Bg)
+		if math.Abs(received.R-v.Expected.R) > 1e-9 || math.Abs(received.G-v.Expected.G) > 1e-9 || math.Abs(received.B-v.Expected.B) > 1e-9 {
+			t.Errorf("Incorrect blend of %v over %v. Expected: %v, Got: %v.", v.Color, v.Bg, v.Expected, received)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package qstr
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// the number of colors reported by Analyzer.Stats
+const topColors = 20
+
+// the distance in Lab space within which colors are counted together
+const clusterRadius = 10.0
+
+// Analyzer gathers statistics about the color codes used across many QStr
+// values, such as all the nicks seen on a server. It is safe for concurrent
+// use.
+type Analyzer struct {
+	mu       sync.Mutex
+	total    int
+	colored  int
+	decimal  int
+	hex      int
+	clusters []colorCluster
+}
+
+// colorCluster is a group of perceptually similar colors, centered on the
+// first color seen.
+type colorCluster struct {
+	center  LabColor
+	members map[RGBColor]int
+}
+
+// Stats is a summary of the values seen by an Analyzer.
+type Stats struct {
+	// Total is the number of values analyzed
+	Total int `json:"total"`
+
+	// Colored is the number of values with at least one color code, and
+	// ColoredShare is its share of Total
+	Colored      int     `json:"colored"`
+	ColoredShare float64 `json:"colored_share"`
+
+	// DecimalCodes and HexCodes count the ^N and ^xNNN codes used
+	DecimalCodes int `json:"decimal_codes"`
+	HexCodes     int `json:"hex_codes"`
+
+	// TopColors holds the most used colors, most used first. Similar
+	// colors are counted together under the most common of them.
+	TopColors []ColorCount `json:"top_colors"`
+}
+
+// ColorCount is a color along with the number of times it was used.
+type ColorCount struct {
+	Color RGBColor `json:"-"`
+	Hex   string   `json:"color"`
+	Count int      `json:"count"`
+}
+
+// NewAnalyzer returns an empty Analyzer.
+func NewAnalyzer() *Analyzer {
+	return &Analyzer{}
+}
+
+// Add records the color codes used in s.
+func (a *Analyzer) Add(s QStr) {
+	raw := string(s)
+	var codes []string
+	for _, loc := range findCodes(raw) {
+		codes = append(codes, raw[loc[0]:loc[1]])
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.total++
+	if len(codes) > 0 {
+		a.colored++
+	}
+	for _, code := range codes {
+		if !Code(code).IsHex() {
+			a.decimal++
+		} else {
+			a.hex++
+		}
+
+		c := ColorCodeToColorRGB(code)
+		a.cluster(c).members[c]++
+	}
+}
+
+// Stats returns a summary of the values added so far.
+func (a *Analyzer) Stats() Stats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	st := Stats{
+		Total:        a.total,
+		Colored:      a.colored,
+		DecimalCodes: a.decimal,
+		HexCodes:     a.hex,
+		TopColors:    make([]ColorCount, 0, len(a.clusters)),
+	}
+	if a.total > 0 {
+		st.ColoredShare = float64(a.colored) / float64(a.total)
+	}
+
+	for _, cl := range a.clusters {
+		var top ColorCount
+		best := 0
+		for c, n := range cl.members {
+			// ties go to the lowest hex value so the result is stable
+			if n > best || (n == best && c.Hex() < top.Hex) {
+				top.Color, top.Hex, best = c, c.Hex(), n
+			}
+			top.Count += n
+		}
+		st.TopColors = append(st.TopColors, top)
+	}
+	sort.Slice(st.TopColors, func(i, j int) bool {
+		if st.TopColors[i].Count != st.TopColors[j].Count {
+			return st.TopColors[i].Count > st.TopColors[j].Count
+		}
+		return st.TopColors[i].Hex < st.TopColors[j].Hex
+	})
+	if len(st.TopColors) > topColors {
+		st.TopColors = st.TopColors[:topColors]
+	}
+
+	return st
+}
+
+// WriteJSON writes the current statistics to w as JSON.
+func (a *Analyzer) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(a.Stats())
+}
+
+// cluster returns the cluster c belongs to, starting a new one if c is not
+// close to any existing cluster.
+func (a *Analyzer) cluster(c RGBColor) *colorCluster {
+	lab := c.Lab()
+	for i := range a.clusters {
+		if lab.distance(a.clusters[i].center) <= clusterRadius {
+			return &a.clusters[i]
+		}
+	}
+	a.clusters = append(a.clusters, colorCluster{center: lab, members: make(map[RGBColor]int)})
+	return &a.clusters[len(a.clusters)-1]
+}
+
+// ColorStats counts the use of each color within one or more QStr values.
+type ColorStats struct {
+	// Colors holds a count for each color used, the colors covering the
+	// most characters first. Codes giving the same color, such as ^1 and
+	// ^xf00, are counted together.
+	Colors []ColorUsage `json:"colors"`
+
+	// Uncolored is the number of characters shown before any color code.
+	Uncolored int `json:"uncolored"`
+}
+
+// ColorUsage is the use of a single color.
+type ColorUsage struct {
+	Color RGBColor `json:"-"`
+	Hex   string   `json:"color"`
+
+	// Chars is the number of visible characters shown in the color. White
+	// space is not counted as it shows no color.
+	Chars int `json:"chars"`
+
+	// Codes is the number of color codes giving the color, including any
+	// that color no text.
+	Codes int `json:"codes"`
+}
+
+// ColorStats returns the number of characters and color codes of each color
+// used in s.
+func (s *QStr) ColorStats() ColorStats {
+	return AggregateColorStats([]QStr{*s})
+}
+
+// AggregateColorStats returns the number of characters and color codes of
+// each color used across values, such as to find the most popular nick
+// colors.
+func AggregateColorStats(values []QStr) ColorStats {
+	var st ColorStats
+	usage := make(map[RGBColor]*ColorUsage)
+	count := func(text string, u *ColorUsage) {
+		n := 0
+		for _, g := range graphemes(unescape(text)) {
+			if strings.TrimSpace(g) != "" {
+				n++
+			}
+		}
+		if u == nil {
+			st.Uncolored += n
+		} else {
+			u.Chars += n
+		}
+	}
+
+	for _, s := range values {
+		raw := string(s)
+		var current *ColorUsage
+		prev := 0
+		for _, loc := range findCodes(raw) {
+			count(raw[prev:loc[0]], current)
+			c := ColorCodeToColorRGB(raw[loc[0]:loc[1]])
+			if usage[c] == nil {
+				usage[c] = &ColorUsage{Color: c, Hex: c.Hex()}
+			}
+			current = usage[c]
+			current.Codes++
+			prev = loc[1]
+		}
+		count(raw[prev:], current)
+	}
+
+	st.Colors = make([]ColorUsage, 0, len(usage))
+	for _, u := range usage {
+		st.Colors = append(st.Colors, *u)
+	}
+	sort.Slice(st.Colors, func(i, j int) bool {
+		a, b := st.Colors[i], st.Colors[j]
+		if a.Chars != b.Chars {
+			return a.Chars > b.Chars
+		}
+		if a.Codes != b.Codes {
+			return a.Codes > b.Codes
+		}
+		return a.Hex < b.Hex
+	})
+	return st
+}
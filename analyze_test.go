@@ -0,0 +1,76 @@
+package qstr
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzer(t *testing.T) {
+	a := NewAnalyzer()
+	for _, nick := range []QStr{"^1Anti^7body", "^1Red", "^xF00Red", "^xE00Red", "Plain"} {
+		a.Add(nick)
+	}
+
+	st := a.Stats()
+	if st.Total != 5 || st.Colored != 4 || st.ColoredShare != 0.8 {
+		t.Errorf("Incorrect totals. Expected: %v/%v/%v, Got: %v/%v/%v.", 5, 4, 0.8, st.Total, st.Colored, st.ColoredShare)
+	}
+	if st.DecimalCodes != 3 || st.HexCodes != 2 {
+		t.Errorf("Incorrect code counts. Expected: %v/%v, Got: %v/%v.", 3, 2, st.DecimalCodes, st.HexCodes)
+	}
+
+	expected := []ColorCount{
+		{Color: RGBColor{1, 0, 0}, Hex: "#ff0000", Count: 4},
+		{Color: RGBColor{1, 1, 1}, Hex: "#ffffff", Count: 1},
+	}
+	if len(st.TopColors) != len(expected) {
+		t.Fatalf("Incorrect number of top colors. Expected: %v, Got: %v.", len(expected), len(st.TopColors))
+	}
+	for i, c := range st.TopColors {
+		if c != expected[i] {
+			t.Errorf("Incorrect top color %d. Expected: %+v, Got: %+v.", i, expected[i], c)
+		}
+	}
+}
+
+func TestAnalyzerJSON(t *testing.T) {
+	a := NewAnalyzer()
+	a.Add("^1Anti^1body")
+
+	var b bytes.Buffer
+	if err := a.WriteJSON(&b); err != nil {
+		t.Fatalf("Unexpected error: %v.", err)
+	}
+
+	expected := `{"total":1,"colored":1,"colored_share":1,"decimal_codes":2,"hex_codes":0,"top_colors":[{"color":"#ff0000","count":2}]}` + "\n"
+	if received := b.String(); received != expected {
+		t.Errorf("Incorrect JSON. Expected: %v, Got: %v.", expected, received)
+	}
+}
+
+func TestColorStats(t *testing.T) {
+	s := QStr("my ^1Anti^xf00bo^2dy^3")
+	expected := ColorStats{
+		Colors: []ColorUsage{
+			{Color: RGBColor{1, 0, 0}, Hex: "#ff0000", Chars: 6, Codes: 2},
+			{Color: RGBColor{0.2, 1, 0}, Hex: "#33ff00", Chars: 2, Codes: 1},
+			{Color: RGBColor{1, 1, 0}, Hex: "#ffff00", Chars: 0, Codes: 1},
+		},
+		Uncolored: 2,
+	}
+	if received := s.ColorStats(); !reflect.DeepEqual(received, expected) {
+		t.Errorf("Incorrect color stats. Expected: %+v, Got: %+v.", expected, received)
+	}
+
+	agg := AggregateColorStats([]QStr{"^2a b", "^2c^^", "^4d"})
+	expected = ColorStats{
+		Colors: []ColorUsage{
+			{Color: RGBColor{0.2, 1, 0}, Hex: "#33ff00", Chars: 4, Codes: 2},
+			{Color: RGBColor{0.2, 0.4, 1}, Hex: "#3366ff", Chars: 1, Codes: 1},
+		},
+	}
+	if !reflect.DeepEqual(agg, expected) {
+		t.Errorf("Incorrect aggregate color stats. Expected: %+v, Got: %+v.", expected, agg)
+	}
+}
@@ -0,0 +1,228 @@
+package qstr
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ColorDepth is the number of colors a terminal can display.
+type ColorDepth int
+
+const (
+	// TrueColor uses 24-bit colors. This is the default.
+	TrueColor ColorDepth = iota
+
+	// Color256 uses the nearest entry of the xterm 256-color palette.
+	Color256
+
+	// Color16 uses the nearest of the 16 basic terminal colors.
+	Color16
+)
+
+// the SGR sequence resetting all attributes
+const ansiReset = "\x1b[0m"
+
+// ansi16 holds the typical values of the 16 basic terminal colors
+var ansi16 = [16]RGBColor{
+	NewRGBColorFrom255(0, 0, 0),
+	NewRGBColorFrom255(205, 0, 0),
+	NewRGBColorFrom255(0, 205, 0),
+	NewRGBColorFrom255(205, 205, 0),
+	NewRGBColorFrom255(0, 0, 238),
+	NewRGBColorFrom255(205, 0, 205),
+	NewRGBColorFrom255(0, 205, 205),
+	NewRGBColorFrom255(229, 229, 229),
+	NewRGBColorFrom255(127, 127, 127),
+	NewRGBColorFrom255(255, 0, 0),
+	NewRGBColorFrom255(0, 255, 0),
+	NewRGBColorFrom255(255, 255, 0),
+	NewRGBColorFrom255(92, 92, 255),
+	NewRGBColorFrom255(255, 0, 255),
+	NewRGBColorFrom255(0, 255, 255),
+	NewRGBColorFrom255(255, 255, 255),
+}
+
+// the channel values of the 6x6x6 color cube in the 256-color palette
+var ansiCubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// ANSI returns s with its color codes converted into ANSI SGR escape
+// sequences for display in a terminal. Options may be given to alter the
+// output; see Renderer.
+func (s *QStr) ANSI(opts ...Option) string {
+	return NewRenderer(opts...).ANSI(*s)
+}
+
+// WithColorDepth sets the number of colors used for ANSI output. Colors are
+// downgraded to the nearest available entry for terminals without true
+// color support.
+func WithColorDepth(depth ColorDepth) Option {
+	return func(r *Renderer) {
+		r.depth = depth
+	}
+}
+
+// ANSI returns s with its color codes converted into ANSI SGR escape
+// sequences. Hex colors are capped to the theme's lightness bounds, as in
+// HTML output, and the attributes of the dialect's extension codes are
+// honored. Control characters in the text, other than tabs and newlines,
+// are replaced by U+FFFD so a name can't inject escape sequences. The
+// output ends with a reset if any attribute was set.
+func (r *Renderer) ANSI(s QStr) string {
+	return r.cached(cacheANSI, s, func() string {
+		out, _ := r.renderANSI(context.Background(), s)
+		return out
+	})
+}
+
+// renderANSI does the work of ANSI, giving up on escape sequences once ctx is
+// done: the rest of the text is then appended stripped and false is returned.
+func (r *Renderer) renderANSI(ctx context.Context, s QStr) (string, bool) {
+	var b strings.Builder
+	ok := r.writeANSI(ctx, &b, s)
+	return b.String(), ok
+}
+
+// writeANSI writes the ANSI representation of s to b as renderANSI does,
+// returning false if ctx was done before the escape sequences were complete.
+func (r *Renderer) writeANSI(ctx context.Context, b textWriter, s QStr) bool {
+	s, cut := r.limit(s)
+	if cut {
+		r.truncated(len(s))
+	}
+	d := r.newDeadline(ctx)
+	styled := false
+	off := r.dialect.scanWarnings(s, func(seg Segment) bool {
+		if !d.admit(seg) {
+			r.colorsDropped()
+			return false
+		}
+		if seg.styled() {
+			b.WriteString(r.sgr(seg, styled))
+			styled = true
+		} else if styled {
+			b.WriteString(ansiReset)
+			styled = false
+		}
+		b.WriteString(r.terminalText(seg.Text))
+		return true
+	}, r.warn)
+	if styled {
+		b.WriteString(ansiReset)
+	}
+	b.WriteString(r.terminalText(r.dialect.Strip(s[off:])))
+
+	return !d.hit && !cut
+}
+
+// terminalText returns text translated as by r.text, with every control
+// character other than tab and newline, including ESC, BEL, and the C1
+// controls, replaced by U+FFFD, so that the text can't send escape sequences
+// of its own to the terminal.
+func (r *Renderer) terminalText(text string) string {
+	text = r.text(text)
+	return strings.Map(func(c rune) rune {
+		if c == '\t' || c == '\n' {
+			return c
+		}
+		if c < 0x20 || (0x7f <= c && c <= 0x9f) {
+			return utf8.RuneError
+		}
+		return c
+	}, text)
+}
+
+// sgr returns the SGR sequence setting the attributes of seg. If reset is
+// true, the attributes of the previous segment are cleared first.
+func (r *Renderer) sgr(seg Segment, reset bool) string {
+	params := make([]string, 0, 6)
+	if reset {
+		params = append(params, "0")
+	}
+	if seg.Style.Has(Bold) {
+		params = append(params, "1")
+	}
+	if seg.Style.Has(Italic) {
+		params = append(params, "3")
+	}
+	if seg.Style.Has(Underline) {
+		params = append(params, "4")
+	}
+	if seg.Style.Has(Blink) {
+		params = append(params, "5")
+	}
+	if seg.Code != "" {
+		c := r.color(seg)
+		if seg.Code.IsHex() {
+			c = r.capLightness(c)
+		}
+		params = append(params, r.ansiColor(c, false))
+	}
+	if seg.HasBackground {
+		params = append(params, r.ansiColor(seg.Background, true))
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// ansiColor returns the SGR parameters selecting c as the foreground or
+// background color at the renderer's color depth.
+func (r *Renderer) ansiColor(c RGBColor, background bool) string {
+	switch r.depth {
+	case Color256:
+		if background {
+			return "48;5;" + strconv.Itoa(ansi256Index(c))
+		}
+		return "38;5;" + strconv.Itoa(ansi256Index(c))
+	case Color16:
+		i := nearestColor(c, ansi16[:])
+		base := 30
+		if i >= 8 {
+			base, i = 90, i-8
+		}
+		if background {
+			base += 10
+		}
+		return strconv.Itoa(base + i)
+	}
+
+	if background {
+		return fmt.Sprintf("48;2;%d;%d;%d", to255(c.R), to255(c.G), to255(c.B))
+	}
+	return fmt.Sprintf("38;2;%d;%d;%d", to255(c.R), to255(c.G), to255(c.B))
+}
+
+// ansi256Index returns the entry of the xterm 256-color palette nearest to
+// c, choosing between the 6x6x6 color cube and the grayscale ramp.
+func ansi256Index(c RGBColor) int {
+	nearestLevel := func(v float64) int {
+		best := 0
+		for i, l := range ansiCubeLevels {
+			if abs(to255(v)-l) < abs(to255(v)-ansiCubeLevels[best]) {
+				best = i
+			}
+		}
+		return best
+	}
+	ri, gi, bi := nearestLevel(c.R), nearestLevel(c.G), nearestLevel(c.B)
+	cube := NewRGBColorFrom255(float64(ansiCubeLevels[ri]), float64(ansiCubeLevels[gi]), float64(ansiCubeLevels[bi]))
+
+	// the grayscale ramp runs from 8 to 238 in steps of 10
+	avg := (to255(c.R) + to255(c.G) + to255(c.B)) / 3
+	gi2 := min(max((avg-8+5)/10, 0), 23)
+	level := float64(8 + 10*gi2)
+	gray := NewRGBColorFrom255(level, level, level)
+
+	if c.distance(gray) < c.distance(cube) {
+		return 232 + gi2
+	}
+	return 16 + 36*ri + 6*gi + bi
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
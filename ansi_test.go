@@ -0,0 +1,65 @@
+package qstr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestANSI(t *testing.T) {
+	nick := QStr("Mr ^1Anti^x444body")
+
+	var ansiList = []struct {
+		Depth    ColorDepth
+		Expected string
+	}{
+		{TrueColor, "Mr \x1b[38;2;255;0;0mAnti\x1b[0;38;2;128;128;128mbody\x1b[0m"},
+		{Color256, "Mr \x1b[38;5;196mAnti\x1b[0;38;5;244mbody\x1b[0m"},
+		{Color16, "Mr \x1b[91mAnti\x1b[0;90mbody\x1b[0m"},
+	}
+
+	for _, v := range ansiList {
+		received := nick.ANSI(WithColorDepth(v.Depth))
+		if received != v.Expected {
+			t.Errorf("Incorrect ANSI output at depth %v. Expected: %q, Got: %q.", v.Depth, v.Expected, received)
+		}
+	}
+}
+
+func TestANSIDialect(t *testing.T) {
+	d := &Dialect{Codes: []ExtCode{StyleCode("b", Bold), BackgroundHexCode("g")}}
+	nick := QStr("^bbold^gF00^2text^b^g-plain")
+
+	expected := "\x1b[1mbold\x1b[0;1;38;2;51;255;0;48;2;255;0;0mtext\x1b[0;38;2;51;255;0mplain\x1b[0m"
+	if received := nick.ANSI(WithDialect(d)); received != expected {
+		t.Errorf("Incorrect ANSI output. Expected: %q, Got: %q.", expected, received)
+	}
+}
+
+func TestANSIPlain(t *testing.T) {
+	nick := QStr("Antibody")
+	if received := nick.ANSI(); received != "Antibody" {
+		t.Errorf("Incorrect ANSI output. Expected: %q, Got: %q.", "Antibody", received)
+	}
+}
+
+func TestANSIControls(t *testing.T) {
+	nick := QStr("^1evil\x1b]0;pwned\x07\x1b[2J\u009b31m\tok")
+
+	expected := "\x1b[38;2;255;0;0mevil\ufffd]0;pwned\ufffd\ufffd[2J\ufffd31m\tok\x1b[0m"
+	if received := nick.ANSI(); received != expected {
+		t.Errorf("Incorrect ANSI output. Expected: %q, Got: %q.", expected, received)
+	}
+
+	var b strings.Builder
+	w := NewANSIWriter(&b)
+	w.Write([]byte(nick))
+	w.Close()
+	if received := b.String(); received != expected {
+		t.Errorf("Incorrect streamed ANSI output. Expected: %q, Got: %q.", expected, received)
+	}
+
+	// only the color and the reset are escape sequences
+	if received := nick.DiscordANSI(); strings.Count(received, "\x1b") != 2 || strings.ContainsRune(received, '\a') {
+		t.Errorf("Incorrect Discord output. Got: %q.", received)
+	}
+}
@@ -0,0 +1,51 @@
+package qstr
+
+import (
+	"context"
+	"io"
+)
+
+// textWriter is where rendered output is written: a strings.Builder for the
+// string forms, or an appendWriter for the Append forms.
+type textWriter interface {
+	io.Writer
+	io.StringWriter
+}
+
+// appendWriter appends everything written to it to buf
+type appendWriter struct {
+	buf []byte
+}
+
+func (w *appendWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	return len(p), nil
+}
+
+func (w *appendWriter) WriteString(s string) (int, error) {
+	w.buf = append(w.buf, s...)
+	return len(s), nil
+}
+
+// AppendHTML appends the HTML representation of s, as returned by HTML, to
+// dst and returns the extended buffer. It lets callers building large pages
+// write every nick into one buffer instead of allocating a string for each.
+func (r *Renderer) AppendHTML(dst []byte, s QStr) []byte {
+	if r.cache != nil {
+		return append(dst, r.HTML(s)...)
+	}
+	w := appendWriter{dst}
+	r.writeHTML(context.Background(), &w, s, nil)
+	return w.buf
+}
+
+// AppendANSI appends the ANSI representation of s, as returned by ANSI, to
+// dst and returns the extended buffer.
+func (r *Renderer) AppendANSI(dst []byte, s QStr) []byte {
+	if r.cache != nil {
+		return append(dst, r.ANSI(s)...)
+	}
+	w := appendWriter{dst}
+	r.writeANSI(context.Background(), &w, s)
+	return w.buf
+}
@@ -0,0 +1,50 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestAppendHTML(t *testing.T) {
+	nicks := []QStr{"^1Anti^x4afbody", "plain", "", "^^1<b>"}
+	renderers := []*Renderer{
+		NewRenderer(),
+		NewRenderer(WithTitle(), WithLinks()),
+		NewRenderer(WithCache(10)),
+	}
+
+	for _, r := range renderers {
+		for _, nick := range nicks {
+			buf := []byte("> ")
+			if received, expected := string(r.AppendHTML(buf, nick)), "> "+string(r.HTML(nick)); received != expected {
+				t.Errorf("Incorrect HTML appended for %v. Expected: %v, Got: %v.", nick, expected, received)
+			}
+			if received, expected := string(r.AppendANSI(buf, nick)), "> "+r.ANSI(nick); received != expected {
+				t.Errorf("Incorrect ANSI appended for %v. Expected: %q, Got: %q.", nick, expected, received)
+			}
+		}
+	}
+}
+
+func BenchmarkAppendHTML(b *testing.B) {
+	nicks := []QStr{"^1Anti^7body", "^x444Some^5one", "^2p^3l^4a^5y^6e^7r"}
+	r := NewRenderer()
+	var buf []byte
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = buf[:0]
+		for _, nick := range nicks {
+			buf = r.AppendHTML(buf, nick)
+		}
+	}
+}
+
+func BenchmarkHTML(b *testing.B) {
+	nicks := []QStr{"^1Anti^7body", "^x444Some^5one", "^2p^3l^4a^5y^6e^7r"}
+	r := NewRenderer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, nick := range nicks {
+			r.HTML(nick)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package qstr
+
+import (
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+)
+
+// the golden ratio conjugate, used to step around the hue circle so that
+// successive hues stay far apart
+const goldenRatioConjugate = 0.618033988749895
+
+// ColorAssigner hands out visually distinct, readable colors to players,
+// keeping each player's color stable for the lifetime of the assigner. Use
+// one per report so charts, tables, and chat excerpts color players
+// consistently.
+type ColorAssigner struct {
+	// Theme gives the lightness bounds a color must fall within to be
+	// readable
+	Theme Theme
+
+	// MinDistance is the smallest distance in RGB space allowed between
+	// two assigned colors
+	MinDistance float64
+
+	assigned map[string]RGBColor
+}
+
+// NewColorAssigner returns a ColorAssigner picking colors readable on the
+// given theme.
+func NewColorAssigner(theme Theme) *ColorAssigner {
+	return &ColorAssigner{
+		Theme:       theme,
+		MinDistance: 0.25,
+		assigned:    make(map[string]RGBColor),
+	}
+}
+
+// Assign returns the color for nick. Nicks that normalize to the same text,
+// ignoring colors, case, and invisible characters, get the same color. A
+// player's own dominant nick color is used when it is readable and distinct
+// from the colors already handed out; otherwise a color is derived from the
+// normalized nick.
+func (a *ColorAssigner) Assign(nick QStr) RGBColor {
+	key := normalizedKey(nick)
+	if c, ok := a.assigned[key]; ok {
+		return c
+	}
+
+	c, ok := dominantColor(DarkPlaces.Tokenize(nick))
+	if !ok || !a.readable(c) || !a.distinct(c) {
+		c = a.derive(key)
+	}
+	a.assigned[key] = c
+	return c
+}
+
+// AssignAll assigns colors to all of nicks at once. Nicks are assigned in
+// order of their normalized text, so the result does not depend on the
+// order of nicks.
+func (a *ColorAssigner) AssignAll(nicks []QStr) map[QStr]RGBColor {
+	sorted := append([]QStr(nil), nicks...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return normalizedKey(sorted[i]) < normalizedKey(sorted[j])
+	})
+
+	colors := make(map[QStr]RGBColor, len(nicks))
+	for _, nick := range sorted {
+		colors[nick] = a.Assign(nick)
+	}
+	return colors
+}
+
+// readable reports whether c falls within the theme's lightness bounds
+func (a *ColorAssigner) readable(c RGBColor) bool {
+	l := c.HSL().L
+	return a.Theme.MinLightness <= l && l <= a.Theme.MaxLightness
+}
+
+// distinct reports whether c is far enough from every assigned color
+func (a *ColorAssigner) distinct(c RGBColor) bool {
+	for _, o := range a.assigned {
+		if c.distance(o) < a.MinDistance {
+			return false
+		}
+	}
+	return true
+}
+
+// derive returns a readable color seeded by key, stepping around the hue
+// circle until it finds one distinct from the colors already assigned. If
+// none is found, the first candidate is used.
+func (a *ColorAssigner) derive(key string) RGBColor {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	hue := float64(h.Sum32()) / math.MaxUint32
+
+	l := (a.Theme.MinLightness + a.Theme.MaxLightness) / 2
+	first := HSLColor{hue, 0.75, l}
+	for i := 0; i < 36; i++ {
+		candidate := HSLColor{math.Mod(hue+float64(i)*goldenRatioConjugate, 1.0), 0.75, l}
+		if c := candidate.RGB(); a.distinct(c) {
+			return c
+		}
+	}
+	return first.RGB()
+}
+
+// DominantColor returns the color covering the most visible characters of
+// s, not counting white space, which makes a good accent color for the
+// player. Basic color codes take their colors from the palette if one is
+// given, and from XonoticPalette otherwise. Text before the first code
+// counts as ^7, as does a value without any visible text. Ties go to the
+// color that reached the count first.
+func (s *QStr) DominantColor(palette ...Palette) RGBColor {
+	p := &XonoticPalette
+	if len(palette) > 0 {
+		p = &palette[0]
+	}
+
+	segments := DarkPlaces.Tokenize(*s)
+	for i := range segments {
+		if segments[i].Code == "" {
+			segments[i].Code = resetCode
+		}
+		segments[i].Color = p.color(segments[i])
+	}
+	if c, ok := dominantColor(segments); ok {
+		return c
+	}
+	return p[7]
+}
+
+// dominantColor returns the color covering the most visible characters in
+// segments. The second return value is false if none of the text is
+// colored.
+func dominantColor(segments []Segment) (RGBColor, bool) {
+	counts := make(map[RGBColor]int)
+	best, bestCount := RGBColor{}, 0
+	for _, seg := range segments {
+		if seg.Code == "" {
+			continue
+		}
+		counts[seg.Color] += graphemeCount(strings.TrimSpace(seg.Text))
+		if n := counts[seg.Color]; n > bestCount {
+			best, bestCount = seg.Color, n
+		}
+	}
+	return best, bestCount > 0
+}
+
+// normalizedKey returns the text identifying a player regardless of colors,
+// case, glyph choice, invisible characters, and surrounding whitespace.
+func normalizedKey(s QStr) string {
+	return strippedKey(s.Stripped())
+}
+
+// strippedKey returns the normalizedKey of a value already stripped of its
+// color codes.
+func strippedKey(stripped string) string {
+	return strings.ToLower(strings.TrimSpace(removeHidden(decodeString(stripped))))
+}
@@ -0,0 +1,65 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestColorAssigner(t *testing.T) {
+	a := NewColorAssigner(DarkTheme)
+
+	// a readable dominant color is reused
+	red := a.Assign("^1Anti^7b")
+	if red != (RGBColor{1, 0, 0}) {
+		t.Errorf("Incorrect color for a red nick. Expected: %v, Got: %v.", RGBColor{1, 0, 0}, red)
+	}
+
+	// the same player is recognized across recolorings
+	if c := a.Assign("^x400ANTI^3B"); c != red {
+		t.Errorf("Incorrect color for a recolored nick. Expected: %v, Got: %v.", red, c)
+	}
+
+	// a second red player gets a different, distinct color
+	other := a.Assign("^1Someone")
+	if other.distance(red) < a.MinDistance {
+		t.Errorf("Colors are not distinct. Got: %v and %v.", red, other)
+	}
+
+	// unreadable colors are replaced
+	dark := a.Assign("^x111Shadow")
+	if l := dark.HSL().L; l < DarkTheme.MinLightness {
+		t.Errorf("Incorrect lightness for a dark nick. Expected: >= %v, Got: %v.", DarkTheme.MinLightness, l)
+	}
+}
+
+func TestAssignAllOrder(t *testing.T) {
+	nicks := []QStr{"^1alpha", "^1beta", "gamma", "^x111delta"}
+	reversed := []QStr{"^x111delta", "gamma", "^1beta", "^1alpha"}
+
+	first := NewColorAssigner(DarkTheme).AssignAll(nicks)
+	second := NewColorAssigner(DarkTheme).AssignAll(reversed)
+	for _, nick := range nicks {
+		if first[nick] != second[nick] {
+			t.Errorf("Color for %v depends on input order. Got: %v and %v.", nick, first[nick], second[nick])
+		}
+	}
+}
+
+func TestDominantColor(t *testing.T) {
+	var dominantList = []struct {
+		Input    QStr
+		Palette  []Palette
+		Expected RGBColor
+	}{
+		{"^1[CLAN]^x4afAntibody", nil, HexToRGB("4", "a", "f")},
+		{"^1[CLAN]       ^x4afAb", nil, RGBColor{1, 0, 0}},
+		{"ab^1cd", nil, XonoticPalette[7]},
+		{"", nil, XonoticPalette[7]},
+		{"^1Anti", []Palette{Quake3Palette}, Quake3Palette[1]},
+	}
+
+	for _, v := range dominantList {
+		if received := v.Input.DominantColor(v.Palette...); received != v.Expected {
+			t.Errorf("Incorrect dominant color of %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package qstr
+
+import (
+	"html/template"
+)
+
+// HTMLMap returns the HTML representation of each of nicks, keyed by nick.
+// Each distinct nick is converted only once, so long lists with many
+// repeated names stay cheap.
+func HTMLMap(nicks []QStr, opts ...Option) map[QStr]template.HTML {
+	return NewRenderer(opts...).HTMLMap(nicks)
+}
+
+// HTMLMap returns the HTML representation of each of nicks, keyed by nick.
+// Each distinct nick is converted only once.
+func (r *Renderer) HTMLMap(nicks []QStr) map[QStr]template.HTML {
+	res := make(map[QStr]template.HTML)
+	for _, nick := range nicks {
+		if _, ok := res[nick]; !ok {
+			res[nick] = r.HTML(nick)
+		}
+	}
+	return res
+}
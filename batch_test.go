@@ -0,0 +1,31 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestHTMLMap(t *testing.T) {
+	nicks := []QStr{"^1Anti^7body", "^2other", "^1Anti^7body", "plain"}
+	received := HTMLMap(nicks)
+
+	if len(received) != 3 {
+		t.Errorf("Incorrect number of entries. Expected: %v, Got: %v.", 3, len(received))
+	}
+	for _, nick := range nicks {
+		if expected := nick.HTML(); received[nick] != expected {
+			t.Errorf("Incorrect HTML for %v. Expected: %v, Got: %v.", nick, expected, received[nick])
+		}
+	}
+}
+
+func BenchmarkHTMLMap(b *testing.B) {
+	nicks := make([]QStr, 0, 5000)
+	for i := 0; i < 5000; i++ {
+		nicks = append(nicks, []QStr{"^1Anti^7body", "^x444Some^5one", "^2p^3l^4a^5y^6e^7r"}[i%3])
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		HTMLMap(nicks)
+	}
+}
@@ -0,0 +1,49 @@
+package qstr
+
+import (
+	"strings"
+)
+
+// BBCode returns s with its color codes converted into BBCode [color] tags,
+// for pasting colored nicks into forums. Options may be given to alter the
+// output; see Renderer.
+func (s *QStr) BBCode(opts ...Option) string {
+	return NewRenderer(opts...).BBCode(*s)
+}
+
+// BBCode returns s with its color codes converted into BBCode [color] tags.
+// Hex colors are capped to the theme's lightness bounds, as in HTML output,
+// and bold, italic, and underlined text from dialect codes is wrapped in [b],
+// [i], and [u] tags. Tags are never nested across segments. Opening brackets
+// in the text are followed by an empty [b][/b] pair, which forums render as
+// nothing, so the text can't form tags of its own.
+func (r *Renderer) BBCode(s QStr) string {
+	var b strings.Builder
+	for _, seg := range r.dialect.Tokenize(s) {
+		var closing []string
+		if seg.Code != "" {
+			c := r.color(seg)
+			if seg.Code.IsHex() {
+				c = r.capLightness(c)
+			}
+			b.WriteString("[color=" + c.Hex() + "]")
+			closing = append(closing, "[/color]")
+		}
+		for _, tag := range []struct {
+			style Style
+			name  string
+		}{{Bold, "b"}, {Italic, "i"}, {Underline, "u"}} {
+			if seg.Style.Has(tag.style) {
+				b.WriteString("[" + tag.name + "]")
+				closing = append(closing, "[/"+tag.name+"]")
+			}
+		}
+
+		b.WriteString(strings.ReplaceAll(r.text(seg.Text), "[", "[[b][/b]"))
+
+		for i := len(closing) - 1; i >= 0; i-- {
+			b.WriteString(closing[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,25 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestBBCode(t *testing.T) {
+	var bbcodeList = []struct {
+		Input    QStr
+		Options  []Option
+		Expected string
+	}{
+		{"^1Anti^x444body", nil, "[color=#ff0000]Anti[/color][color=#808080]body[/color]"},
+		{"[X]^5Anti", nil, "[[b][/b]X][color=#33ffff]Anti[/color]"},
+		{"^1[color=#000]", nil, "[color=#ff0000][[b][/b]color=#000][/color]"},
+		{"^bAnti^1body", []Option{WithDialect(&Dialect{Codes: []ExtCode{StyleCode("b", Bold)}})}, "[b]Anti[/b][color=#ff0000][b]body[/b][/color]"},
+	}
+
+	for _, v := range bbcodeList {
+		received := v.Input.BBCode(v.Options...)
+		if received != v.Expected {
+			t.Errorf("Incorrect BBCode for %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
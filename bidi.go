@@ -0,0 +1,26 @@
+package qstr
+
+import (
+	"strings"
+)
+
+// isBidiControl reports whether c is an explicit bidirectional formatting
+// character: an embedding, override, or isolate, or the character that
+// terminates one.
+func isBidiControl(c rune) bool {
+	return ('\u202a' <= c && c <= '\u202e') || ('\u2066' <= c && c <= '\u2069')
+}
+
+// removeBidiControls returns text without any explicit bidirectional
+// formatting characters.
+func removeBidiControls(text string) string {
+	if !strings.ContainsFunc(text, isBidiControl) {
+		return text
+	}
+	return strings.Map(func(c rune) rune {
+		if isBidiControl(c) {
+			return -1
+		}
+		return c
+	}, text)
+}
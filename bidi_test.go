@@ -0,0 +1,16 @@
+package qstr
+
+import (
+	"html/template"
+	"testing"
+)
+
+func TestBidiIsolation(t *testing.T) {
+	nick := QStr("^1\u202eydobitnA\u202c")
+	expected := template.HTML("<bdi><span style='color:rgb(255,0,0)'>ydobitnA</span></bdi>")
+	received := nick.HTML(WithBidiIsolation())
+
+	if received != expected {
+		t.Errorf("Incorrect HTML value returned. Expected: %v, Got: %v.", expected, received)
+	}
+}
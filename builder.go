@@ -0,0 +1,76 @@
+package qstr
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// Builder composes a QStr from text and colors, writing each color code only
+// when the text that follows it needs it. The zero value is ready to use.
+// Methods return the Builder so calls can be chained:
+//
+//	var b qstr.Builder
+//	b.PaletteColor(1).Text("[DoD] ").Color(c).Text("Antibody")
+type Builder struct {
+	b       strings.Builder
+	current Code
+	pending Code
+}
+
+// Text appends text in the current color. Carets in text are escaped, so it
+// is shown exactly as given.
+func (b *Builder) Text(text string) *Builder {
+	if text == "" {
+		return b
+	}
+	if b.pending != b.current {
+		b.b.WriteString(string(b.pending))
+		b.current = b.pending
+	}
+	b.b.WriteString(string(Escape(text)))
+	return b
+}
+
+// Color sets the color of the text that follows to the ^xNNN code nearest
+// to c.
+func (b *Builder) Color(c RGBColor) *Builder {
+	return b.Code(HexCode(c))
+}
+
+// PaletteColor sets the color of the text that follows to the basic color
+// code ^i. It panics if i is not in the range [0, 9].
+func (b *Builder) PaletteColor(i int) *Builder {
+	if i < 0 || i >= len(Palette{}) {
+		panic(fmt.Sprintf("qstr: palette index %d out of range", i))
+	}
+	return b.Code(Code(fmt.Sprintf("^%d", i)))
+}
+
+// Code sets the color of the text that follows to that of code.
+func (b *Builder) Code(code Code) *Builder {
+	b.pending = code
+	return b
+}
+
+// Reset sets the color of the text that follows back to the engine's default
+// text color.
+func (b *Builder) Reset() *Builder {
+	if b.current == "" {
+		// nothing has been colored yet
+		return b.Code("")
+	}
+	return b.Code(resetCode)
+}
+
+// QStr returns the composed value.
+func (b *Builder) QStr() QStr {
+	return QStr(b.b.String())
+}
+
+// HTML returns the HTML representation of the composed value. Options may be
+// given to alter the output; see Renderer.
+func (b *Builder) HTML(opts ...Option) template.HTML {
+	s := b.QStr()
+	return s.HTML(opts...)
+}
@@ -0,0 +1,52 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	var b Builder
+	b.PaletteColor(1).Text("[DoD] ").Color(NewRGBColorFrom255(68, 170, 255)).Text("Anti").Text("body")
+	b.Reset().Text(" wins")
+
+	expected := QStr("^1[DoD] ^x4AFAntibody^7 wins")
+	if received := b.QStr(); received != expected {
+		t.Errorf("Incorrect built QStr. Expected: %v, Got: %v.", expected, received)
+	}
+	if received := b.HTML(); received != expected.HTML() {
+		t.Errorf("Incorrect built HTML. Expected: %v, Got: %v.", expected.HTML(), received)
+	}
+}
+
+func TestBuilderSkipsUnusedCodes(t *testing.T) {
+	var b Builder
+	b.Reset().Text("plain ").PaletteColor(2).PaletteColor(3).Text("").Text("yellow").PaletteColor(3).Text("!").PaletteColor(4)
+
+	expected := QStr("plain ^3yellow!")
+	if received := b.QStr(); received != expected {
+		t.Errorf("Incorrect built QStr. Expected: %v, Got: %v.", expected, received)
+	}
+}
+
+func TestBuilderEscapesCarets(t *testing.T) {
+	var b Builder
+	b.Text("^1Anti").PaletteColor(2).Text("body^")
+
+	expected := QStr("^^1Anti^2body^^")
+	if received := b.QStr(); received != expected {
+		t.Errorf("Incorrect built QStr. Expected: %v, Got: %v.", expected, received)
+	}
+	if received := expected.Stripped(); received != "^1Antibody^" {
+		t.Errorf("Incorrect stripping applied to %v. Expected: %v, Got: %v.", expected, "^1Antibody^", received)
+	}
+}
+
+func TestBuilderPaletteRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Incorrect handling of palette index 10. Expected a panic.")
+		}
+	}()
+	var b Builder
+	b.PaletteColor(10)
+}
@@ -0,0 +1,23 @@
+package qstr
+
+// StripBytes returns the text of b without its color codes, as Stripped
+// does for a QStr. It saves servers reading names from game packets from
+// converting every one of them to a string first.
+func StripBytes(b []byte) []byte {
+	return AppendStripBytes(make([]byte, 0, len(b)), b)
+}
+
+// AppendStripBytes appends the text of b without its color codes, as
+// returned by StripBytes, to dst and returns the extended buffer.
+func AppendStripBytes(dst, b []byte) []byte {
+	stripRuns(b, func(text []byte) {
+		dst = append(dst, text...)
+	})
+	return dst
+}
+
+// TokenizeBytes breaks b into its colored segments, as Tokenize does for a
+// QStr. The segments share a single copy of b, however many there are.
+func TokenizeBytes(b []byte) []Segment {
+	return DarkPlaces.Tokenize(QStr(b))
+}
@@ -0,0 +1,33 @@
+package qstr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBytesVariants(t *testing.T) {
+	for _, v := range TestVectors() {
+		b := []byte(v.Input)
+		if received := string(StripBytes(b)); received != v.Stripped {
+			t.Errorf("Incorrect stripping applied to %v. Expected: %v, Got: %v.", v.Input, v.Stripped, received)
+		}
+		if received, expected := TokenizeBytes(b), v.Input.Tokenize(); !reflect.DeepEqual(received, expected) {
+			t.Errorf("Incorrect tokenization of %v. Expected: %+v, Got: %+v.", v.Input, expected, received)
+		}
+	}
+
+	if received := string(AppendStripBytes([]byte("> "), []byte("^1Anti^^1"))); received != "> Anti^1" {
+		t.Errorf("Incorrect stripping appended. Expected: %v, Got: %v.", "> Anti^1", received)
+	}
+}
+
+func BenchmarkStripBytes(b *testing.B) {
+	nicks := [][]byte{[]byte("^1Anti^7body"), []byte("^x444Some^5one"), []byte("^2p^3l^4a^5y^6e^7r"), []byte("plain")}
+	var buf []byte
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, nick := range nicks {
+			buf = AppendStripBytes(buf[:0], nick)
+		}
+	}
+}
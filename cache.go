@@ -0,0 +1,102 @@
+package qstr
+
+import (
+	"container/list"
+	"sync"
+)
+
+// the outputs held by a renderCache
+const (
+	cacheHTML = iota
+	cacheANSI
+)
+
+// cacheKey identifies a rendered output in a renderCache
+type cacheKey struct {
+	kind int
+	s    QStr
+}
+
+// cacheEntry is an output held by a renderCache
+type cacheEntry struct {
+	key cacheKey
+	out string
+}
+
+// renderCache is a least recently used cache of rendered outputs. It is safe
+// for concurrent use.
+type renderCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[cacheKey]*list.Element
+}
+
+func newRenderCache(size int) *renderCache {
+	return &renderCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[cacheKey]*list.Element),
+	}
+}
+
+// get returns the output stored under key, marking it as recently used.
+func (c *renderCache) get(key cacheKey) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*cacheEntry).out, true
+}
+
+// put stores out under key, evicting the least recently used output if the
+// cache is full.
+func (c *renderCache) put(key cacheKey, out string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*cacheEntry).out = out
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key, out})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// WithCache keeps the HTML and ANSI output of the n most recently rendered
+// strings, so that rendering the same strings over and over, such as the
+// nicks on a leaderboard, skips parsing and assembling the output. The cache
+// belongs to the Renderer, so it only helps when one Renderer is kept and
+// reused. A size of zero or less disables caching.
+func WithCache(n int) Option {
+	return func(r *Renderer) {
+		r.cache = nil
+		if n > 0 {
+			r.cache = newRenderCache(n)
+		}
+	}
+}
+
+// cached returns the output of kind for s from the cache, calling render and
+// storing its result on a miss. Without a cache it just calls render.
+func (r *Renderer) cached(kind int, s QStr, render func() string) string {
+	if r.cache == nil {
+		return render()
+	}
+	key := cacheKey{kind, s}
+	if out, ok := r.cache.get(key); ok {
+		return out
+	}
+	out := render()
+	r.cache.put(key, out)
+	return out
+}
@@ -0,0 +1,45 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestRendererCache(t *testing.T) {
+	plain := NewRenderer()
+	r := NewRenderer(WithCache(2))
+	nicks := []QStr{"^1Anti^x4afbody", "^2Some^7one", "^1Anti^x4afbody", "^3third", "^2Some^7one"}
+	for _, nick := range nicks {
+		if expected, received := plain.HTML(nick), r.HTML(nick); received != expected {
+			t.Errorf("Incorrect cached HTML of %v. Expected: %v, Got: %v.", nick, expected, received)
+		}
+		if expected, received := plain.ANSI(nick), r.ANSI(nick); received != expected {
+			t.Errorf("Incorrect cached ANSI of %v. Expected: %q, Got: %q.", nick, expected, received)
+		}
+	}
+
+	if n := r.cache.order.Len(); n != 2 {
+		t.Errorf("Incorrect cache size. Expected: %v, Got: %v.", 2, n)
+	}
+	// only the two outputs of the last nick are left
+	if _, ok := r.cache.get(cacheKey{cacheANSI, "^2Some^7one"}); !ok {
+		t.Errorf("Incorrect cache contents. Expected the ANSI of %v to be cached.", "^2Some^7one")
+	}
+	if _, ok := r.cache.get(cacheKey{cacheHTML, "^1Anti^x4afbody"}); ok {
+		t.Errorf("Incorrect cache contents. Expected the HTML of %v to be evicted.", "^1Anti^x4afbody")
+	}
+
+	if r := NewRenderer(WithCache(0)); r.cache != nil {
+		t.Errorf("Incorrect cache for size 0. Expected: nil, Got: %v.", r.cache)
+	}
+}
+
+func BenchmarkRendererCache(b *testing.B) {
+	nicks := []QStr{"^1Anti^7body", "^x444Some^5one", "^2p^3l^4a^5y^6e^7r"}
+	r := NewRenderer(WithCache(100))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, nick := range nicks {
+			r.HTML(nick)
+		}
+	}
+}
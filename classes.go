@@ -0,0 +1,119 @@
+package qstr
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// classSpan returns the opening span for a styled segment in the class-based
+// format described by WithClassesOnly.
+func (r *Renderer) classSpan(seg Segment) string {
+	classes := make([]string, 0, 6)
+	if seg.Code != "" {
+		classes = append(classes, r.prefix+colorClass(seg.Code))
+	}
+	if seg.HasBackground {
+		classes = append(classes, r.prefix+"bg-"+hexClass(seg.Background))
+	}
+	for _, st := range styleClasses {
+		if seg.Style.Has(st.style) {
+			classes = append(classes, r.prefix+st.name)
+		}
+	}
+	return fmt.Sprintf("<span class=\"%s\">", html.EscapeString(strings.Join(classes, " ")))
+}
+
+// styleClasses names the class of each formatting attribute
+var styleClasses = []struct {
+	style Style
+	name  string
+}{
+	{Bold, "bold"},
+	{Italic, "italic"},
+	{Underline, "underline"},
+	{Blink, "blink"},
+}
+
+// colorClass returns the class name, without prefix, for a color code
+func colorClass(code Code) string {
+	if !code.IsHex() {
+		return string(code[1:])
+	}
+	return strings.ToLower(string(code[1:]))
+}
+
+// hexClass returns the class name, without prefix, for a background color
+func hexClass(c RGBColor) string {
+	return fmt.Sprintf("x%x%x%x", to15(c.R), to15(c.G), to15(c.B))
+}
+
+// Stylesheet returns the CSS rules for the classes written by the renderer
+// when WithClassPrefix and WithClassesOnly are in effect: one rule for each
+// palette color and formatting attribute, plus one for each hex color and
+// background used in nicks. The rules follow the renderer's theme and
+// background mode. Without WithClassesOnly, only the palette rules apply.
+func (r *Renderer) Stylesheet(nicks ...QStr) string {
+	var b strings.Builder
+
+	for i, c := range r.theme.Palette {
+		r.writeColorRule(&b, strconv.Itoa(i), c, false)
+	}
+
+	hexes := make(map[string]RGBColor)
+	backgrounds := make(map[string]RGBColor)
+	for _, nick := range nicks {
+		for _, seg := range r.dialect.Tokenize(nick) {
+			if seg.Code.IsHex() {
+				hexes[colorClass(seg.Code)] = seg.Color
+			}
+			if seg.HasBackground {
+				backgrounds[hexClass(seg.Background)] = seg.Background
+			}
+		}
+	}
+	for _, name := range sortedKeys(hexes) {
+		r.writeColorRule(&b, name, hexes[name], true)
+	}
+	for _, name := range sortedKeys(backgrounds) {
+		c := backgrounds[name]
+		fmt.Fprintf(&b, ".%sbg-%s{background-color:%s}\n", r.prefix, name, c.Hex())
+	}
+
+	fmt.Fprintf(&b, ".%sbold{font-weight:bold}\n", r.prefix)
+	fmt.Fprintf(&b, ".%sitalic{font-style:italic}\n", r.prefix)
+	fmt.Fprintf(&b, ".%sunderline{text-decoration:underline}\n", r.prefix)
+	fmt.Fprintf(&b, ".%sblink{text-decoration:blink}\n", r.prefix)
+	fmt.Fprintf(&b, ".%sunderline.%sblink{text-decoration:underline blink}\n", r.prefix, r.prefix)
+
+	return b.String()
+}
+
+// writeColorRule writes the rule for a color class. Hex colors are capped to
+// the theme's lightness bounds in the foreground-only mode.
+func (r *Renderer) writeColorRule(b *strings.Builder, name string, c RGBColor, capped bool) {
+	contrast := c.readableForeground()
+	switch r.background {
+	case BackgroundOnly:
+		fmt.Fprintf(b, ".%s%s{background-color:%s;color:%s}\n", r.prefix, name, c.Hex(), contrast.Hex())
+	case ForegroundAndBackground:
+		fmt.Fprintf(b, ".%s%s{color:%s;background-color:%s}\n", r.prefix, name, c.Hex(), contrast.Hex())
+	default:
+		if capped {
+			c = r.capLightness(c)
+		}
+		fmt.Fprintf(b, ".%s%s{color:%s}\n", r.prefix, name, c.Hex())
+	}
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys(m map[string]RGBColor) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,44 @@
+package qstr
+
+import (
+	"html/template"
+	"testing"
+)
+
+func TestClassHTML(t *testing.T) {
+	d := &Dialect{Codes: []ExtCode{BackgroundHexCode("b"), StyleCode("u", Underline)}}
+	nick := QStr("^3Anti^bF00^u^xABCbody^7")
+
+	expected := template.HTML("<span class=\"qstr-3\">Anti<span class=\"qstr-xabc qstr-bg-xf00 qstr-underline\">body</span></span>")
+	received := nick.HTML(WithDialect(d), WithClassPrefix("qstr-"), WithClassesOnly())
+	if received != expected {
+		t.Errorf("Incorrect HTML value returned. Expected: %v, Got: %v.", expected, received)
+	}
+}
+
+func TestStylesheet(t *testing.T) {
+	palette := Palette{}
+	r := NewRenderer(WithClassPrefix("q-"), WithClassesOnly(), WithPalette(palette))
+
+	expected := ".q-0{color:#000000}\n" +
+		".q-1{color:#000000}\n" +
+		".q-2{color:#000000}\n" +
+		".q-3{color:#000000}\n" +
+		".q-4{color:#000000}\n" +
+		".q-5{color:#000000}\n" +
+		".q-6{color:#000000}\n" +
+		".q-7{color:#000000}\n" +
+		".q-8{color:#000000}\n" +
+		".q-9{color:#000000}\n" +
+		".q-x444{color:#808080}\n" +
+		".q-xf00{color:#ff0000}\n" +
+		".q-bold{font-weight:bold}\n" +
+		".q-italic{font-style:italic}\n" +
+		".q-underline{text-decoration:underline}\n" +
+		".q-blink{text-decoration:blink}\n" +
+		".q-underline.q-blink{text-decoration:underline blink}\n"
+	received := r.Stylesheet("^xF00Anti^x444body", "^xf00Anti")
+	if received != expected {
+		t.Errorf("Incorrect stylesheet. Expected: %v, Got: %v.", expected, received)
+	}
+}
@@ -0,0 +1,124 @@
+// Command qstr strips, converts, normalizes, and validates Quake-style
+// strings with color codes, for use in scripts and log processing.
+//
+// Usage:
+//
+//	qstr strip [string ...]
+//	qstr convert -to html|ansi|irc|bbcode|markdown|pango|rtf [string ...]
+//	qstr normalize [string ...]
+//	qstr validate [string ...]
+//
+// Each string given as an argument is processed in turn. Without arguments,
+// each line of standard input is processed instead, so qstr can be used as a
+// filter. validate prints nothing for valid strings, and reports each string
+// holding a malformed color code on standard error, exiting with status 1.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/antzucaro/qstr"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+const usage = `usage:
+  qstr strip [string ...]
+  qstr convert -to html|ansi|irc|bbcode|markdown|pango|rtf [string ...]
+  qstr normalize [string ...]
+  qstr validate [string ...]
+`
+
+// converters are the output formats of the convert subcommand
+var converters = map[string]func(s qstr.QStr) string{
+	"html":     func(s qstr.QStr) string { return string(s.HTML()) },
+	"ansi":     func(s qstr.QStr) string { return s.ANSI() },
+	"irc":      func(s qstr.QStr) string { return s.IRC() },
+	"bbcode":   func(s qstr.QStr) string { return s.BBCode() },
+	"markdown": func(s qstr.QStr) string { return s.Markdown() },
+	"pango":    func(s qstr.QStr) string { return s.Pango() },
+	"rtf":      func(s qstr.QStr) string { return s.RTF() },
+}
+
+// run runs the command with the given arguments and returns its exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("qstr "+args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	to := fs.String("to", "html", "output format for convert: html, ansi, irc, bbcode, markdown, pango, or rtf")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	// process returns the output for a string, or an error for an invalid
+	// one
+	var process func(s qstr.QStr) (string, error)
+	switch args[0] {
+	case "strip":
+		process = func(s qstr.QStr) (string, error) {
+			return s.Stripped(), nil
+		}
+	case "convert":
+		convert, ok := converters[*to]
+		if !ok {
+			fmt.Fprintf(stderr, "qstr: unknown format %q\n%s", *to, usage)
+			return 2
+		}
+		process = func(s qstr.QStr) (string, error) {
+			return convert(s), nil
+		}
+	case "normalize":
+		process = func(s qstr.QStr) (string, error) {
+			return string(s.Normalize()), nil
+		}
+	case "validate":
+		process = func(s qstr.QStr) (string, error) {
+			_, err := qstr.ParseStrict(string(s))
+			return "", err
+		}
+	default:
+		fmt.Fprintf(stderr, "qstr: unknown command %q\n%s", args[0], usage)
+		return 2
+	}
+
+	status := 0
+	handle := func(line string) {
+		out, err := process(qstr.QStr(line))
+		if err != nil {
+			fmt.Fprintf(stderr, "qstr: %q: %v\n", line, err)
+			status = 1
+			return
+		}
+		if args[0] != "validate" {
+			fmt.Fprintln(stdout, out)
+		}
+	}
+
+	if fs.NArg() > 0 {
+		for _, arg := range fs.Args() {
+			handle(arg)
+		}
+		return status
+	}
+
+	scanner := bufio.NewScanner(stdin)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		handle(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(stderr, "qstr: %v\n", err)
+		return 1
+	}
+	return status
+}
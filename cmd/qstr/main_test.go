@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	var runList = []struct {
+		Args   []string
+		Stdin  string
+		Stdout string
+		Status int
+	}{
+		{[]string{"strip", "^1Anti^x4afbody", "^^1"}, "", "Antibody\n^1\n", 0},
+		{[]string{"strip"}, "^1Anti\n^2body\n", "Anti\nbody\n", 0},
+		{[]string{"convert", "-to", "irc", "^1Anti"}, "", "\x0304Anti\n", 0},
+		{[]string{"convert", "^1Anti"}, "", "<span style='color:rgb(255,0,0)'>Anti</span>\n", 0},
+		{[]string{"convert", "-to", "pdf", "^1Anti"}, "", "", 2},
+		{[]string{"normalize", "^1^1Anti^1body"}, "", "^1Antibody\n", 0},
+		{[]string{"validate"}, "^1Anti\n^x4afbody\n", "", 0},
+		{[]string{"validate", "^1Anti", "^x1Gbody"}, "", "", 1},
+		{[]string{"frobnicate"}, "", "", 2},
+		{nil, "", "", 2},
+	}
+
+	for _, v := range runList {
+		var stdout, stderr bytes.Buffer
+		status := run(v.Args, strings.NewReader(v.Stdin), &stdout, &stderr)
+		if status != v.Status || stdout.String() != v.Stdout {
+			t.Errorf("Incorrect output of %v. Expected: %q (%v), Got: %q (%v).", v.Args, v.Stdout, v.Status, stdout.String(), status)
+		}
+		if (status != 0) != (stderr.Len() > 0) {
+			t.Errorf("Incorrect errors of %v. Got: %q.", v.Args, stderr.String())
+		}
+	}
+}
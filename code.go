@@ -0,0 +1,229 @@
+package qstr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidCode is returned by ParseCode for text that is not a color code.
+var ErrInvalidCode = errors.New("qstr: invalid color code")
+
+// Code is a basic color code, either ^N, which selects a palette color, or
+// ^xNNN, which gives a color as three hexadecimal digits.
+type Code string
+
+// ParseCode returns the Code spelled by s, which must consist of exactly one
+// color code.
+func ParseCode(s string) (Code, error) {
+	if n := basicCodeLen(s); n == 0 || n != len(s) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCode, s)
+	}
+	return Code(s), nil
+}
+
+// SyntaxError is returned by ParseStrict for a malformed color code.
+type SyntaxError struct {
+	// Offset is the byte offset of the malformed code.
+	Offset int
+
+	// Text is the malformed code as written.
+	Text string
+}
+
+// Error describes the malformed code and where it is.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("qstr: malformed color code %q at offset %d", e.Text, e.Offset)
+}
+
+// Unwrap returns ErrInvalidCode, so that errors.Is matches it.
+func (e *SyntaxError) Unwrap() error {
+	return ErrInvalidCode
+}
+
+// ParseStrict returns s as a QStr if all of its carets are part of a color
+// code or stand before text that can't be mistaken for one. A hex code cut
+// short, such as ^x1G, or a trailing caret is rejected with a *SyntaxError
+// giving the byte offset of the first such problem. Use it to validate
+// names where they are entered rather than have them render oddly later.
+func ParseStrict(s string) (QStr, error) {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '^' {
+			continue
+		}
+		rest := s[i+1:]
+		if strings.HasPrefix(rest, "^") {
+			i++
+			continue
+		}
+		if n := colorCodeLen(rest); n > 0 {
+			i += n
+			continue
+		}
+		if n, ok := DarkPlaces.malformedCodeLen(rest); ok {
+			return "", &SyntaxError{Offset: i, Text: s[i : i+1+n]}
+		}
+	}
+	return QStr(s), nil
+}
+
+// HexCode returns the ^xNNN code nearest to c.
+func HexCode(c RGBColor) Code {
+	return Code(fmt.Sprintf("^x%X%X%X", to15(c.R), to15(c.G), to15(c.B)))
+}
+
+// IsHex reports whether c is of the form ^xNNN.
+func (c Code) IsHex() bool {
+	return len(c) == 5
+}
+
+// Color returns the color selected by c, taking the colors of ^N codes from
+// p. It returns black for an empty or invalid code.
+func (c Code) Color(p *Palette) RGBColor {
+	switch {
+	case basicCodeLen(string(c)) != len(c) || len(c) == 0:
+		return RGBColor{}
+	case c.IsHex():
+		rgb, _ := ParseHex(string(c[2:]))
+		return rgb
+	default:
+		return p[c[1]-'0']
+	}
+}
+
+// String returns the code as it is written.
+func (c Code) String() string {
+	return string(c)
+}
+
+// CodePosition is a color code found in a QStr, as returned by
+// CodePositions.
+type CodePosition struct {
+	Code Code
+
+	// Color is the color selected by Code, with ^N colors taken from
+	// XonoticPalette or the palette passed to Palette.CodePositions.
+	Color RGBColor
+
+	// Offset is the byte offset of the code in the raw value.
+	Offset int
+
+	// Index is the number of visible characters before the code, counted
+	// as in VisibleLen.
+	Index int
+}
+
+// Colors returns the color of each color code in s, in order of appearance.
+// Basic codes take their colors from XonoticPalette; see Palette.Colors for
+// other palettes.
+func (s *QStr) Colors() []RGBColor {
+	return XonoticPalette.Colors(*s)
+}
+
+// Colors is like QStr.Colors, but takes the colors of basic codes from p.
+func (p *Palette) Colors(s QStr) []RGBColor {
+	positions := p.CodePositions(s)
+	colors := make([]RGBColor, len(positions))
+	for i, p := range positions {
+		colors[i] = p.Color
+	}
+	return colors
+}
+
+// CodePositions returns each color code in s in order of appearance, along
+// with where it appears.
+func (s *QStr) CodePositions() []CodePosition {
+	return XonoticPalette.CodePositions(*s)
+}
+
+// CodePositions is like QStr.CodePositions, but takes the colors of basic
+// codes from p.
+func (p *Palette) CodePositions(s QStr) []CodePosition {
+	raw := string(s)
+	locs := findCodes(raw)
+	positions := make([]CodePosition, 0, len(locs))
+
+	index, prev := 0, 0
+	for _, loc := range locs {
+		index += graphemeCount(unescape(raw[prev:loc[0]]))
+		prev = loc[1]
+
+		code := Code(raw[loc[0]:loc[1]])
+		positions = append(positions, CodePosition{
+			Code:   code,
+			Color:  code.Color(p),
+			Offset: loc[0],
+			Index:  index,
+		})
+	}
+	return positions
+}
+
+// Normalize returns s without its redundant color codes: codes followed
+// directly by another code, codes at the end of s, and codes setting the
+// color already in effect, with hex codes compared regardless of case. The
+// text and the colors it is shown in are unchanged.
+func (s *QStr) Normalize() QStr {
+	raw := string(*s)
+
+	var b strings.Builder
+	b.Grow(len(raw))
+	var active, pending Code
+	for i := 0; i < len(raw); {
+		if n := basicCodeLen(raw[i:]); n > 0 {
+			pending = Code(raw[i : i+n])
+			i += n
+			continue
+		}
+
+		if !strings.EqualFold(string(pending), string(active)) {
+			b.WriteString(string(pending))
+			active = pending
+		}
+		// an escape is copied whole so its second caret never starts a code
+		n := 1
+		if strings.HasPrefix(raw[i:], "^^") {
+			n = 2
+		}
+		b.WriteString(raw[i : i+n])
+		i += n
+	}
+	return QStr(b.String())
+}
+
+// Canonical returns a deterministic form of s suitable for use as a unique
+// key, so that values that look the same map to the same key. Every code is
+// written as an uppercase ^xNNN code, with ^N colors taken from palette;
+// codes that change nothing visible, including ones coloring only white
+// space and ones selecting the color of ^7 at the start, are dropped; font
+// glyphs are translated with XonoticDecodeKey; and every caret is escaped.
+func (s *QStr) Canonical(palette Palette) QStr {
+	raw := string(*s)
+
+	var b strings.Builder
+	b.Grow(len(raw))
+	active := HexCode(palette[7])
+	pending := active
+	text := func(chunk string) {
+		t := decodeString(unescape(chunk))
+		// the code goes right before the first visible character, so where
+		// a code falls among white space doesn't change the key
+		rest := strings.TrimLeftFunc(t, unicode.IsSpace)
+		b.WriteString(t[:len(t)-len(rest)])
+		if rest != "" && pending != active {
+			b.WriteString(string(pending))
+			active = pending
+		}
+		b.WriteString(string(Escape(rest)))
+	}
+
+	prev := 0
+	for _, loc := range findCodes(raw) {
+		text(raw[prev:loc[0]])
+		pending = HexCode(Code(raw[loc[0]:loc[1]]).Color(&palette))
+		prev = loc[1]
+	}
+	text(raw[prev:])
+	return QStr(b.String())
+}
@@ -0,0 +1,184 @@
+package qstr
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseCode(t *testing.T) {
+	valid := []string{"^0", "^7", "^x4af", "^xFFF"}
+	for _, s := range valid {
+		code, err := ParseCode(s)
+		if err != nil || code.String() != s {
+			t.Errorf("Incorrect parse of %q. Expected: %v, Got: %v (%v).", s, s, code, err)
+		}
+	}
+
+	invalid := []string{"", "^", "^x4a", "^xggg", "^1abc", "4af", "^^"}
+	for _, s := range invalid {
+		if _, err := ParseCode(s); !errors.Is(err, ErrInvalidCode) {
+			t.Errorf("Incorrect parse of %q. Expected: %v, Got: %v.", s, ErrInvalidCode, err)
+		}
+	}
+}
+
+func TestCodeColor(t *testing.T) {
+	var palette Palette
+	palette[1] = RGBColor{0.5, 0.25, 0}
+
+	tests := []struct {
+		code     Code
+		expected RGBColor
+	}{
+		{"^1", RGBColor{0.5, 0.25, 0}},
+		{"^xf00", RGBColor{1, 0, 0}},
+		{"^x1", RGBColor{}},
+		{"", RGBColor{}},
+	}
+
+	for _, tt := range tests {
+		if received := tt.code.Color(&palette); received != tt.expected {
+			t.Errorf("Incorrect color for %q. Expected: %v, Got: %v.", tt.code, tt.expected, received)
+		}
+	}
+
+	if received := Code("^1").Color(&XonoticPalette); received != XonoticPalette[1] {
+		t.Errorf("Incorrect color for ^1. Expected: %v, Got: %v.", XonoticPalette[1], received)
+	}
+}
+
+func TestHexCode(t *testing.T) {
+	expected := Code("^x4AF")
+	received := HexCode(RGBColor{4.0 / 15, 10.0 / 15, 1})
+	if received != expected || !received.IsHex() {
+		t.Errorf("Incorrect hex code. Expected: %v, Got: %v.", expected, received)
+	}
+	if Code("^4").IsHex() {
+		t.Errorf("Incorrect IsHex for ^4. Expected: false, Got: true.")
+	}
+}
+
+func TestSegmentCode(t *testing.T) {
+	nick := QStr("^x4afab^3c")
+	segments := nick.Tokenize()
+	if len(segments) != 2 || segments[0].Code != "^x4af" || segments[1].Code != "^3" {
+		t.Fatalf("Incorrect segments. Got: %v.", segments)
+	}
+	if !segments[0].Code.IsHex() || segments[0].Code.Color(&XonoticPalette) != segments[0].Color {
+		t.Errorf("Incorrect segment code %v. Expected color: %v.", segments[0].Code, segments[0].Color)
+	}
+}
+
+func TestColors(t *testing.T) {
+	nick := QStr("^1Anti^x4afbody^1^2日本")
+
+	expected := []RGBColor{XonoticPalette[1], HexToRGB("4", "a", "f"), XonoticPalette[1], XonoticPalette[2]}
+	if received := nick.Colors(); !reflect.DeepEqual(received, expected) {
+		t.Errorf("Incorrect colors of %v. Expected: %v, Got: %v.", nick, expected, received)
+	}
+
+	positions := []CodePosition{
+		{"^1", XonoticPalette[1], 0, 0},
+		{"^x4af", HexToRGB("4", "a", "f"), 6, 4},
+		{"^1", XonoticPalette[1], 15, 8},
+		{"^2", XonoticPalette[2], 17, 8},
+	}
+	if received := nick.CodePositions(); !reflect.DeepEqual(received, positions) {
+		t.Errorf("Incorrect code positions of %v. Expected: %v, Got: %v.", nick, positions, received)
+	}
+
+	plain := QStr("Antibody")
+	if received := plain.Colors(); len(received) != 0 {
+		t.Errorf("Incorrect colors of %v. Expected: [], Got: %v.", plain, received)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	var normalizeList = []struct {
+		Input    QStr
+		Expected QStr
+	}{
+		{"^1^1^x400name^7^7", "^x400name"},
+		{"^1Anti^1body", "^1Antibody"},
+		{"^x4afAnti^x4AFbody", "^x4afAntibody"},
+		{"^1Anti^2^3body^3", "^1Anti^3body"},
+		{"^7Anti^7body", "^7Antibody"},
+		{"^1a^^1^12", "^1a^^12"},
+		{"^1a^^1b", "^1a^^1b"},
+		{"Antibody", "Antibody"},
+		{"^1^2", ""},
+		{"", ""},
+	}
+
+	for _, v := range normalizeList {
+		received := v.Input.Normalize()
+		if received != v.Expected {
+			t.Errorf("Incorrect normalization of %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+		if received.Stripped() != v.Input.Stripped() {
+			t.Errorf("Incorrect text after normalization of %v. Expected: %v, Got: %v.", v.Input, v.Input.Stripped(), received.Stripped())
+		}
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	valid := []string{"^1Anti^x4afbody", "Antibody", "^^1a", "100^ rate", "", "^7"}
+	for _, s := range valid {
+		if received, err := ParseStrict(s); err != nil || received != QStr(s) {
+			t.Errorf("Incorrect strict parse of %q. Expected: %v, Got: %v (%v).", s, s, received, err)
+		}
+	}
+
+	var invalidList = []struct {
+		Input  string
+		Offset int
+		Text   string
+	}{
+		{"^x1G", 0, "^x1"},
+		{"Anti^", 4, "^"},
+		{"^1Anti^xbody", 6, "^xb"},
+		{"^xfff^x12", 5, "^x12"},
+	}
+	for _, v := range invalidList {
+		_, err := ParseStrict(v.Input)
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) || !errors.Is(err, ErrInvalidCode) {
+			t.Errorf("Incorrect error for %q. Expected a SyntaxError, Got: %v.", v.Input, err)
+			continue
+		}
+		if syntaxErr.Offset != v.Offset || syntaxErr.Text != v.Text {
+			t.Errorf("Incorrect error for %q. Expected: %q at %v, Got: %q at %v.", v.Input, v.Text, v.Offset, syntaxErr.Text, syntaxErr.Offset)
+		}
+	}
+
+	expected := `qstr: malformed color code "^x1" at offset 0`
+	if _, err := ParseStrict("^x1G"); err == nil || err.Error() != expected {
+		t.Errorf("Incorrect error message. Expected: %v, Got: %v.", expected, err)
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	var canonicalList = []struct {
+		Input    QStr
+		Expected QStr
+	}{
+		{"^1Anti^xf00body", "^xF00Antibody"},
+		{"^x4afAnti^x4AFbody^3", "^x4AFAntibody"},
+		{"^7Anti^1 ^2body", "Anti ^x3F0body"},
+		{"^1^2^3", ""},
+		{"^1a^^1", "^xF00a^^1"},
+		{"a^b", "a^^b"},
+		{"^1\ue041nti", "^xF00Anti"},
+		{"^1 x", " ^xF00x"},
+		{" ^1x", " ^xF00x"},
+		{"^1a ^2b", "^xF00a ^x3F0b"},
+		{"^1a^2 b", "^xF00a ^x3F0b"},
+	}
+
+	for _, v := range canonicalList {
+		if received := v.Input.Canonical(XonoticPalette); received != v.Expected {
+			t.Errorf("Incorrect canonical form of %q. Expected: %q, Got: %q.", v.Input, v.Expected, received)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Package collation sorts QStr values by the rules of a language, comparing
+// their visible text rather than the raw bytes, so player lists come out in
+// the order a community expects.
+package collation
+
+import (
+	"sort"
+
+	"github.com/antzucaro/qstr"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// Collator compares and sorts QStr values by the stripped text, ignoring
+// case. It is not safe for concurrent use.
+type Collator struct {
+	c *collate.Collator
+}
+
+// New returns a Collator for the given language. Additional options, such as
+// collate.Numeric, are passed on to the underlying collator.
+func New(tag language.Tag, opts ...collate.Option) *Collator {
+	opts = append([]collate.Option{collate.IgnoreCase}, opts...)
+	return &Collator{c: collate.New(tag, opts...)}
+}
+
+// Compare returns an integer comparing the visible text of a and b. The
+// result is 0 if they collate equally, -1 if a sorts before b, and +1
+// otherwise.
+func (c *Collator) Compare(a, b qstr.QStr) int {
+	return c.c.CompareString(a.Stripped(), b.Stripped())
+}
+
+// Sort sorts nicks in place. Values that collate equally keep their original
+// order, and every value keeps its color codes.
+func (c *Collator) Sort(nicks []qstr.QStr) {
+	stripped := make([]string, len(nicks))
+	for i, nick := range nicks {
+		stripped[i] = nick.Stripped()
+	}
+
+	sort.Stable(byText{nicks: nicks, stripped: stripped, c: c.c})
+}
+
+// byText sorts nicks by their precomputed stripped text
+type byText struct {
+	nicks    []qstr.QStr
+	stripped []string
+	c        *collate.Collator
+}
+
+func (s byText) Len() int {
+	return len(s.nicks)
+}
+
+func (s byText) Less(i, j int) bool {
+	return s.c.CompareString(s.stripped[i], s.stripped[j]) < 0
+}
+
+func (s byText) Swap(i, j int) {
+	s.nicks[i], s.nicks[j] = s.nicks[j], s.nicks[i]
+	s.stripped[i], s.stripped[j] = s.stripped[j], s.stripped[i]
+}
@@ -0,0 +1,39 @@
+package collation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/antzucaro/qstr"
+	"golang.org/x/text/language"
+)
+
+func TestSort(t *testing.T) {
+	nicks := []qstr.QStr{"^1Zed", "^2öre", "apple", "^x4AFOrange", "Äpfel"}
+
+	var sortList = []struct {
+		Tag      language.Tag
+		Expected []qstr.QStr
+	}{
+		{language.German, []qstr.QStr{"Äpfel", "apple", "^x4AFOrange", "^2öre", "^1Zed"}},
+		{language.Swedish, []qstr.QStr{"apple", "^x4AFOrange", "^1Zed", "Äpfel", "^2öre"}},
+	}
+
+	for _, v := range sortList {
+		received := append([]qstr.QStr(nil), nicks...)
+		New(v.Tag).Sort(received)
+		if !reflect.DeepEqual(received, v.Expected) {
+			t.Errorf("Incorrect sort order for %v. Expected: %v, Got: %v.", v.Tag, v.Expected, received)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	c := New(language.English)
+	if received := c.Compare("^1ANTIBODY", "^2antibody"); received != 0 {
+		t.Errorf("Incorrect comparison. Expected: %v, Got: %v.", 0, received)
+	}
+	if received := c.Compare("^1b", "^2A"); received != 1 {
+		t.Errorf("Incorrect comparison. Expected: %v, Got: %v.", 1, received)
+	}
+}
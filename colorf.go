@@ -0,0 +1,185 @@
+package qstr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Colorf formats according to a format specifier, as fmt.Sprintf does, and
+// returns the result as a QStr. The color codes in format are kept, while
+// the text of each interpolated value is escaped, so user input such as a
+// chat message or nick can't add codes of its own. QStr values formatted
+// with %s or %v are the exception: they are inserted with their colors, and
+// width pads them by visible length.
+//
+// A verb may be preceded by a color in braces, which colors only that
+// value. The color is either a code, such as {^1} or {^x4f0}, or a CSS
+// color, such as {#44ff00} or {orange}:
+//
+//	qstr.Colorf("^1%s^7 joined as %{#44ff00}s", server, nick)
+//
+// Wherever an interpolated value changes the color, the color of the format
+// in effect before it is restored afterwards, so a player's trailing color
+// does not bleed into the rest of the message. A lone caret or an unfinished
+// ^x code at the end of a piece of format or of a QStr value is escaped, so
+// the text after it can't complete it into a code. Argument indexes and *
+// widths are not supported.
+func Colorf(format string, args ...interface{}) QStr {
+	var b strings.Builder
+	var active Code  // the color set by format
+	restore := false // whether active must be written before more text
+	write := func(s string) {
+		if s == "" {
+			return
+		}
+		if restore && basicCodeLen(s) == 0 {
+			if active == "" {
+				b.WriteString(resetCode)
+			} else {
+				b.WriteString(string(active))
+			}
+		}
+		restore = false
+		b.WriteString(s)
+	}
+	literal := func(s string) {
+		if codes := findCodes(s); len(codes) > 0 {
+			last := codes[len(codes)-1]
+			active = Code(s[last[0]:last[1]])
+		}
+		write(escapeUnfinished(s))
+	}
+
+	argNum := 0
+	for i := 0; i < len(format); {
+		j := strings.IndexByte(format[i:], '%')
+		if j < 0 {
+			literal(format[i:])
+			break
+		}
+		literal(format[i : i+j])
+		i += j + 1
+		if i < len(format) && format[i] == '%' {
+			write("%")
+			i++
+			continue
+		}
+
+		var color Code
+		if i < len(format) && format[i] == '{' {
+			end := strings.IndexByte(format[i:], '}')
+			if end < 0 {
+				write(string(Escape("%!{(BADCOLOR)")))
+				break
+			}
+			spec := format[i+1 : i+end]
+			i += end + 1
+			var ok bool
+			if color, ok = parseColorSpec(spec); !ok {
+				write(string(Escape("%!{" + spec + "}(BADCOLOR)")))
+			}
+		}
+
+		// flags, width, and precision up to the verb
+		start := i
+		for i < len(format) && strings.IndexByte("+-# 0123456789.", format[i]) >= 0 {
+			i++
+		}
+		if i == len(format) {
+			write(string(Escape("%!(NOVERB)")))
+			break
+		}
+		verb := format[i]
+		i++
+		spec := "%" + format[start:i]
+
+		if argNum >= len(args) {
+			write(string(Escape(fmt.Sprintf("%%!%c(MISSING)", verb))))
+			continue
+		}
+		arg := args[argNum]
+		argNum++
+
+		var value string
+		colored := false
+		if q, ok := arg.(QStr); ok && (verb == 's' || verb == 'v') && !strings.ContainsAny(format[start:i], "#+") {
+			value = escapeUnfinished(string(padQStr(q, format[start:i-1])))
+			colored = len(findCodes(value)) > 0
+		} else {
+			value = string(Escape(fmt.Sprintf(spec, arg)))
+		}
+		if value == "" {
+			continue
+		}
+		if color != "" {
+			write(string(color))
+			colored = true
+		}
+		write(value)
+		restore = restore || colored
+	}
+
+	if argNum < len(args) {
+		extra := make([]string, 0, len(args)-argNum)
+		for _, arg := range args[argNum:] {
+			extra = append(extra, fmt.Sprintf("%T=%v", arg, arg))
+		}
+		write(string(Escape("%!(EXTRA " + strings.Join(extra, ", ") + ")")))
+	}
+	return QStr(b.String())
+}
+
+// escapeUnfinished returns s with its last caret escaped if that caret is
+// not itself escaped and is followed by nothing, an x, or an x and up to two
+// hexadecimal digits, so that text appended to s can't complete a code.
+func escapeUnfinished(s string) string {
+	k := strings.LastIndexByte(s, '^')
+	if k < 0 {
+		return s
+	}
+	tail := s[k+1:]
+	if tail != "" {
+		if tail[0] != 'x' || len(tail) > 3 {
+			return s
+		}
+		for i := 1; i < len(tail); i++ {
+			if !isHexDigit(tail[i]) {
+				return s
+			}
+		}
+	}
+	// the caret is live if it ends an odd run of carets
+	run := len(s[:k+1]) - len(strings.TrimRight(s[:k+1], "^"))
+	if run%2 == 0 {
+		return s
+	}
+	return s[:k] + "^" + s[k:]
+}
+
+// parseColorSpec returns the code for a color given to Colorf in braces
+func parseColorSpec(spec string) (Code, bool) {
+	if strings.HasPrefix(spec, "^") {
+		code, err := ParseCode(spec)
+		return code, err == nil
+	}
+	c, err := ParseCSSColor(spec)
+	if err != nil {
+		return "", false
+	}
+	return HexCode(c), true
+}
+
+// padQStr pads s to the width given in flags, which hold the flags and
+// width of a %s verb, on the right if flags has a minus sign
+func padQStr(s QStr, flags string) QStr {
+	width := 0
+	if k := strings.IndexByte(flags, '.'); k >= 0 {
+		flags = flags[:k]
+	}
+	digits := strings.TrimLeft(flags, "-+# 0")
+	fmt.Sscan(digits, &width)
+	if strings.Contains(flags, "-") {
+		return s.PadRight(width)
+	}
+	return s.PadLeft(width)
+}
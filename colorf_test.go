@@ -0,0 +1,39 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestColorf(t *testing.T) {
+	var colorfList = []struct {
+		Format   string
+		Args     []interface{}
+		Expected QStr
+	}{
+		{"^1%s^7 joined", []interface{}{"^2Anti"}, "^1^^2Anti^7 joined"},
+		{"%s joined", []interface{}{QStr("^1Anti")}, "^1Anti^7 joined"},
+		{"^3[%s] says hi", []interface{}{QStr("^1Anti")}, "^3[^1Anti^3] says hi"},
+		{"%s^2 joined", []interface{}{QStr("^1Anti")}, "^1Anti^2 joined"},
+		{"%{#44ff00}s joined", []interface{}{"Anti"}, "^x4F0Anti^7 joined"},
+		{"%{^1}d frags", []interface{}{12}, "^112^7 frags"},
+		{"score: %5d%%", []interface{}{42}, "score:    42%"},
+		{"[%-6s]", []interface{}{QStr("^1Anti")}, "[^1Anti  ^7]"},
+		{"cost ^%d", []interface{}{1}, "cost ^^1"},
+		{"%q", []interface{}{QStr("^1Anti")}, "\"^^1Anti\""},
+		{"%s and %s", []interface{}{"a"}, "a and %!s(MISSING)"},
+		{"%s", []interface{}{"a", 1}, "a%!(EXTRA int=1)"},
+		{"%{nope}s", []interface{}{"a"}, "%!{nope}(BADCOLOR)a"},
+		{"^x%s", []interface{}{"4afbody"}, "^^x4afbody"},
+		{"^x4%s", []interface{}{"afbody"}, "^^x4afbody"},
+		{"^x4a%s", []interface{}{"fbody"}, "^^x4afbody"},
+		{"^^x4a%s", []interface{}{"fbody"}, "^^x4afbody"},
+		{"%s1 joined", []interface{}{QStr("Anti^")}, "Anti^^1 joined"},
+		{"%sf joined", []interface{}{QStr("^1Anti^x4a")}, "^1Anti^^x4a^7f joined"},
+	}
+
+	for _, v := range colorfList {
+		if received := Colorf(v.Format, v.Args...); received != v.Expected {
+			t.Errorf("Incorrect Colorf(%q). Expected: %q, Got: %q.", v.Format, v.Expected, received)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package qstr
+
+import (
+	"math"
+	"strconv"
+)
+
+// distance returns the Euclidean distance between two colors in RGB space.
+// It ranges from 0 for identical colors to √3 for black and white.
+func (c *RGBColor) distance(o RGBColor) float64 {
+	dr := c.R - o.R
+	dg := c.G - o.G
+	db := c.B - o.B
+	return math.Sqrt(dr*dr + dg*dg + db*db)
+}
+
+// ContainsColor reports whether any visible text in s is colored within
+// tolerance of c. The tolerance is the Euclidean distance in RGB space, so 0
+// requires an exact match.
+func (s *QStr) ContainsColor(c RGBColor, tolerance float64) bool {
+	for _, seg := range DarkPlaces.Tokenize(*s) {
+		if seg.Code != "" && seg.Color.distance(c) <= tolerance {
+			return true
+		}
+	}
+	return false
+}
+
+// nearestColor returns the index of the color in colors nearest to c. Ties
+// go to the lowest index.
+func nearestColor(c RGBColor, colors []RGBColor) int {
+	best := 0
+	for i, o := range colors {
+		if c.distance(o) < c.distance(colors[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// to15 converts a channel in the range [0, 1] to the nearest value in the
+// range [0, 15].
+func to15(v float64) int {
+	return int(math.Round(clamp01(v) * 15.0))
+}
+
+// LabColor is a color in the CIE L*a*b* space, where distances roughly match
+// the differences people perceive.
+type LabColor struct {
+	// Lightness, and the green-red and blue-yellow axes
+	L, A, B float64
+}
+
+// Lab converts an sRGB color into a LabColor under the D65 illuminant.
+func (c *RGBColor) Lab() LabColor {
+	r, g, b := linearize(c.R), linearize(c.G), linearize(c.B)
+
+	// normalized to the D65 white point
+	x := (0.4124*r + 0.3576*g + 0.1805*b) / 0.95047
+	y := 0.2126*r + 0.7152*g + 0.0722*b
+	z := (0.0193*r + 0.1192*g + 0.9505*b) / 1.08883
+
+	f := func(t float64) float64 {
+		if t > 216.0/24389.0 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27.0*t + 16.0) / 116.0
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+
+	return LabColor{116.0*fy - 16.0, 500.0 * (fx - fy), 200.0 * (fy - fz)}
+}
+
+// distance returns the CIE76 color difference between two Lab colors.
+func (c *LabColor) distance(o LabColor) float64 {
+	dl := c.L - o.L
+	da := c.A - o.A
+	db := c.B - o.B
+	return math.Sqrt(dl*dl + da*da + db*db)
+}
+
+// distance2000 returns the CIEDE2000 color difference between two Lab
+// colors. It corrects CIE76's overestimation of differences in chroma, so a
+// saturated color is matched to a hue like it rather than to a gray.
+func (c *LabColor) distance2000(o LabColor) float64 {
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	deg := func(rad float64) float64 { return math.Mod(rad*180/math.Pi+360, 360) }
+	pow7 := func(v float64) float64 { return v * v * v * v * v * v * v }
+
+	cMean := (math.Hypot(c.A, c.B) + math.Hypot(o.A, o.B)) / 2
+	g := 0.5 * (1 - math.Sqrt(pow7(cMean)/(pow7(cMean)+pow7(25))))
+	a1, a2 := (1+g)*c.A, (1+g)*o.A
+	c1, c2 := math.Hypot(a1, c.B), math.Hypot(a2, o.B)
+	h1, h2 := deg(math.Atan2(c.B, a1)), deg(math.Atan2(o.B, a2))
+
+	dh, hMean := 0.0, h1+h2
+	if c1*c2 != 0 {
+		dh = h2 - h1
+		if dh > 180 {
+			dh -= 360
+		} else if dh < -180 {
+			dh += 360
+		}
+		hMean = (h1 + h2) / 2
+		if math.Abs(h1-h2) > 180 {
+			if h1+h2 < 360 {
+				hMean += 180
+			} else {
+				hMean -= 180
+			}
+		}
+	}
+
+	dL := o.L - c.L
+	dC := c2 - c1
+	dH := 2 * math.Sqrt(c1*c2) * math.Sin(rad(dh/2))
+
+	lMean := (c.L + o.L) / 2
+	cpMean := (c1 + c2) / 2
+	t := 1 - 0.17*math.Cos(rad(hMean-30)) + 0.24*math.Cos(rad(2*hMean)) +
+		0.32*math.Cos(rad(3*hMean+6)) - 0.20*math.Cos(rad(4*hMean-63))
+	dTheta := 30 * math.Exp(-math.Pow((hMean-275)/25, 2))
+	rc := 2 * math.Sqrt(pow7(cpMean)/(pow7(cpMean)+pow7(25)))
+	sl := 1 + 0.015*(lMean-50)*(lMean-50)/math.Sqrt(20+(lMean-50)*(lMean-50))
+	sc := 1 + 0.045*cpMean
+	sh := 1 + 0.015*cpMean*t
+	rt := -math.Sin(rad(2*dTheta)) * rc
+
+	l, ch, hu := dL/sl, dC/sc, dH/sh
+	return math.Sqrt(l*l + ch*ch + hu*hu + rt*ch*hu)
+}
+
+// DeltaE returns the CIEDE2000 color difference between a and b. A
+// difference of about 2.3 is just noticeable, so colors closer than that are
+// effectively the same to a reader.
+func DeltaE(a, b RGBColor) float64 {
+	la := a.Lab()
+	return la.distance2000(b.Lab())
+}
+
+// MapColors returns s with the color of every color code replaced by the
+// result of f, such as a desaturated, tinted, or inverted version of it. The
+// colors of basic codes are taken from XonoticPalette; see Palette.MapColors
+// for other palettes. Codes whose color f changes are rewritten as the
+// nearest ^xNNN code, while all other codes and the text are left exactly as
+// they are.
+func (s *QStr) MapColors(f func(RGBColor) RGBColor) QStr {
+	return XonoticPalette.MapColors(*s, f)
+}
+
+// MapColors is like QStr.MapColors, but takes the colors of basic codes from
+// p.
+func (p *Palette) MapColors(s QStr, f func(RGBColor) RGBColor) QStr {
+	return QStr(replaceCodes(string(s), func(m string) string {
+		c := Code(m).Color(p)
+		if mapped := f(c); mapped != c {
+			return string(HexCode(mapped))
+		}
+		return m
+	}))
+}
+
+// ToDecimalCodes returns s with every ^xNNN code replaced by the ^N code of
+// XonoticPalette that is perceptually closest to it, for engines and chat
+// bridges that only understand the basic codes. Where two palette colors are
+// equally close, the lower code is used. See Palette.ToDecimalCodes for other
+// palettes.
+func (s *QStr) ToDecimalCodes() QStr {
+	return XonoticPalette.ToDecimalCodes(*s)
+}
+
+// ToDecimalCodes is like QStr.ToDecimalCodes, but picks the ^N codes of p.
+func (p *Palette) ToDecimalCodes(s QStr) QStr {
+	labs := make([]LabColor, len(p))
+	for i := range p {
+		labs[i] = p[i].Lab()
+	}
+
+	return QStr(replaceCodes(string(s), func(m string) string {
+		if len(m) == 2 {
+			return m
+		}
+		c := Code(m).Color(p)
+		lab := c.Lab()
+
+		best, bestDist := 0, math.Inf(1)
+		for i := range labs {
+			if d := lab.distance2000(labs[i]); d < bestDist {
+				best, bestDist = i, d
+			}
+		}
+		return "^" + strconv.Itoa(best)
+	}))
+}
+
+// Quantize returns s with the color of every color code snapped to the
+// perceptually nearest color of palette, measured with CIEDE2000, for
+// exports to engines, terminals, and other outputs with a limited set of
+// colors. Codes whose color changes are rewritten as ^xNNN codes, so the
+// palette colors should be representable as such. The colors of basic codes
+// are taken from XonoticPalette; see Palette.Quantize for other palettes.
+// With an empty palette, s is returned unchanged.
+func (s *QStr) Quantize(palette []RGBColor) QStr {
+	return XonoticPalette.Quantize(*s, palette)
+}
+
+// Quantize is like QStr.Quantize, but takes the colors of basic codes from p.
+func (p *Palette) Quantize(s QStr, palette []RGBColor) QStr {
+	if len(palette) == 0 {
+		return s
+	}
+	return p.MapColors(s, quantizer(palette))
+}
+
+// quantizer returns a function snapping colors to the perceptually nearest
+// color of palette, or nil if palette is empty.
+func quantizer(palette []RGBColor) func(RGBColor) RGBColor {
+	if len(palette) == 0 {
+		return nil
+	}
+	labs := make([]LabColor, len(palette))
+	for i := range palette {
+		labs[i] = palette[i].Lab()
+	}
+
+	return func(c RGBColor) RGBColor {
+		lab := c.Lab()
+		best, bestDist := 0, math.Inf(1)
+		for i := range labs {
+			if d := lab.distance2000(labs[i]); d < bestDist {
+				best, bestDist = i, d
+			}
+		}
+		return palette[best]
+	}
+}
+
+// ToHexCodes returns s with every ^N code replaced by the ^xNNN code nearest
+// to the color palette gives it, so later processing only has to handle one
+// form of code. Pass the palette of the engine the value comes from, such as
+// XonoticPalette.
+func (s *QStr) ToHexCodes(palette Palette) QStr {
+	return QStr(replaceCodes(string(*s), func(m string) string {
+		if len(m) != 2 {
+			return m
+		}
+		return string(HexCode(palette[m[1]-'0']))
+	}))
+}
@@ -0,0 +1,195 @@
+package qstr
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestContainsColor(t *testing.T) {
+	black := RGBColor{0, 0, 0}
+
+	var containsList = []struct {
+		Input     QStr
+		Tolerance float64
+		Expected  bool
+	}{
+		{"^x000Antibody", 0, true},
+		{"^x111Antibody", 0, false},
+		{"^x111Antibody", 0.2, true},
+		{"^7Anti^x000", 0, false},
+		{"Antibody", 1, false},
+	}
+
+	for _, v := range containsList {
+		received := v.Input.ContainsColor(black, v.Tolerance)
+		if received != v.Expected {
+			t.Errorf("Incorrect ContainsColor result for %v with tolerance %v. Expected: %v, Got: %v.", v.Input, v.Tolerance, v.Expected, received)
+		}
+	}
+}
+
+func TestLab(t *testing.T) {
+	var labList = []struct {
+		Color    RGBColor
+		Expected LabColor
+	}{
+		{RGBColor{0, 0, 0}, LabColor{0, 0, 0}},
+		{RGBColor{1, 1, 1}, LabColor{100, 0, 0}},
+		{RGBColor{1, 0, 0}, LabColor{53.24, 80.09, 67.20}},
+	}
+
+	for _, v := range labList {
+		received := v.Color.Lab()
+		if math.Abs(received.L-v.Expected.L) > 0.05 || math.Abs(received.A-v.Expected.A) > 0.05 || math.Abs(received.B-v.Expected.B) > 0.05 {
+			t.Errorf("Incorrect Lab value for %+v. Expected: %+v, Got: %+v.", v.Color, v.Expected, received)
+		}
+	}
+}
+
+func TestMapColors(t *testing.T) {
+	invert := func(c RGBColor) RGBColor {
+		return RGBColor{1 - c.R, 1 - c.G, 1 - c.B}
+	}
+	redToBlue := func(c RGBColor) RGBColor {
+		if c == XonoticPalette[1] {
+			return RGBColor{0, 0, 1}
+		}
+		return c
+	}
+
+	var mapList = []struct {
+		Input    QStr
+		F        func(RGBColor) RGBColor
+		Expected QStr
+	}{
+		{"^1Anti^x4afbody^7", invert, "^x0FFAnti^xB50body^x000"},
+		{"^1Anti^2body^1^3", redToBlue, "^x00FAnti^2body^x00F^3"},
+		{"Antibody ^^x", invert, "Antibody ^^x"},
+	}
+
+	for _, v := range mapList {
+		received := v.Input.MapColors(v.F)
+		if received != v.Expected {
+			t.Errorf("Incorrect mapping of %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestDistance2000(t *testing.T) {
+	// reference pairs from Sharma, Wu and Dalal's CIEDE2000 test data
+	var distanceList = []struct {
+		A, B     LabColor
+		Expected float64
+	}{
+		{LabColor{50, 2.6772, -79.7751}, LabColor{50, 0, -82.7485}, 2.0425},
+		{LabColor{50, -1, 2}, LabColor{50, 0, 0}, 2.3669},
+		{LabColor{50, 2.5, 0}, LabColor{73, 25, -18}, 27.1492},
+		{LabColor{60.2574, -34.0099, 36.2677}, LabColor{60.4626, -34.1751, 39.4387}, 1.2644},
+		{LabColor{50, 0, 0}, LabColor{50, 0, 0}, 0},
+	}
+
+	for _, v := range distanceList {
+		received := v.A.distance2000(v.B)
+		if math.Abs(received-v.Expected) > 0.0001 {
+			t.Errorf("Incorrect distance between %v and %v. Expected: %v, Got: %v.", v.A, v.B, v.Expected, received)
+		}
+	}
+}
+
+func TestDeltaE(t *testing.T) {
+	red := RGBColor{1, 0, 0}
+	if received := DeltaE(red, red); received != 0 {
+		t.Errorf("Incorrect difference between %v and itself. Expected: 0, Got: %v.", red, received)
+	}
+
+	white, black := RGBColor{1, 1, 1}, RGBColor{0, 0, 0}
+	if received := DeltaE(white, black); math.Abs(received-100) > 0.01 {
+		t.Errorf("Incorrect difference between %v and %v. Expected: 100, Got: %v.", white, black, received)
+	}
+
+	// a saturated red is nearer to a darker red than to a gray of the same
+	// lightness
+	darkRed, gray := RGBColor{0.7, 0, 0}, RGBColor{0.6, 0.6, 0.6}
+	if DeltaE(red, darkRed) >= DeltaE(red, gray) {
+		t.Errorf("Incorrect ordering. Expected %v nearer to %v than %v.", red, darkRed, gray)
+	}
+	if DeltaE(red, darkRed) != DeltaE(darkRed, red) {
+		t.Errorf("Incorrect difference. Expected the same in both directions.")
+	}
+}
+
+func TestToDecimalCodes(t *testing.T) {
+	var decimalList = []struct {
+		Input    QStr
+		Expected QStr
+	}{
+		{"^x4afAnti^xF00body", "^4Anti^1body"},
+		{"^x048dark ^x0f0green ^xfa0gold", "^4dark ^2green ^3gold"},
+		{"^x000black ^xFFFwhite", "^0black ^7white"},
+		{"^1already ^2decimal", "^1already ^2decimal"},
+		{"^x4a", "^x4a"},
+	}
+
+	for _, v := range decimalList {
+		received := v.Input.ToDecimalCodes()
+		if received != v.Expected {
+			t.Errorf("Incorrect conversion of %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestToHexCodes(t *testing.T) {
+	custom := XonoticPalette
+	custom[1] = NewRGBColorFrom255(170, 0, 0)
+
+	var hexList = []struct {
+		Input    QStr
+		Palette  Palette
+		Expected QStr
+	}{
+		{"^1Anti^4body^7", XonoticPalette, "^xF00Anti^x36Fbody^xFFF"},
+		{"^1Anti^x4afbody", custom, "^xA00Anti^x4afbody"},
+		{"^^1a", XonoticPalette, "^^1a"},
+		{"^^^1a", XonoticPalette, "^^^xF00a"},
+		{"Antibody", XonoticPalette, "Antibody"},
+	}
+
+	for _, v := range hexList {
+		received := v.Input.ToHexCodes(v.Palette)
+		if received != v.Expected {
+			t.Errorf("Incorrect conversion of %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+
+	// the conversion keeps the colors of the default palette
+	nick := QStr("^1Anti^4body")
+	converted := nick.ToHexCodes(XonoticPalette)
+	if !reflect.DeepEqual(nick.Colors(), converted.Colors()) {
+		t.Errorf("Incorrect colors after conversion. Expected: %v, Got: %v.", nick.Colors(), converted.Colors())
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	palette := []RGBColor{{0, 0, 0}, {1, 1, 1}, {1, 0, 0}, {0, 0, 1}}
+	var quantizeList = []struct {
+		Input    QStr
+		Expected QStr
+	}{
+		{"^1red ^xe11dark", "^1red ^xF00dark"},
+		{"^x22cAnti^x222body", "^x00FAnti^x000body"},
+		{"^7white ^xeeegrey", "^7white ^xFFFgrey"},
+		{"^^1 plain", "^^1 plain"},
+	}
+
+	for _, v := range quantizeList {
+		if received := v.Input.Quantize(palette); received != v.Expected {
+			t.Errorf("Incorrect quantization of %q. Expected: %q, Got: %q.", v.Input, v.Expected, received)
+		}
+	}
+
+	s := QStr("^x4afAnti")
+	if received := s.Quantize(nil); received != s {
+		t.Errorf("Incorrect quantization with no palette. Expected: %v, Got: %v.", s, received)
+	}
+}
@@ -0,0 +1,193 @@
+package qstr
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// ErrBadCompact is returned by DecodeCompact for data that was not produced
+// by EncodeCompact with the same dictionary.
+var ErrBadCompact = errors.New("qstr: malformed compact encoding")
+
+const (
+	// compactEscape introduces a dictionary reference. It never occurs in
+	// valid UTF-8, so nicks rarely need it escaped.
+	compactEscape = 0xff
+
+	// MaxDictionaryEntries is the largest number of entries a Dictionary
+	// can hold. A reference to entry MaxDictionaryEntries stands for a
+	// literal compactEscape byte.
+	MaxDictionaryEntries = 255
+
+	// the shortest and longest substrings considered during training. A
+	// reference takes two bytes, so shorter entries would save nothing.
+	minEntryLen = 3
+	maxEntryLen = 24
+)
+
+// Dictionary is a shared table of substrings, such as clan tags and common
+// color code sequences, that EncodeCompact replaces with two-byte
+// references. A value must be decoded with the same dictionary it was
+// encoded with, so store the entries alongside the encoded nicks and treat
+// them as immutable once data has been written. A Dictionary is safe for
+// concurrent use.
+type Dictionary struct {
+	entries []string
+	// entry indexes by first byte, longest entries first
+	byFirst [256][]int
+}
+
+// NewDictionary returns a Dictionary of entries, as returned by Entries.
+// Entries shorter than two bytes, duplicates, and any entries past
+// MaxDictionaryEntries are dropped.
+func NewDictionary(entries []string) *Dictionary {
+	d := &Dictionary{}
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		if len(d.entries) == MaxDictionaryEntries {
+			break
+		}
+		if len(e) < 2 || seen[e] {
+			continue
+		}
+		seen[e] = true
+		d.entries = append(d.entries, e)
+	}
+
+	for i, e := range d.entries {
+		d.byFirst[e[0]] = append(d.byFirst[e[0]], i)
+	}
+	for _, idx := range d.byFirst {
+		sort.SliceStable(idx, func(a, b int) bool {
+			return len(d.entries[idx[a]]) > len(d.entries[idx[b]])
+		})
+	}
+	return d
+}
+
+// TrainDictionary builds a Dictionary of at most size entries from the
+// substrings that save the most space across corpus. Training counts every
+// substring of every nick, so a representative sample of a few tens of
+// thousands of nicks works better than a full database.
+func TrainDictionary(corpus []QStr, size int) *Dictionary {
+	if size > MaxDictionaryEntries {
+		size = MaxDictionaryEntries
+	}
+
+	counts := make(map[string]int)
+	for _, nick := range corpus {
+		raw := string(nick)
+		for i := range raw {
+			for n := minEntryLen; n <= maxEntryLen && i+n <= len(raw); n++ {
+				counts[raw[i:i+n]]++
+			}
+		}
+	}
+
+	type candidate struct {
+		text    string
+		savings int
+	}
+	var candidates []candidate
+	for text, count := range counts {
+		if count < 2 {
+			continue
+		}
+		candidates = append(candidates, candidate{text, (len(text) - 2) * count})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].savings != candidates[j].savings {
+			return candidates[i].savings > candidates[j].savings
+		}
+		return candidates[i].text < candidates[j].text
+	})
+
+	// substrings of an entry that was already picked mostly occur as part
+	// of that entry, so picking them as well would waste a slot
+	var entries []string
+	for _, c := range candidates {
+		if len(entries) >= size {
+			break
+		}
+		covered := false
+		for _, e := range entries {
+			if strings.Contains(e, c.text) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			entries = append(entries, c.text)
+		}
+	}
+	return NewDictionary(entries)
+}
+
+// Entries returns the entries of d in index order. Passing them to
+// NewDictionary gives back an equivalent Dictionary.
+func (d *Dictionary) Entries() []string {
+	return append([]string(nil), d.entries...)
+}
+
+// match returns the index of the longest entry that s starts with, or -1
+func (d *Dictionary) match(s string) int {
+	for _, i := range d.byFirst[s[0]] {
+		if strings.HasPrefix(s, d.entries[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// EncodeCompact returns a compact binary encoding of s in which substrings
+// found in d are replaced by references to d. A nil Dictionary leaves s as
+// it is apart from escaping. Use DecodeCompact with the same dictionary to
+// get s back.
+func (s *QStr) EncodeCompact(d *Dictionary) []byte {
+	raw := string(*s)
+	b := make([]byte, 0, len(raw))
+	for i := 0; i < len(raw); {
+		if d != nil {
+			if e := d.match(raw[i:]); e >= 0 {
+				b = append(b, compactEscape, byte(e))
+				i += len(d.entries[e])
+				continue
+			}
+		}
+		if raw[i] == compactEscape {
+			b = append(b, compactEscape, MaxDictionaryEntries)
+		} else {
+			b = append(b, raw[i])
+		}
+		i++
+	}
+	return b
+}
+
+// DecodeCompact returns the QStr encoded in b by EncodeCompact with d. It
+// returns ErrBadCompact if b is truncated or refers to an entry d does not
+// have.
+func DecodeCompact(b []byte, d *Dictionary) (QStr, error) {
+	var out strings.Builder
+	out.Grow(2 * len(b))
+	for i := 0; i < len(b); i++ {
+		if b[i] != compactEscape {
+			out.WriteByte(b[i])
+			continue
+		}
+
+		i++
+		switch {
+		case i == len(b):
+			return "", ErrBadCompact
+		case b[i] == MaxDictionaryEntries:
+			out.WriteByte(compactEscape)
+		case d == nil || int(b[i]) >= len(d.entries):
+			return "", ErrBadCompact
+		default:
+			out.WriteString(d.entries[b[i]])
+		}
+	}
+	return QStr(out.String()), nil
+}
@@ -0,0 +1,73 @@
+package qstr
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCompactRoundTrip(t *testing.T) {
+	corpus := []QStr{
+		"^1[^7ABC^1]^7 Antibody",
+		"^1[^7ABC^1]^7 Morphed",
+		"^1[^7ABC^1]^7 Mirio",
+		"^x4af|DoD|^7 Samual",
+		"^x4af|DoD|^7 Halogene",
+		"unique",
+	}
+	d := TrainDictionary(corpus, 8)
+	if len(d.Entries()) == 0 || len(d.Entries()) > 8 {
+		t.Fatalf("Incorrect number of dictionary entries. Expected: 1-8, Got: %v.", len(d.Entries()))
+	}
+
+	nicks := append(corpus, "", "^1[^7ABC^1]^7 newcomer", "bad\xff\xfebytes", "日本語")
+	for _, nick := range nicks {
+		encoded := nick.EncodeCompact(d)
+		received, err := DecodeCompact(encoded, d)
+		if err != nil || received != nick {
+			t.Errorf("Incorrect round trip of %q. Expected: %q, Got: %q (%v).", nick, nick, received, err)
+		}
+	}
+
+	nick := QStr("^1[^7ABC^1]^7 newcomer")
+	if n := len(nick.EncodeCompact(d)); n >= len(nick)-8 {
+		t.Errorf("Incorrect compact length of %q. Expected under: %v, Got: %v.", nick, len(nick)-8, n)
+	}
+}
+
+func TestCompactNilDictionary(t *testing.T) {
+	nick := QStr("^1a\xffb")
+	encoded := nick.EncodeCompact(nil)
+	expected := []byte("^1a\xff\xffb")
+	if !reflect.DeepEqual(encoded, expected) {
+		t.Errorf("Incorrect encoding of %q. Expected: %q, Got: %q.", nick, expected, encoded)
+	}
+	if received, err := DecodeCompact(encoded, nil); err != nil || received != nick {
+		t.Errorf("Incorrect decoding of %q. Expected: %q, Got: %q (%v).", encoded, nick, received, err)
+	}
+}
+
+func TestDecodeCompactErrors(t *testing.T) {
+	d := NewDictionary([]string{"^1[", "x", "^1["})
+	if expected := []string{"^1["}; !reflect.DeepEqual(d.Entries(), expected) {
+		t.Errorf("Incorrect entries. Expected: %v, Got: %v.", expected, d.Entries())
+	}
+
+	for _, b := range [][]byte{{'a', 0xff}, {0xff, 1}} {
+		if _, err := DecodeCompact(b, d); !errors.Is(err, ErrBadCompact) {
+			t.Errorf("Incorrect error for %q. Expected: %v, Got: %v.", b, ErrBadCompact, err)
+		}
+	}
+	if _, err := DecodeCompact([]byte{0xff, 0}, nil); !errors.Is(err, ErrBadCompact) {
+		t.Errorf("Incorrect error without dictionary. Expected: %v, Got: %v.", ErrBadCompact, err)
+	}
+}
+
+func TestDictionaryLongestMatch(t *testing.T) {
+	d := NewDictionary([]string{"^1[", "^1[^7ABC^1]"})
+	nick := QStr("^1[^7ABC^1]x")
+	expected := []byte{0xff, 1, 'x'}
+	if received := nick.EncodeCompact(d); !reflect.DeepEqual(received, expected) {
+		t.Errorf("Incorrect encoding of %q. Expected: %q, Got: %q.", nick, expected, received)
+	}
+}
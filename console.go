@@ -0,0 +1,36 @@
+package qstr
+
+import (
+	"strings"
+)
+
+// ConsoleQuote returns s as a double-quoted token that can be interpolated
+// into a DarkPlaces console command, such as say or kick, without ending the
+// token or the command early. Quotes and backslashes are escaped with a
+// backslash and dollar signs are doubled so they aren't expanded as cvars.
+// Newlines and carriage returns, which always end a command, are replaced
+// with spaces, and NUL bytes are dropped. Semicolons need no escaping since
+// they only separate commands outside of quotes. Color codes are kept.
+func (s *QStr) ConsoleQuote() string {
+	var b strings.Builder
+	b.Grow(len(*s) + 2)
+
+	b.WriteByte('"')
+	for _, c := range string(*s) {
+		switch c {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(c)
+		case '$':
+			b.WriteString("$$")
+		case '\n', '\r':
+			b.WriteByte(' ')
+		case 0:
+		default:
+			b.WriteRune(c)
+		}
+	}
+	b.WriteByte('"')
+
+	return b.String()
+}
@@ -0,0 +1,26 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestConsoleQuote(t *testing.T) {
+	var quoteList = []struct {
+		Input    QStr
+		Expected string
+	}{
+		{"^1Anti^7body", `"^1Anti^7body"`},
+		{`a"; quit; say "b`, `"a\"; quit; say \"b"`},
+		{"back\\slash", `"back\\slash"`},
+		{"line\nbreak\r\n", `"line break  "`},
+		{"$rcon_password", `"$$rcon_password"`},
+		{"nul\x00byte", `"nulbyte"`},
+	}
+
+	for _, v := range quoteList {
+		received := v.Input.ConsoleQuote()
+		if received != v.Expected {
+			t.Errorf("Incorrect console quoting of %q. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
@@ -1,5 +1,9 @@
 package qstr
 
+// XonoticDecodeKey maps the private-use glyphs of the fonts shipped with
+// Xonotic and DarkPlaces, U+E000 through U+E0FF, to the Unicode characters
+// that look most like them. Pass it to Decode to make names readable outside
+// the game.
 var XonoticDecodeKey = map[rune]rune{
 	'': ' ',
 	'': ' ',
@@ -258,3 +262,20 @@ var XonoticDecodeKey = map[rune]rune{
 	'': '~',
 	'': '◀',
 }
+
+// XonoticSymbolKey is the part of XonoticDecodeKey that maps glyphs to
+// symbols and emoji rather than to ASCII. Pass it to Encode to turn symbols
+// typed on the web back into the glyphs the game draws them with, while
+// plain letters, digits, and punctuation are sent as they are.
+var XonoticSymbolKey = symbolKey(XonoticDecodeKey)
+
+// symbolKey returns the entries of key that map to characters outside ASCII
+func symbolKey(key map[rune]rune) map[rune]rune {
+	symbols := make(map[rune]rune)
+	for glyph, c := range key {
+		if c > 0x7f {
+			symbols[glyph] = c
+		}
+	}
+	return symbols
+}
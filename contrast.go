@@ -0,0 +1,78 @@
+package qstr
+
+import (
+	"math"
+)
+
+// The minimum contrast ratios WCAG 2.x level AA requires between text and
+// its background, for normal and for large text.
+const (
+	ContrastAA      = 4.5
+	ContrastAALarge = 3.0
+)
+
+// RelativeLuminance returns the relative luminance of c as defined by WCAG
+// 2.x, from 0 for black to 1 for white.
+func (c *RGBColor) RelativeLuminance() float64 {
+	return 0.2126*linearize(c.R) + 0.7152*linearize(c.G) + 0.0722*linearize(c.B)
+}
+
+// ContrastRatio returns the WCAG 2.x contrast ratio between fg and bg, from
+// 1 for identical colors to 21 for black on white. The order of the colors
+// doesn't matter. Compare the result with ContrastAA to check whether text
+// drawn in fg on bg is readable.
+func ContrastRatio(fg, bg RGBColor) float64 {
+	l1, l2 := fg.RelativeLuminance(), bg.RelativeLuminance()
+	return (math.Max(l1, l2) + 0.05) / (math.Min(l1, l2) + 0.05)
+}
+
+// EnsureContrast returns c adjusted as little as possible so that its
+// contrast ratio against bg is at least minRatio, for rendering on a
+// background that CapLightness's bounds weren't chosen for. Only the OKLCH
+// lightness of c is changed, lightening or darkening it, whichever needs the
+// smaller change, so its hue is kept. If no lightness reaches minRatio,
+// black or white is returned, whichever contrasts more with bg.
+func (c *RGBColor) EnsureContrast(bg RGBColor, minRatio float64) RGBColor {
+	if ContrastRatio(*c, bg) >= minRatio {
+		return *c
+	}
+
+	lch := c.OKLCH()
+	at := func(l float64) RGBColor {
+		v := OKLCHColor{l, lch.C, lch.H}
+		return v.RGB()
+	}
+
+	// search is the lightness closest to lch.L, between it and far, whose
+	// color meets minRatio, if far's does
+	search := func(far float64) (float64, bool) {
+		if ContrastRatio(at(far), bg) < minRatio {
+			return 0, false
+		}
+		near := lch.L
+		for i := 0; i < 32; i++ {
+			mid := (near + far) / 2
+			if ContrastRatio(at(mid), bg) >= minRatio {
+				far = mid
+			} else {
+				near = mid
+			}
+		}
+		return far, true
+	}
+
+	lighter, okLighter := search(1)
+	darker, okDarker := search(0)
+	switch {
+	case okLighter && (!okDarker || lighter-lch.L <= lch.L-darker):
+		return at(lighter)
+	case okDarker:
+		return at(darker)
+	}
+
+	black, white := RGBColor{0, 0, 0}, RGBColor{1, 1, 1}
+	if ContrastRatio(black, bg) > ContrastRatio(white, bg) {
+		return black
+	}
+	return white
+}
@@ -0,0 +1,81 @@
+package qstr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRelativeLuminance(t *testing.T) {
+	var luminanceList = []struct {
+		Color    RGBColor
+		Expected float64
+	}{
+		{RGBColor{0, 0, 0}, 0},
+		{RGBColor{1, 1, 1}, 1},
+		{RGBColor{1, 0, 0}, 0.2126},
+		{NewRGBColorFrom255(128, 128, 128), 0.2158605},
+	}
+
+	for _, v := range luminanceList {
+		if received := v.Color.RelativeLuminance(); math.Abs(received-v.Expected) > 0.00001 {
+			t.Errorf("Incorrect luminance of %v. Expected: %v, Got: %v.", v.Color, v.Expected, received)
+		}
+	}
+}
+
+func TestContrastRatio(t *testing.T) {
+	var contrastList = []struct {
+		Fg, Bg   RGBColor
+		Expected float64
+	}{
+		{RGBColor{0, 0, 0}, RGBColor{1, 1, 1}, 21},
+		{RGBColor{1, 1, 1}, RGBColor{0, 0, 0}, 21},
+		{RGBColor{1, 0, 0}, RGBColor{1, 0, 0}, 1},
+		{NewRGBColorFrom255(0x76, 0x76, 0x76), RGBColor{1, 1, 1}, 4.54},
+		{RGBColor{0, 0, 1}, RGBColor{0, 0, 0}, 2.44},
+	}
+
+	for _, v := range contrastList {
+		if received := ContrastRatio(v.Fg, v.Bg); math.Abs(received-v.Expected) > 0.01 {
+			t.Errorf("Incorrect contrast between %v and %v. Expected: %v, Got: %v.", v.Fg, v.Bg, v.Expected, received)
+		}
+	}
+
+	// #767676 is the lightest gray passing AA on white
+	if ContrastRatio(NewRGBColorFrom255(0x77, 0x77, 0x77), RGBColor{1, 1, 1}) >= ContrastAA {
+		t.Errorf("Incorrect contrast. Expected #777777 on white to fail AA.")
+	}
+}
+
+func TestEnsureContrast(t *testing.T) {
+	white, black := RGBColor{1, 1, 1}, RGBColor{0, 0, 0}
+	var contrastList = []struct {
+		Color, Bg RGBColor
+		MinRatio  float64
+	}{
+		{RGBColor{1, 1, 0}, white, ContrastAA},
+		{RGBColor{0, 0, 0.6}, black, ContrastAA},
+		{RGBColor{0.5, 0.5, 0.5}, NewRGBColorFrom255(0x80, 0x80, 0x80), ContrastAALarge},
+		{RGBColor{0.2, 0.6, 1}, white, 7},
+	}
+
+	for _, v := range contrastList {
+		received := v.Color.EnsureContrast(v.Bg, v.MinRatio)
+		if ratio := ContrastRatio(received, v.Bg); ratio < v.MinRatio-0.001 {
+			t.Errorf("Incorrect contrast of %v adjusted to %v on %v. Expected: at least %v, Got: %v.", v.Color, received, v.Bg, v.MinRatio, ratio)
+		}
+		// the adjustment stops close to the requested ratio
+		if ratio := ContrastRatio(received, v.Bg); ratio > v.MinRatio+0.1 {
+			t.Errorf("Incorrect contrast of %v adjusted to %v on %v. Expected: about %v, Got: %v.", v.Color, received, v.Bg, v.MinRatio, ratio)
+		}
+	}
+
+	readable := RGBColor{0, 0, 0.5}
+	if received := readable.EnsureContrast(white, ContrastAA); received != readable {
+		t.Errorf("Incorrect adjustment of %v. Expected: %v, Got: %v.", readable, readable, received)
+	}
+	gray := NewRGBColorFrom255(0x80, 0x80, 0x80)
+	if received := gray.EnsureContrast(gray, 21); received != black {
+		t.Errorf("Incorrect adjustment of %v for an unreachable ratio. Expected: %v, Got: %v.", gray, black, received)
+	}
+}
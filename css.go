@@ -0,0 +1,77 @@
+package qstr
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Hex formats c as a CSS hex color of the form #rrggbb.
+func (c *RGBColor) Hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", to255(c.R), to255(c.G), to255(c.B))
+}
+
+// CSS formats c as a CSS rgb() function, such as rgb(255,0,0).
+func (c *RGBColor) CSS() string {
+	return fmt.Sprintf("rgb(%d,%d,%d)", int(c.R*255.0), int(c.G*255.0), int(c.B*255.0))
+}
+
+// ParseCSSColor parses a color written in CSS as #rgb, #rrggbb, an rgb()
+// function with numbers from 0 to 255 or percentages, separated by commas or
+// spaces, or one of the named colors of CSS such as "tomato". Case and
+// surrounding space are ignored.
+func ParseCSSColor(s string) (RGBColor, error) {
+	invalid := fmt.Errorf("qstr: invalid CSS color %q", s)
+	text := strings.ToLower(strings.TrimSpace(s))
+
+	switch {
+	case strings.HasPrefix(text, "#"):
+		c, err := ParseHex(text)
+		if err != nil {
+			return RGBColor{}, invalid
+		}
+		return c, nil
+
+	case strings.HasPrefix(text, "rgb(") && strings.HasSuffix(text, ")"):
+		args := strings.Fields(strings.ReplaceAll(text[4:len(text)-1], ",", " "))
+		if len(args) != 3 {
+			return RGBColor{}, invalid
+		}
+		var channels [3]float64
+		for i, arg := range args {
+			scale := 255.0
+			if strings.HasSuffix(arg, "%") {
+				arg, scale = arg[:len(arg)-1], 100
+			}
+			v, err := strconv.ParseFloat(arg, 64)
+			if err != nil || v < 0 || v > scale {
+				return RGBColor{}, invalid
+			}
+			channels[i] = v / scale
+		}
+		return RGBColor{channels[0], channels[1], channels[2]}, nil
+	}
+
+	if c, ok := ColorByName(text); ok {
+		return c, nil
+	}
+	return RGBColor{}, invalid
+}
+
+// ColorByName returns the CSS named color called name, such as "tomato".
+// Case is ignored.
+func ColorByName(name string) (RGBColor, bool) {
+	name = strings.ToLower(name)
+	i := sort.Search(len(CSSColors), func(i int) bool { return CSSColors[i].Name >= name })
+	if i < len(CSSColors) && CSSColors[i].Name == name {
+		return CSSColors[i].Color, true
+	}
+	return RGBColor{}, false
+}
+
+// NearestName returns the name of the CSS named color perceptually nearest
+// to c. Where a color has two names, the first alphabetically is returned.
+func (c *RGBColor) NearestName() string {
+	return CSSNamer.Name(*c)
+}
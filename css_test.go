@@ -0,0 +1,87 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestRGBColorHexCSS(t *testing.T) {
+	c := NewRGBColorFrom255(255, 99, 71)
+	if received := c.Hex(); received != "#ff6347" {
+		t.Errorf("Incorrect hex of %v. Expected: %v, Got: %v.", c, "#ff6347", received)
+	}
+	if received := c.CSS(); received != "rgb(255,99,71)" {
+		t.Errorf("Incorrect CSS of %v. Expected: %v, Got: %v.", c, "rgb(255,99,71)", received)
+	}
+}
+
+func TestParseCSSColor(t *testing.T) {
+	tomato := NewRGBColorFrom255(255, 99, 71)
+	var cssList = []struct {
+		Input    string
+		Expected RGBColor
+	}{
+		{"#ff6347", tomato},
+		{"#FF6347", tomato},
+		{"#f00", RGBColor{1, 0, 0}},
+		{"rgb(255, 99, 71)", tomato},
+		{"rgb(255 99 71)", tomato},
+		{"rgb(100%,0%,50%)", RGBColor{1, 0, 0.5}},
+		{" Tomato ", tomato},
+		{"rebeccapurple", NewRGBColorFrom255(102, 51, 153)},
+	}
+
+	for _, v := range cssList {
+		received, err := ParseCSSColor(v.Input)
+		if err != nil || received != v.Expected {
+			t.Errorf("Incorrect color from %q. Expected: %v, Got: %v (%v).", v.Input, v.Expected, received, err)
+		}
+	}
+
+	for _, bad := range []string{"", "#ff63", "#gg0000", "rgb(256,0,0)", "rgb(1,2)", "rgb(1,2,3", "notacolor"} {
+		if _, err := ParseCSSColor(bad); err == nil {
+			t.Errorf("Incorrect error for %q. Expected an error, Got: nil.", bad)
+		}
+	}
+}
+
+func TestColorByName(t *testing.T) {
+	var nameList = []struct {
+		Name     string
+		Expected RGBColor
+		Found    bool
+	}{
+		{"tomato", NewRGBColorFrom255(255, 99, 71), true},
+		{"CornflowerBlue", NewRGBColorFrom255(100, 149, 237), true},
+		{"aliceblue", NewRGBColorFrom255(240, 248, 255), true},
+		{"yellowgreen", NewRGBColorFrom255(154, 205, 50), true},
+		{"grey", NewRGBColorFrom255(128, 128, 128), true},
+		{"notacolor", RGBColor{}, false},
+		{"", RGBColor{}, false},
+	}
+
+	for _, v := range nameList {
+		received, found := ColorByName(v.Name)
+		if received != v.Expected || found != v.Found {
+			t.Errorf("Incorrect color named %v. Expected: %v (%v), Got: %v (%v).", v.Name, v.Expected, v.Found, received, found)
+		}
+	}
+}
+
+func TestNearestName(t *testing.T) {
+	var nameList = []struct {
+		Color    RGBColor
+		Expected string
+	}{
+		{NewRGBColorFrom255(255, 99, 71), "tomato"},
+		{NewRGBColorFrom255(250, 100, 70), "tomato"},
+		{RGBColor{0, 1, 1}, "aqua"},
+		{RGBColor{0, 0, 0}, "black"},
+		{NewRGBColorFrom255(68, 170, 255), "cornflowerblue"},
+	}
+
+	for _, v := range nameList {
+		if received := v.Color.NearestName(); received != v.Expected {
+			t.Errorf("Incorrect name of %v. Expected: %v, Got: %v.", v.Color, v.Expected, received)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package qstr
+
+// CSSColors are the named colors of CSS Color Module Level 4, in
+// alphabetical order. They are the SVG 1.1 colors, derived from X11's, along
+// with rebeccapurple. Some colors have two names, such as aqua and cyan.
+var CSSColors = []NamedColor{
+	{"aliceblue", NewRGBColorFrom255(240, 248, 255)},
+	{"antiquewhite", NewRGBColorFrom255(250, 235, 215)},
+	{"aqua", NewRGBColorFrom255(0, 255, 255)},
+	{"aquamarine", NewRGBColorFrom255(127, 255, 212)},
+	{"azure", NewRGBColorFrom255(240, 255, 255)},
+	{"beige", NewRGBColorFrom255(245, 245, 220)},
+	{"bisque", NewRGBColorFrom255(255, 228, 196)},
+	{"black", NewRGBColorFrom255(0, 0, 0)},
+	{"blanchedalmond", NewRGBColorFrom255(255, 235, 205)},
+	{"blue", NewRGBColorFrom255(0, 0, 255)},
+	{"blueviolet", NewRGBColorFrom255(138, 43, 226)},
+	{"brown", NewRGBColorFrom255(165, 42, 42)},
+	{"burlywood", NewRGBColorFrom255(222, 184, 135)},
+	{"cadetblue", NewRGBColorFrom255(95, 158, 160)},
+	{"chartreuse", NewRGBColorFrom255(127, 255, 0)},
+	{"chocolate", NewRGBColorFrom255(210, 105, 30)},
+	{"coral", NewRGBColorFrom255(255, 127, 80)},
+	{"cornflowerblue", NewRGBColorFrom255(100, 149, 237)},
+	{"cornsilk", NewRGBColorFrom255(255, 248, 220)},
+	{"crimson", NewRGBColorFrom255(220, 20, 60)},
+	{"cyan", NewRGBColorFrom255(0, 255, 255)},
+	{"darkblue", NewRGBColorFrom255(0, 0, 139)},
+	{"darkcyan", NewRGBColorFrom255(0, 139, 139)},
+	{"darkgoldenrod", NewRGBColorFrom255(184, 134, 11)},
+	{"darkgray", NewRGBColorFrom255(169, 169, 169)},
+	{"darkgreen", NewRGBColorFrom255(0, 100, 0)},
+	{"darkgrey", NewRGBColorFrom255(169, 169, 169)},
+	{"darkkhaki", NewRGBColorFrom255(189, 183, 107)},
+	{"darkmagenta", NewRGBColorFrom255(139, 0, 139)},
+	{"darkolivegreen", NewRGBColorFrom255(85, 107, 47)},
+	{"darkorange", NewRGBColorFrom255(255, 140, 0)},
+	{"darkorchid", NewRGBColorFrom255(153, 50, 204)},
+	{"darkred", NewRGBColorFrom255(139, 0, 0)},
+	{"darksalmon", NewRGBColorFrom255(233, 150, 122)},
+	{"darkseagreen", NewRGBColorFrom255(143, 188, 143)},
+	{"darkslateblue", NewRGBColorFrom255(72, 61, 139)},
+	{"darkslategray", NewRGBColorFrom255(47, 79, 79)},
+	{"darkslategrey", NewRGBColorFrom255(47, 79, 79)},
+	{"darkturquoise", NewRGBColorFrom255(0, 206, 209)},
+	{"darkviolet", NewRGBColorFrom255(148, 0, 211)},
+	{"deeppink", NewRGBColorFrom255(255, 20, 147)},
+	{"deepskyblue", NewRGBColorFrom255(0, 191, 255)},
+	{"dimgray", NewRGBColorFrom255(105, 105, 105)},
+	{"dimgrey", NewRGBColorFrom255(105, 105, 105)},
+	{"dodgerblue", NewRGBColorFrom255(30, 144, 255)},
+	{"firebrick", NewRGBColorFrom255(178, 34, 34)},
+	{"floralwhite", NewRGBColorFrom255(255, 250, 240)},
+	{"forestgreen", NewRGBColorFrom255(34, 139, 34)},
+	{"fuchsia", NewRGBColorFrom255(255, 0, 255)},
+	{"gainsboro", NewRGBColorFrom255(220, 220, 220)},
+	{"ghostwhite", NewRGBColorFrom255(248, 248, 255)},
+	{"gold", NewRGBColorFrom255(255, 215, 0)},
+	{"goldenrod", NewRGBColorFrom255(218, 165, 32)},
+	{"gray", NewRGBColorFrom255(128, 128, 128)},
+	{"green", NewRGBColorFrom255(0, 128, 0)},
+	{"greenyellow", NewRGBColorFrom255(173, 255, 47)},
+	{"grey", NewRGBColorFrom255(128, 128, 128)},
+	{"honeydew", NewRGBColorFrom255(240, 255, 240)},
+	{"hotpink", NewRGBColorFrom255(255, 105, 180)},
+	{"indianred", NewRGBColorFrom255(205, 92, 92)},
+	{"indigo", NewRGBColorFrom255(75, 0, 130)},
+	{"ivory", NewRGBColorFrom255(255, 255, 240)},
+	{"khaki", NewRGBColorFrom255(240, 230, 140)},
+	{"lavender", NewRGBColorFrom255(230, 230, 250)},
+	{"lavenderblush", NewRGBColorFrom255(255, 240, 245)},
+	{"lawngreen", NewRGBColorFrom255(124, 252, 0)},
+	{"lemonchiffon", NewRGBColorFrom255(255, 250, 205)},
+	{"lightblue", NewRGBColorFrom255(173, 216, 230)},
+	{"lightcoral", NewRGBColorFrom255(240, 128, 128)},
+	{"lightcyan", NewRGBColorFrom255(224, 255, 255)},
+	{"lightgoldenrodyellow", NewRGBColorFrom255(250, 250, 210)},
+	{"lightgray", NewRGBColorFrom255(211, 211, 211)},
+	{"lightgreen", NewRGBColorFrom255(144, 238, 144)},
+	{"lightgrey", NewRGBColorFrom255(211, 211, 211)},
+	{"lightpink", NewRGBColorFrom255(255, 182, 193)},
+	{"lightsalmon", NewRGBColorFrom255(255, 160, 122)},
+	{"lightseagreen", NewRGBColorFrom255(32, 178, 170)},
+	{"lightskyblue", NewRGBColorFrom255(135, 206, 250)},
+	{"lightslategray", NewRGBColorFrom255(119, 136, 153)},
+	{"lightslategrey", NewRGBColorFrom255(119, 136, 153)},
+	{"lightsteelblue", NewRGBColorFrom255(176, 196, 222)},
+	{"lightyellow", NewRGBColorFrom255(255, 255, 224)},
+	{"lime", NewRGBColorFrom255(0, 255, 0)},
+	{"limegreen", NewRGBColorFrom255(50, 205, 50)},
+	{"linen", NewRGBColorFrom255(250, 240, 230)},
+	{"magenta", NewRGBColorFrom255(255, 0, 255)},
+	{"maroon", NewRGBColorFrom255(128, 0, 0)},
+	{"mediumaquamarine", NewRGBColorFrom255(102, 205, 170)},
+	{"mediumblue", NewRGBColorFrom255(0, 0, 205)},
+	{"mediumorchid", NewRGBColorFrom255(186, 85, 211)},
+	{"mediumpurple", NewRGBColorFrom255(147, 112, 219)},
+	{"mediumseagreen", NewRGBColorFrom255(60, 179, 113)},
+	{"mediumslateblue", NewRGBColorFrom255(123, 104, 238)},
+	{"mediumspringgreen", NewRGBColorFrom255(0, 250, 154)},
+	{"mediumturquoise", NewRGBColorFrom255(72, 209, 204)},
+	{"mediumvioletred", NewRGBColorFrom255(199, 21, 133)},
+	{"midnightblue", NewRGBColorFrom255(25, 25, 112)},
+	{"mintcream", NewRGBColorFrom255(245, 255, 250)},
+	{"mistyrose", NewRGBColorFrom255(255, 228, 225)},
+	{"moccasin", NewRGBColorFrom255(255, 228, 181)},
+	{"navajowhite", NewRGBColorFrom255(255, 222, 173)},
+	{"navy", NewRGBColorFrom255(0, 0, 128)},
+	{"oldlace", NewRGBColorFrom255(253, 245, 230)},
+	{"olive", NewRGBColorFrom255(128, 128, 0)},
+	{"olivedrab", NewRGBColorFrom255(107, 142, 35)},
+	{"orange", NewRGBColorFrom255(255, 165, 0)},
+	{"orangered", NewRGBColorFrom255(255, 69, 0)},
+	{"orchid", NewRGBColorFrom255(218, 112, 214)},
+	{"palegoldenrod", NewRGBColorFrom255(238, 232, 170)},
+	{"palegreen", NewRGBColorFrom255(152, 251, 152)},
+	{"paleturquoise", NewRGBColorFrom255(175, 238, 238)},
+	{"palevioletred", NewRGBColorFrom255(219, 112, 147)},
+	{"papayawhip", NewRGBColorFrom255(255, 239, 213)},
+	{"peachpuff", NewRGBColorFrom255(255, 218, 185)},
+	{"peru", NewRGBColorFrom255(205, 133, 63)},
+	{"pink", NewRGBColorFrom255(255, 192, 203)},
+	{"plum", NewRGBColorFrom255(221, 160, 221)},
+	{"powderblue", NewRGBColorFrom255(176, 224, 230)},
+	{"purple", NewRGBColorFrom255(128, 0, 128)},
+	{"rebeccapurple", NewRGBColorFrom255(102, 51, 153)},
+	{"red", NewRGBColorFrom255(255, 0, 0)},
+	{"rosybrown", NewRGBColorFrom255(188, 143, 143)},
+	{"royalblue", NewRGBColorFrom255(65, 105, 225)},
+	{"saddlebrown", NewRGBColorFrom255(139, 69, 19)},
+	{"salmon", NewRGBColorFrom255(250, 128, 114)},
+	{"sandybrown", NewRGBColorFrom255(244, 164, 96)},
+	{"seagreen", NewRGBColorFrom255(46, 139, 87)},
+	{"seashell", NewRGBColorFrom255(255, 245, 238)},
+	{"sienna", NewRGBColorFrom255(160, 82, 45)},
+	{"silver", NewRGBColorFrom255(192, 192, 192)},
+	{"skyblue", NewRGBColorFrom255(135, 206, 235)},
+	{"slateblue", NewRGBColorFrom255(106, 90, 205)},
+	{"slategray", NewRGBColorFrom255(112, 128, 144)},
+	{"slategrey", NewRGBColorFrom255(112, 128, 144)},
+	{"snow", NewRGBColorFrom255(255, 250, 250)},
+	{"springgreen", NewRGBColorFrom255(0, 255, 127)},
+	{"steelblue", NewRGBColorFrom255(70, 130, 180)},
+	{"tan", NewRGBColorFrom255(210, 180, 140)},
+	{"teal", NewRGBColorFrom255(0, 128, 128)},
+	{"thistle", NewRGBColorFrom255(216, 191, 216)},
+	{"tomato", NewRGBColorFrom255(255, 99, 71)},
+	{"turquoise", NewRGBColorFrom255(64, 224, 208)},
+	{"violet", NewRGBColorFrom255(238, 130, 238)},
+	{"wheat", NewRGBColorFrom255(245, 222, 179)},
+	{"white", NewRGBColorFrom255(255, 255, 255)},
+	{"whitesmoke", NewRGBColorFrom255(245, 245, 245)},
+	{"yellow", NewRGBColorFrom255(255, 255, 0)},
+	{"yellowgreen", NewRGBColorFrom255(154, 205, 50)},
+}
@@ -0,0 +1,75 @@
+package qstr
+
+import (
+	"context"
+	"html/template"
+)
+
+// the number of segments converted between checks of the deadline
+const deadlineInterval = 16
+
+// HTMLContext returns the HTML representation of s, as HTML does, unless ctx
+// is done before the conversion finishes. In that case the rest of the text
+// is appended stripped of its color codes, so a pathologically long value
+// can't stall a request, and false is returned to flag the degraded result.
+// False is also returned if the value was cut short by WithCodeLimit or
+// WithSizeLimit.
+func (r *Renderer) HTMLContext(ctx context.Context, s QStr) (template.HTML, bool) {
+	return r.renderHTML(ctx, s, nil)
+}
+
+// ANSIContext returns s with ANSI escape sequences, as ANSI does, unless ctx
+// is done before the conversion finishes. In that case the rest of the text
+// is appended stripped of its color codes and false is returned.
+func (r *Renderer) ANSIContext(ctx context.Context, s QStr) (string, bool) {
+	return r.renderANSI(ctx, s)
+}
+
+// deadline checks a context periodically while converting segments, along
+// with the code limit.
+type deadline struct {
+	ctx   context.Context
+	count int
+
+	// the number of colored segments admitted, and the most allowed if not
+	// zero
+	codes, codeLimit int
+
+	// hit is set once the context is found to be done
+	hit bool
+}
+
+func newDeadline(ctx context.Context) *deadline {
+	return &deadline{ctx: ctx}
+}
+
+// expired reports whether the context is done, checking it only every
+// deadlineInterval calls.
+func (d *deadline) expired() bool {
+	if d.hit {
+		return true
+	}
+	if d.ctx.Done() == nil {
+		return false
+	}
+	d.count++
+	if d.count%deadlineInterval == 1 && d.ctx.Err() != nil {
+		d.hit = true
+	}
+	return d.hit
+}
+
+// admit reports whether seg may still be converted, counting it against the
+// code limit if it is colored.
+func (d *deadline) admit(seg Segment) bool {
+	if d.expired() {
+		return false
+	}
+	if d.codeLimit > 0 && seg.Code != "" {
+		if d.codes++; d.codes > d.codeLimit {
+			d.hit = true
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+package qstr
+
+import (
+	"context"
+	"html/template"
+	"testing"
+)
+
+func TestHTMLContext(t *testing.T) {
+	nick := QStr("^1Anti^2body")
+	r := NewRenderer()
+
+	received, ok := r.HTMLContext(context.Background(), nick)
+	if !ok || received != nick.HTML() {
+		t.Errorf("Incorrect HTML value returned. Expected: %v (%v), Got: %v (%v).", nick.HTML(), true, received, ok)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expected := template.HTML("Anti&lt;body")
+	received, ok = r.HTMLContext(ctx, "^1Anti<^2body")
+	if ok || received != expected {
+		t.Errorf("Incorrect HTML value returned. Expected: %v (%v), Got: %v (%v).", expected, false, received, ok)
+	}
+
+	received, ok = NewRenderer(WithLinks()).HTMLContext(ctx, "^1Anti<^2body")
+	if ok || received != expected {
+		t.Errorf("Incorrect HTML value returned. Expected: %v (%v), Got: %v (%v).", expected, false, received, ok)
+	}
+}
+
+func TestANSIContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	received, ok := NewRenderer().ANSIContext(ctx, "^1Anti^2body")
+	if ok || received != "Antibody" {
+		t.Errorf("Incorrect ANSI output. Expected: %q (%v), Got: %q (%v).", "Antibody", false, received, ok)
+	}
+}
+
+func TestDeadlineMidway(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// cancel once the first check has passed
+	var nick QStr
+	for i := 0; i < 2*deadlineInterval; i++ {
+		nick += "^1a^2b"
+	}
+	r := NewRenderer()
+	d := newDeadline(ctx)
+	n := 0
+	off := r.dialect.scan(nick, func(seg Segment) bool {
+		if d.expired() {
+			return false
+		}
+		if n++; n == 1 {
+			cancel()
+		}
+		return true
+	})
+
+	if off != len("^1a^2b")*deadlineInterval/2+2 {
+		t.Errorf("Incorrect offset. Expected: %v, Got: %v.", len("^1a^2b")*deadlineInterval/2+2, off)
+	}
+}
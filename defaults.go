@@ -0,0 +1,65 @@
+package qstr
+
+import (
+	"sync"
+)
+
+// defaults holds the package-level configuration used by NewRenderer
+var defaults = struct {
+	sync.RWMutex
+	theme Theme
+
+	// renderer is built on first use after each change, which bumps
+	// version
+	renderer *Renderer
+	version  int
+}{theme: DarkTheme}
+
+// SetDefaultTheme sets the theme used by renderers created without
+// WithTheme, including those behind the QStr methods such as HTML and ANSI.
+// It is meant to be called once at startup, but is safe for concurrent use.
+// Renderers created before the call keep the theme they were created with.
+func SetDefaultTheme(theme Theme) {
+	defaults.Lock()
+	defer defaults.Unlock()
+	defaults.theme = theme
+	defaults.renderer = nil
+	defaults.version++
+}
+
+// SetDefaultPalette sets the palette of the default theme. See
+// SetDefaultTheme.
+func SetDefaultPalette(p Palette) {
+	defaults.Lock()
+	defer defaults.Unlock()
+	defaults.theme.Palette = p
+	defaults.renderer = nil
+	defaults.version++
+}
+
+// DefaultTheme returns the theme set with SetDefaultTheme and
+// SetDefaultPalette, which is DarkTheme unless changed.
+func DefaultTheme() Theme {
+	defaults.RLock()
+	defer defaults.RUnlock()
+	return defaults.theme
+}
+
+// DefaultRenderer returns a Renderer with the default options, shared
+// between callers until the defaults change.
+func DefaultRenderer() *Renderer {
+	defaults.RLock()
+	r, theme, version := defaults.renderer, defaults.theme, defaults.version
+	defaults.RUnlock()
+	if r != nil {
+		return r
+	}
+
+	r = NewRenderer(WithTheme(theme))
+	defaults.Lock()
+	defer defaults.Unlock()
+	if defaults.version == version && defaults.renderer == nil {
+		defaults.renderer = r
+	}
+	return r
+}
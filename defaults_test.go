@@ -0,0 +1,62 @@
+package qstr
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSetDefaultTheme(t *testing.T) {
+	defer SetDefaultTheme(DarkTheme)
+	s := QStr("^1red ^x000black")
+
+	SetDefaultTheme(LightTheme)
+	if received, expected := s.HTML(), s.HTML(WithTheme(LightTheme)); received != expected {
+		t.Errorf("Incorrect HTML with the light default theme. Expected: %v, Got: %v.", expected, received)
+	}
+	if received, expected := DefaultRenderer().HTML(s), s.HTML(WithTheme(LightTheme)); received != expected {
+		t.Errorf("Incorrect HTML from the default renderer. Expected: %v, Got: %v.", expected, received)
+	}
+	if received, expected := s.HTML(WithTheme(DarkTheme)), NewRenderer(WithTheme(DarkTheme)).HTML(s); received != expected {
+		t.Errorf("Incorrect HTML with an explicit theme. Expected: %v, Got: %v.", expected, received)
+	}
+}
+
+func TestSetDefaultPalette(t *testing.T) {
+	defer SetDefaultTheme(DarkTheme)
+	s := QStr("^1red")
+
+	before := DefaultRenderer()
+	p := XonoticPalette
+	p[1] = RGBColor{0, 0, 1}
+	SetDefaultPalette(p)
+
+	if received, expected := s.HTML(), s.HTML(WithPalette(p)); received != expected {
+		t.Errorf("Incorrect HTML with the default palette. Expected: %v, Got: %v.", expected, received)
+	}
+	if DefaultRenderer() == before {
+		t.Errorf("Default renderer was not rebuilt after the palette changed.")
+	}
+	if received := DefaultTheme().Palette; received != p {
+		t.Errorf("Incorrect default palette. Expected: %v, Got: %v.", p, received)
+	}
+}
+
+func TestDefaultsConcurrent(t *testing.T) {
+	defer SetDefaultTheme(DarkTheme)
+	s := QStr("^1red")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetDefaultTheme(LightTheme)
+		}()
+		go func() {
+			defer wg.Done()
+			DefaultRenderer().HTML(s)
+			s.ANSI()
+		}()
+	}
+	wg.Wait()
+}
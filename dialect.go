@@ -0,0 +1,350 @@
+package qstr
+
+import (
+	"iter"
+	"regexp"
+	"strings"
+)
+
+// Dialect describes the flavor of color codes understood by a particular
+// engine. Every dialect understands the basic ^N and ^xNNN color codes;
+// modded engines may define additional codes through Codes.
+type Dialect struct {
+	// Name is a human-readable name for the dialect
+	Name string
+
+	// Caret is the character that introduces every code. The zero value
+	// means '^'.
+	Caret rune
+
+	// Codes are the additional codes understood by the dialect. They are
+	// tried in order after the basic color codes.
+	Codes []ExtCode
+}
+
+// ExtCode is an additional escape sequence understood by a Dialect, such as
+// a background color code.
+type ExtCode struct {
+	// Pattern matches the code, starting right after the caret. It must be
+	// anchored; use NewExtCode to build one from an unanchored expression.
+	Pattern *regexp.Regexp
+
+	// Apply updates the running state when the code is encountered. The
+	// match holds the full code (without the caret) followed by any
+	// submatches of Pattern.
+	Apply func(state *Segment, match []string)
+}
+
+// DarkPlaces is the dialect spoken by the DarkPlaces engine and the games
+// built upon it, such as Xonotic. It is the default for all operations.
+var DarkPlaces = &Dialect{Name: "DarkPlaces"}
+
+// NewExtCode returns an ExtCode matching pattern immediately after the caret.
+// It panics if the pattern cannot be compiled.
+func NewExtCode(pattern string, apply func(state *Segment, match []string)) ExtCode {
+	return ExtCode{
+		Pattern: regexp.MustCompile(`^(?:` + pattern + `)`),
+		Apply:   apply,
+	}
+}
+
+// BackgroundHexCode returns an ExtCode for background colors of the form
+// ^<prefix>NNN, where the Ns are hexadecimal characters. The code ^<prefix>-
+// clears the background again.
+func BackgroundHexCode(prefix string) ExtCode {
+	p := regexp.QuoteMeta(prefix)
+	return NewExtCode(p+`(?:([\dA-Fa-f])([\dA-Fa-f])([\dA-Fa-f])|-)`, func(state *Segment, match []string) {
+		if match[1] == "" {
+			state.Background = RGBColor{}
+			state.HasBackground = false
+			return
+		}
+		state.Background, _ = ParseHex(match[1] + match[2] + match[3])
+		state.HasBackground = true
+	})
+}
+
+// StyleCode returns an ExtCode of the form ^<prefix> that toggles the given
+// formatting attributes on and off.
+func StyleCode(prefix string, style Style) ExtCode {
+	return NewExtCode(regexp.QuoteMeta(prefix), func(state *Segment, match []string) {
+		state.Style ^= style
+	})
+}
+
+// ResetStyleCode returns an ExtCode of the form ^<prefix> that clears all
+// formatting attributes.
+func ResetStyleCode(prefix string) ExtCode {
+	return NewExtCode(regexp.QuoteMeta(prefix), func(state *Segment, match []string) {
+		state.Style = 0
+	})
+}
+
+// Tokenize breaks s into its segments according to the dialect. Segments are
+// only returned for non-empty runs of text.
+func (d *Dialect) Tokenize(s QStr) []Segment {
+	segments := make([]Segment, 0)
+	d.scan(s, func(seg Segment) bool {
+		segments = append(segments, seg)
+		return true
+	})
+	return segments
+}
+
+// Segments returns an iterator over the segments of s according to the
+// dialect, without collecting them into a slice first.
+func (d *Dialect) Segments(s QStr) iter.Seq[Segment] {
+	return func(yield func(Segment) bool) {
+		d.scan(s, yield)
+	}
+}
+
+// scan makes a single left-to-right pass over s, calling emit with each
+// segment as soon as it is complete. If emit returns false the scan stops,
+// and scan returns the offset into s of the text of the segment that was
+// refused; otherwise it returns len(s).
+func (d *Dialect) scan(s QStr, emit func(Segment) bool) int {
+	return d.scanWarnings(s, emit, nil)
+}
+
+// scanWarnings is scan, also adding the malformed codes it passes over to
+// warn and keeping warn's current code up to date, if warn is not nil.
+func (d *Dialect) scanWarnings(s QStr, emit func(Segment) bool, warn *warningList) int {
+	raw := string(s)
+
+	// the text of a segment is sliced out of raw, unless a ^^ escape breaks
+	// it up; the pieces before the escape are then gathered in text
+	var state Segment
+	var text strings.Builder
+	runStart, textStart := 0, 0
+	i := 0
+	stopped := false
+	flush := func() {
+		t := raw[runStart:i]
+		if text.Len() > 0 {
+			text.WriteString(t)
+			t = text.String()
+			text.Reset()
+		}
+		if t != "" && !stopped {
+			state.Text = t
+			stopped = !emit(state)
+		}
+	}
+
+	caret := d.caret()
+	for {
+		j := strings.Index(raw[i:], caret)
+		if j < 0 {
+			break
+		}
+		i += j
+
+		if strings.HasPrefix(raw[i+len(caret):], caret) {
+			// a doubled caret stands for a literal one
+			text.WriteString(raw[runStart : i+len(caret)])
+			i += 2 * len(caret)
+			runStart = i
+			continue
+		}
+
+		if n := colorCodeLen(raw[i+len(caret):]); n > 0 {
+			if flush(); stopped {
+				return textStart
+			}
+			// codes are kept in their ^ form regardless of the caret
+			if caret == "^" {
+				state.Code = Code(raw[i : i+1+n])
+			} else {
+				state.Code = Code("^" + raw[i+len(caret):i+len(caret)+n])
+			}
+			state.Color = state.Code.Color(&XonoticPalette)
+			if warn != nil {
+				warn.setCode(i, raw[i:i+len(caret)+n])
+			}
+			i += len(caret) + n
+			runStart, textStart = i, i
+			continue
+		}
+
+		if len(d.Codes) > 0 {
+			// extension codes are applied to a copy, so that state does
+			// not escape to the heap when the dialect has none
+			ext := state
+			if n := d.applyExtCode(raw[i+len(caret):], &ext, flush); n > 0 {
+				if stopped {
+					return textStart
+				}
+				state = ext
+				i += len(caret) + n
+				runStart, textStart = i, i
+				continue
+			}
+		}
+
+		if warn != nil {
+			if n, ok := d.malformedCodeLen(raw[i+len(caret):]); ok {
+				warn.add(Warning{Kind: MalformedCode, Offset: i, Text: raw[i : i+len(caret)+n]})
+			}
+		}
+		i++
+	}
+	i = len(raw)
+	if flush(); stopped {
+		return textStart
+	}
+	return len(raw)
+}
+
+// Strip returns the visible text of s, removing every code understood by the
+// dialect.
+func (d *Dialect) Strip(s QStr) string {
+	if !strings.Contains(string(s), d.caret()) {
+		return string(s)
+	}
+	var b strings.Builder
+	d.scan(s, func(seg Segment) bool {
+		b.WriteString(seg.Text)
+		return true
+	})
+	return b.String()
+}
+
+// Join reassembles segments into a QStr using the dialect's caret, emitting
+// each segment's color code only when it differs from the one already in
+// effect. Uncolored segments following colored ones are preceded by a reset
+// to the default color. Carets in the text that would otherwise start a code
+// are doubled. Extension attributes are not written back.
+func (d *Dialect) Join(segments []Segment) QStr {
+	caret := d.caret()
+	var b strings.Builder
+	var text strings.Builder
+	var code Code
+	for _, seg := range segments {
+		if seg.Code != code {
+			b.WriteString(d.escape(text.String(), true))
+			text.Reset()
+			if seg.Code == "" {
+				// back to uncolored text
+				b.WriteString(caret + resetCode[1:])
+			} else {
+				b.WriteString(caret + string(seg.Code[1:]))
+			}
+			code = seg.Code
+		}
+		text.WriteString(seg.Text)
+	}
+	b.WriteString(d.escape(text.String(), false))
+	return QStr(b.String())
+}
+
+// escape returns text with each caret that would start a code or an escape
+// doubled. If more is true, a code follows text, so a trailing caret is
+// doubled too.
+func (d *Dialect) escape(text string, more bool) string {
+	caret := d.caret()
+	if !strings.Contains(text, caret) {
+		return text
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		if !strings.HasPrefix(text[i:], caret) {
+			b.WriteByte(text[i])
+			i++
+			continue
+		}
+		rest := text[i+len(caret):]
+		b.WriteString(caret)
+		if (rest == "" && more) || strings.HasPrefix(rest, caret) || colorCodeLen(rest) > 0 || d.extCodeLen(rest) > 0 {
+			b.WriteString(caret)
+		}
+		i += len(caret)
+	}
+	return b.String()
+}
+
+// caret returns the string introducing the dialect's codes
+func (d *Dialect) caret() string {
+	if d.Caret == 0 {
+		return "^"
+	}
+	return string(d.Caret)
+}
+
+// applyExtCode looks for one of the dialect's extension codes at the start of
+// rest. If one is found, flush is called before the state is updated and the
+// length of the code is returned; otherwise it returns 0.
+func (d *Dialect) applyExtCode(rest string, state *Segment, flush func()) int {
+	for _, ext := range d.Codes {
+		m := ext.Pattern.FindStringSubmatch(rest)
+		if m == nil || len(m[0]) == 0 {
+			continue
+		}
+		flush()
+		ext.Apply(state, m)
+		return len(m[0])
+	}
+	return 0
+}
+
+// extCodeLen returns the length of the extension code at the start of rest,
+// which follows a caret, or 0 if there is none.
+func (d *Dialect) extCodeLen(rest string) int {
+	for _, ext := range d.Codes {
+		if loc := ext.Pattern.FindStringIndex(rest); loc != nil && loc[1] > 0 {
+			return loc[1]
+		}
+	}
+	return 0
+}
+
+// malformedCodeLen reports whether rest, which follows a caret that starts
+// no code, looks like a code cut short: a hex code with fewer than three
+// digits, or nothing at all. It also returns the length of the part of rest
+// belonging to the malformed code.
+func (d *Dialect) malformedCodeLen(rest string) (int, bool) {
+	switch {
+	case rest == "":
+		return 0, true
+	case rest[0] != 'x' || d.extCodeLen(rest) > 0:
+		return 0, false
+	}
+	n := 1
+	for n < 4 && n < len(rest) && isHexDigit(rest[n]) {
+		n++
+	}
+	return n, true
+}
+
+// basicCodeLen returns the length of the ^N or ^xNNN color code at the start
+// of s, or 0 if s does not start with one.
+func basicCodeLen(s string) int {
+	if len(s) < 2 || s[0] != '^' {
+		return 0
+	}
+	if n := colorCodeLen(s[1:]); n > 0 {
+		return n + 1
+	}
+	return 0
+}
+
+// colorCodeLen returns the length of the N or xNNN part of a color code at
+// the start of s, which follows the caret, or 0 if there is none.
+func colorCodeLen[T string | []byte](s T) int {
+	if len(s) >= 1 && isDigit(s[0]) {
+		return 1
+	}
+	if len(s) >= 4 && s[0] == 'x' && isHexDigit(s[1]) && isHexDigit(s[2]) && isHexDigit(s[3]) {
+		return 4
+	}
+	return 0
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
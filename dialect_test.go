@@ -0,0 +1,116 @@
+package qstr
+
+import (
+	"html/template"
+	"reflect"
+	"testing"
+)
+
+var bgDialect = &Dialect{
+	Name:  "test",
+	Codes: []ExtCode{BackgroundHexCode("b")},
+}
+
+func TestTokenize(t *testing.T) {
+	nick := QStr("Anti^1bo^bF00d^b-y^^")
+
+	expected := []Segment{
+		{Text: "Anti"},
+		{Text: "bo", Code: "^1", Color: RGBColor{1, 0, 0}},
+		{Text: "d", Code: "^1", Color: RGBColor{1, 0, 0}, Background: RGBColor{1, 0, 0}, HasBackground: true},
+		{Text: "y^", Code: "^1", Color: RGBColor{1, 0, 0}},
+	}
+	received := bgDialect.Tokenize(nick)
+
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("Incorrect tokenization of %v. Expected: %+v, Got: %+v.", nick, expected, received)
+	}
+}
+
+func TestDialectStrip(t *testing.T) {
+	nick := QStr("^bF00Anti^7bo^b-dy")
+	expected := "Antibody"
+
+	if received := bgDialect.Strip(nick); received != expected {
+		t.Errorf("Incorrect stripping applied to %v. Expected: %v, Got: %v.", nick, expected, received)
+	}
+
+	// the default dialect leaves unknown codes alone
+	expected = "^bF00Antibo^b-dy"
+	if received := DarkPlaces.Strip(nick); received != expected {
+		t.Errorf("Incorrect stripping applied to %v. Expected: %v, Got: %v.", nick, expected, received)
+	}
+}
+
+func TestDialectHTML(t *testing.T) {
+	nick := QStr("^bFFFAnti^1body")
+	expected := template.HTML("<span style=\"background-color:rgb(255,255,255)\">Anti<span style=\"color:rgb(255,0,0);background-color:rgb(255,255,255)\">body</span></span>")
+	received := nick.HTML(WithDialect(bgDialect))
+
+	if received != expected {
+		t.Errorf("Incorrect HTML value returned. Expected: %v, Got: %v.", expected, received)
+	}
+}
+
+func TestStyleCodes(t *testing.T) {
+	d := &Dialect{
+		Name:  "test",
+		Codes: []ExtCode{StyleCode("b", Bold), StyleCode("i", Italic), StyleCode("u", Underline), ResetStyleCode("r")},
+	}
+	nick := QStr("^bAnti^i^1bo^udy^rz^b")
+
+	expected := []Style{Bold, Bold | Italic, Bold | Italic | Underline, 0}
+	segments := d.Tokenize(nick)
+	if len(segments) != len(expected) {
+		t.Fatalf("Incorrect number of segments for %v. Expected: %v, Got: %v.", nick, len(expected), len(segments))
+	}
+	for i, seg := range segments {
+		if seg.Style != expected[i] {
+			t.Errorf("Incorrect style for segment %q. Expected: %v, Got: %v.", seg.Text, expected[i], seg.Style)
+		}
+	}
+
+	expectedHTML := template.HTML("<span style=\"font-weight:bold\">Anti" +
+		"<span style=\"color:rgb(255,0,0);font-weight:bold;font-style:italic\">bo" +
+		"<span style=\"color:rgb(255,0,0);font-weight:bold;font-style:italic;text-decoration:underline\">dy" +
+		"<span style='color:rgb(255,0,0)'>z</span></span></span></span>")
+	if received := nick.HTML(WithDialect(d)); received != expectedHTML {
+		t.Errorf("Incorrect HTML value returned. Expected: %v, Got: %v.", expectedHTML, received)
+	}
+}
+
+func TestDialectCaret(t *testing.T) {
+	d := &Dialect{Name: "test", Caret: '&', Codes: []ExtCode{StyleCode("b", Bold)}}
+	nick := QStr("Anti&1bo&bd&x444y^2")
+
+	expected := []Segment{
+		{Text: "Anti"},
+		{Text: "bo", Code: "^1", Color: RGBColor{1, 0, 0}},
+		{Text: "d", Code: "^1", Color: RGBColor{1, 0, 0}, Style: Bold},
+		{Text: "y^2", Code: "^x444", Color: HexToRGB("4", "4", "4"), Style: Bold},
+	}
+	received := d.Tokenize(nick)
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("Incorrect tokenization of %v. Expected: %+v, Got: %+v.", nick, expected, received)
+	}
+
+	if received := d.Strip(nick); received != "Antibody^2" {
+		t.Errorf("Incorrect stripping applied to %v. Expected: %v, Got: %v.", nick, "Antibody^2", received)
+	}
+
+	joined := d.Join([]Segment{{Text: "A"}, {Text: "nti", Code: "^1"}, {Text: "body"}})
+	if joined != "A&1nti&7body" {
+		t.Errorf("Incorrect join. Expected: %v, Got: %v.", "A&1nti&7body", joined)
+	}
+}
+
+func TestJoinEscapes(t *testing.T) {
+	segs := []Segment{{Text: "^1 a^"}, {Text: "b^^", Code: "^2", Color: RGBColor{0.2, 1, 0}}}
+	joined := DarkPlaces.Join(segs)
+	if joined != "^^1 a^^^2b^^^" {
+		t.Errorf("Incorrect join. Expected: %v, Got: %v.", "^^1 a^^^2b^^^", joined)
+	}
+	if received := DarkPlaces.Tokenize(joined); !reflect.DeepEqual(received, segs) {
+		t.Errorf("Incorrect tokenization of %v. Expected: %+v, Got: %+v.", joined, segs, received)
+	}
+}
@@ -0,0 +1,77 @@
+package qstr
+
+// DiffOption adjusts how Diff marks changes.
+type DiffOption func(*diffConfig)
+
+type diffConfig struct {
+	insert, delete Code
+}
+
+// DiffInsertions sets the code coloring inserted text. The default is ^2.
+func DiffInsertions(code Code) DiffOption {
+	return func(c *diffConfig) {
+		c.insert = code
+	}
+}
+
+// DiffDeletions sets the code coloring deleted text. The default is ^1.
+func DiffDeletions(code Code) DiffOption {
+	return func(c *diffConfig) {
+		c.delete = code
+	}
+}
+
+// Diff compares the visible text of old and new, returning the text of both
+// merged in place, such as for a history of name changes: text only in old
+// is shown in the deletion color, text only in new in the insertion color,
+// and text common to both keeps its colors from new. Text is compared by
+// grapheme cluster, and where both have changes, deletions come first.
+func Diff(old, new QStr, opts ...DiffOption) QStr {
+	c := diffConfig{insert: "^2", delete: "^1"}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	oldG := graphemes(old.Stripped())
+	newSegments := DarkPlaces.Tokenize(new)
+	newG := graphemes(segmentsText(newSegments))
+
+	// lcs[i][j] is the length of the longest common subsequence of
+	// oldG[i:] and newG[j:]
+	lcs := make([][]int, len(oldG)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newG)+1)
+	}
+	for i := len(oldG) - 1; i >= 0; i-- {
+		for j := len(newG) - 1; j >= 0; j-- {
+			if oldG[i] == newG[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var res []Segment
+	i, j, off := 0, 0, 0
+	keep := func() {
+		res = append(res, byteRangeSegments(newSegments, off, off+len(newG[j]))...)
+		off += len(newG[j])
+		j++
+	}
+	for i < len(oldG) || j < len(newG) {
+		switch {
+		case i < len(oldG) && j < len(newG) && oldG[i] == newG[j]:
+			keep()
+			i++
+		case i < len(oldG) && (j == len(newG) || lcs[i+1][j] >= lcs[i][j+1]):
+			res = append(res, Segment{Text: oldG[i], Code: c.delete})
+			i++
+		default:
+			res = append(res, Segment{Text: newG[j], Code: c.insert})
+			off += len(newG[j])
+			j++
+		}
+	}
+	return joinSegments(res)
+}
@@ -0,0 +1,26 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	var diffList = []struct {
+		Old, New QStr
+		Opts     []DiffOption
+		Expected QStr
+	}{
+		{"^1Anti^2body", "^3Antibody", nil, "^3Antibody"},
+		{"Antibody", "^5Anybody", nil, "^5An^1ti^2y^5body"},
+		{"^4[x]nick", "nick", nil, "^1[x]^7nick"},
+		{"nick", "nick^3!", []DiffOption{DiffInsertions("^x0f0")}, "nick^x0f0!"},
+		{"abc", "", []DiffOption{DiffDeletions("^x777")}, "^x777abc"},
+		{"", "", nil, ""},
+	}
+
+	for _, v := range diffList {
+		if received := Diff(v.Old, v.New, v.Opts...); received != v.Expected {
+			t.Errorf("Incorrect diff of %q and %q. Expected: %q, Got: %q.", v.Old, v.New, v.Expected, received)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package qstr
+
+import (
+	"strconv"
+	"strings"
+)
+
+// discordColors are the foreground colors Discord shows for SGR codes 30
+// through 37 inside ```ansi code blocks
+var discordColors = [8]RGBColor{
+	NewRGBColorFrom255(0x4f, 0x54, 0x5c),
+	NewRGBColorFrom255(0xdc, 0x32, 0x2f),
+	NewRGBColorFrom255(0x85, 0x99, 0x00),
+	NewRGBColorFrom255(0xb5, 0x89, 0x00),
+	NewRGBColorFrom255(0x26, 0x8b, 0xd2),
+	NewRGBColorFrom255(0xd3, 0x36, 0x82),
+	NewRGBColorFrom255(0x2a, 0xa1, 0x98),
+	NewRGBColorFrom255(0xff, 0xff, 0xff),
+}
+
+// DiscordANSI returns s with its color codes converted into the ANSI SGR
+// sequences that Discord renders inside ```ansi code blocks. Options may be
+// given to alter the output; see Renderer.
+func (s *QStr) DiscordANSI(opts ...Option) string {
+	return NewRenderer(opts...).DiscordANSI(*s)
+}
+
+// DiscordANSI returns s with its color codes converted into the ANSI SGR
+// sequences that Discord renders inside ```ansi code blocks, for posting
+// names from bots. Discord only supports eight foreground colors, so each
+// color is replaced by the perceptually nearest of them, and of the
+// dialect's styles only bold and underline are kept. Backticks are followed
+// by a zero-width space so the text can't close the code block. The output
+// ends with a reset if any attribute was set; wrap it in "```ansi\n" and
+// "\n```" to post it.
+func (r *Renderer) DiscordANSI(s QStr) string {
+	var b strings.Builder
+	styled := false
+	for _, seg := range r.dialect.Tokenize(s) {
+		params := make([]string, 0, 3)
+		if seg.Style.Has(Bold) {
+			params = append(params, "1")
+		}
+		if seg.Style.Has(Underline) {
+			params = append(params, "4")
+		}
+		if seg.Code != "" {
+			c := r.color(seg)
+			if seg.Code.IsHex() {
+				c = r.capLightness(c)
+			}
+			params = append(params, strconv.Itoa(30+nearestDiscordColor(c)))
+		}
+
+		switch {
+		case len(params) > 0:
+			if styled {
+				params = append([]string{"0"}, params...)
+			}
+			b.WriteString("\x1b[" + strings.Join(params, ";") + "m")
+			styled = true
+		case styled:
+			b.WriteString(ansiReset)
+			styled = false
+		}
+		b.WriteString(strings.ReplaceAll(r.terminalText(seg.Text), "`", "`\u200b"))
+	}
+	if styled {
+		b.WriteString(ansiReset)
+	}
+	return b.String()
+}
+
+// nearestDiscordColor returns the index of the Discord color perceptually
+// nearest to c
+func nearestDiscordColor(c RGBColor) int {
+	lab := c.Lab()
+	best, bestDist := 0, -1.0
+	for i := range discordColors {
+		if d := lab.distance2000(discordColors[i].Lab()); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
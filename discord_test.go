@@ -0,0 +1,24 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestDiscordANSI(t *testing.T) {
+	var discordList = []struct {
+		Input    QStr
+		Expected string
+	}{
+		{"^1Anti^4body", "\x1b[31mAnti\x1b[0;34mbody\x1b[0m"},
+		{"^2green^7 white ^0gray", "\x1b[32mgreen\x1b[0;37m white \x1b[0;30mgray\x1b[0m"},
+		{"^x4afAnti^x0F0body", "\x1b[34mAnti\x1b[0;32mbody\x1b[0m"},
+		{"plain ```", "plain `\u200b`\u200b`\u200b"},
+		{"", ""},
+	}
+
+	for _, v := range discordList {
+		if received := v.Input.DiscordANSI(); received != v.Expected {
+			t.Errorf("Incorrect Discord ANSI for %v. Expected: %q, Got: %q.", v.Input, v.Expected, received)
+		}
+	}
+}
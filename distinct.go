@@ -0,0 +1,78 @@
+package qstr
+
+// DistinctOption adjusts the colors picked by DistinctColors.
+type DistinctOption func(*distinctConfig)
+
+type distinctConfig struct {
+	lightness, chroma, hue float64
+	background             *RGBColor
+}
+
+// DistinctLightness sets the OKLCH lightness of the colors picked, in the
+// range [0, 1]. The default is 0.72, which reads well on a dark background.
+func DistinctLightness(lightness float64) DistinctOption {
+	return func(c *distinctConfig) {
+		c.lightness = lightness
+	}
+}
+
+// DistinctChroma sets the OKLCH chroma of the colors picked. The default is
+// 0.13; hues that can't reach it within sRGB get the most chroma they can.
+func DistinctChroma(chroma float64) DistinctOption {
+	return func(c *distinctConfig) {
+		c.chroma = chroma
+	}
+}
+
+// DistinctHue sets the hue in degrees of the first color picked. The
+// default is 30, a red.
+func DistinctHue(hue float64) DistinctOption {
+	return func(c *distinctConfig) {
+		c.hue = hue
+	}
+}
+
+// DistinctBackground adjusts the lightness of each color picked until it
+// has a contrast ratio of at least ContrastAALarge against bg, as with
+// EnsureContrast.
+func DistinctBackground(bg RGBColor) DistinctOption {
+	return func(c *distinctConfig) {
+		c.background = &bg
+	}
+}
+
+// DistinctColors returns n colors that are as easy to tell apart as
+// possible, such as one per team in a bracket. The hues are spaced evenly
+// around the OKLCH hue circle at a constant lightness and chroma, so no
+// color stands out more than the others. Beyond eight colors neighboring
+// hues come close, so every other color is made a little lighter and the
+// rest a little darker.
+func DistinctColors(n int, opts ...DistinctOption) []RGBColor {
+	c := &distinctConfig{lightness: 0.72, chroma: 0.13, hue: 30}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	colors := make([]RGBColor, 0, max(n, 0))
+	for i := 0; i < n; i++ {
+		lch := OKLCHColor{c.lightness, c.chroma, c.hue + float64(i)*360/float64(n)}
+		for lch.H >= 360 {
+			lch.H -= 360
+		}
+		if n > 8 {
+			if i%2 == 0 {
+				lch.L += 0.06
+			} else {
+				lch.L -= 0.06
+			}
+			lch.L = min(max(lch.L, 0), 1)
+		}
+
+		rgb := lch.RGB()
+		if c.background != nil {
+			rgb = rgb.EnsureContrast(*c.background, ContrastAALarge)
+		}
+		colors = append(colors, rgb)
+	}
+	return colors
+}
@@ -0,0 +1,33 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestDistinctColors(t *testing.T) {
+	for _, n := range []int{1, 2, 5, 12} {
+		colors := DistinctColors(n, DistinctBackground(RGBColor{0, 0, 0}))
+		if len(colors) != n {
+			t.Errorf("Incorrect number of colors. Expected: %v, Got: %v.", n, len(colors))
+		}
+		for i, a := range colors {
+			if ratio := ContrastRatio(a, RGBColor{0, 0, 0}); ratio < ContrastAALarge {
+				t.Errorf("Incorrect contrast of color %v of %v. Expected at least: %v, Got: %v.", i, n, ContrastAALarge, ratio)
+			}
+			for _, b := range colors[i+1:] {
+				if d := DeltaE(a, b); d < 10 {
+					t.Errorf("Incorrect distance between colors of %v. Expected at least: %v, Got: %v.", n, 10, d)
+				}
+			}
+		}
+	}
+
+	if colors := DistinctColors(0); len(colors) != 0 {
+		t.Errorf("Incorrect number of colors. Expected: %v, Got: %v.", 0, len(colors))
+	}
+
+	first := DistinctColors(3, DistinctHue(120), DistinctLightness(0.5))[0]
+	if lch := first.OKLCH(); lch.H < 110 || lch.H > 130 || lch.L < 0.49 || lch.L > 0.51 {
+		t.Errorf("Incorrect first color. Expected hue 120 and lightness 0.5, Got: %+v.", lch)
+	}
+}
@@ -0,0 +1,40 @@
+package qstr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// emailTag returns the opening <font> element for a segment in the email
+// profile described by WithEmail, or an empty string if the segment needs
+// none.
+func (r *Renderer) emailTag(seg Segment) string {
+	if !seg.styled() {
+		return ""
+	}
+
+	attr := ""
+	decls := make([]string, 0, 5)
+	if seg.Code != "" {
+		c := r.color(seg)
+		c = r.capLightness(c)
+		attr = fmt.Sprintf(" color=\"%s\"", c.Hex())
+		decls = append(decls, "color:"+c.Hex())
+	}
+	if seg.HasBackground {
+		decls = append(decls, "background-color:"+seg.Background.Hex())
+	}
+	decls = appendStyleDecls(decls, seg.Style)
+
+	return fmt.Sprintf("<font%s style=\"%s\">", attr, strings.Join(decls, ";"))
+}
+
+// PlainText returns the visible text of s with the renderer's character
+// translations applied, such as the plain-text alternative of an HTML email.
+func (r *Renderer) PlainText(s QStr) string {
+	var b strings.Builder
+	for _, seg := range r.dialect.Tokenize(s) {
+		b.WriteString(r.text(seg.Text))
+	}
+	return b.String()
+}
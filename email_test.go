@@ -0,0 +1,51 @@
+package qstr
+
+import (
+	"html/template"
+	"testing"
+)
+
+func TestHTMLEmail(t *testing.T) {
+	var emailList = []struct {
+		Input    QStr
+		Options  []Option
+		Expected template.HTML
+	}{
+		{
+			"^1Anti^7body",
+			nil,
+			"<font color=\"#ff0000\" style=\"color:#ff0000\">Anti</font><font color=\"#808080\" style=\"color:#808080\">body</font>",
+		},
+		{
+			"[X] ^x4AFAnti<",
+			nil,
+			"[X] <font color=\"#008bff\" style=\"color:#008bff\">Anti&lt;</font>",
+		},
+		{
+			"^bAnti^1body",
+			[]Option{WithDialect(&Dialect{Codes: []ExtCode{StyleCode("b", Bold)}})},
+			"<font style=\"font-weight:bold\">Anti</font><font color=\"#ff0000\" style=\"color:#ff0000;font-weight:bold\">body</font>",
+		},
+		{
+			"[X] ^1Anti^7body",
+			[]Option{WithClassFunc(func(seg Segment, index int) string { return "nick" })},
+			"<font class=\"nick\">[X] </font><font class=\"nick\" color=\"#ff0000\" style=\"color:#ff0000\">Anti</font><font class=\"nick\" color=\"#808080\" style=\"color:#808080\">body</font>",
+		},
+	}
+
+	for _, v := range emailList {
+		received := v.Input.HTML(append([]Option{WithEmail()}, v.Options...)...)
+		if received != v.Expected {
+			t.Errorf("Incorrect email HTML for %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	nick := QStr("^1Anti\ue062ody")
+	r := NewRenderer(WithDecodeKey(XonoticDecodeKey))
+
+	if received := r.PlainText(nick); received != "Antibody" {
+		t.Errorf("Incorrect plain text for %v. Expected: %v, Got: %v.", nick, "Antibody", received)
+	}
+}
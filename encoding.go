@@ -0,0 +1,134 @@
+package qstr
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MarshalText returns the raw string of s. It implements
+// encoding.TextMarshaler.
+func (s QStr) MarshalText() ([]byte, error) {
+	return []byte(s), nil
+}
+
+// UnmarshalText sets s to the raw string in text. It implements
+// encoding.TextUnmarshaler.
+func (s *QStr) UnmarshalText(text []byte) error {
+	*s = QStr(text)
+	return nil
+}
+
+// MarshalBinary returns the raw string of s. It implements
+// encoding.BinaryMarshaler.
+func (s QStr) MarshalBinary() ([]byte, error) {
+	return []byte(s), nil
+}
+
+// UnmarshalBinary sets s to the raw string in data. It implements
+// encoding.BinaryUnmarshaler.
+func (s *QStr) UnmarshalBinary(data []byte) error {
+	*s = QStr(data)
+	return nil
+}
+
+// MarshalText returns c as #rrggbb. It implements encoding.TextMarshaler.
+func (c RGBColor) MarshalText() ([]byte, error) {
+	return []byte(c.Hex()), nil
+}
+
+// UnmarshalText sets c to the color written as #rrggbb in text. It
+// implements encoding.TextUnmarshaler.
+func (c *RGBColor) UnmarshalText(text []byte) error {
+	v, err := parseHexColor(string(text))
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
+
+// MarshalBinary returns the channels of c as three big-endian float64s, so
+// that unlike the text form no precision is lost. It implements
+// encoding.BinaryMarshaler.
+func (c RGBColor) MarshalBinary() ([]byte, error) {
+	return marshalFloats(c.R, c.G, c.B), nil
+}
+
+// UnmarshalBinary sets c to the color encoded in data by MarshalBinary. It
+// implements encoding.BinaryUnmarshaler.
+func (c *RGBColor) UnmarshalBinary(data []byte) error {
+	return unmarshalFloats(data, &c.R, &c.G, &c.B)
+}
+
+// MarshalText returns c as its hue, saturation, and lightness separated by
+// commas, each in the range [0, 1], such as "0.5,1,0.25". It implements
+// encoding.TextMarshaler.
+func (c HSLColor) MarshalText() ([]byte, error) {
+	var b []byte
+	for i, v := range []float64{c.H, c.S, c.L} {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = strconv.AppendFloat(b, v, 'g', -1, 64)
+	}
+	return b, nil
+}
+
+// UnmarshalText sets c to the color written in text by MarshalText. It
+// implements encoding.TextUnmarshaler.
+func (c *HSLColor) UnmarshalText(text []byte) error {
+	parts := strings.Split(string(text), ",")
+	if len(parts) != 3 {
+		return fmt.Errorf("qstr: invalid HSL color %q", text)
+	}
+	var v [3]float64
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil || f < 0 || f > 1 {
+			return fmt.Errorf("qstr: invalid HSL color %q", text)
+		}
+		v[i] = f
+	}
+	*c = HSLColor{v[0], v[1], v[2]}
+	return nil
+}
+
+// MarshalBinary returns the components of c as three big-endian float64s.
+// It implements encoding.BinaryMarshaler.
+func (c HSLColor) MarshalBinary() ([]byte, error) {
+	return marshalFloats(c.H, c.S, c.L), nil
+}
+
+// UnmarshalBinary sets c to the color encoded in data by MarshalBinary. It
+// implements encoding.BinaryUnmarshaler.
+func (c *HSLColor) UnmarshalBinary(data []byte) error {
+	return unmarshalFloats(data, &c.H, &c.S, &c.L)
+}
+
+// errBadColorBinary is returned when decoding a binary color of the wrong
+// length
+var errBadColorBinary = errors.New("qstr: invalid binary color")
+
+// marshalFloats encodes vs as big-endian float64s
+func marshalFloats(vs ...float64) []byte {
+	b := make([]byte, 0, 8*len(vs))
+	for _, v := range vs {
+		b = binary.BigEndian.AppendUint64(b, math.Float64bits(v))
+	}
+	return b
+}
+
+// unmarshalFloats decodes the float64s encoded by marshalFloats into vs
+func unmarshalFloats(data []byte, vs ...*float64) error {
+	if len(data) != 8*len(vs) {
+		return errBadColorBinary
+	}
+	for i, v := range vs {
+		*v = math.Float64frombits(binary.BigEndian.Uint64(data[8*i:]))
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package qstr
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+func TestRGBColorText(t *testing.T) {
+	c := NewRGBColorFrom255(68, 170, 255)
+	text, err := c.MarshalText()
+	if err != nil || string(text) != "#44aaff" {
+		t.Errorf("Incorrect text of %v. Expected: %v, Got: %s (%v).", c, "#44aaff", text, err)
+	}
+
+	var received RGBColor
+	if err := received.UnmarshalText(text); err != nil || received != c {
+		t.Errorf("Incorrect color from %s. Expected: %v, Got: %v (%v).", text, c, received, err)
+	}
+	if err := received.UnmarshalText([]byte("44aaff")); err == nil {
+		t.Errorf("Incorrect error for 44aaff. Expected an error, Got: nil.")
+	}
+}
+
+func TestHSLColorText(t *testing.T) {
+	c := HSLColor{0.5, 1, 0.25}
+	text, err := c.MarshalText()
+	if err != nil || string(text) != "0.5,1,0.25" {
+		t.Errorf("Incorrect text of %v. Expected: %v, Got: %s (%v).", c, "0.5,1,0.25", text, err)
+	}
+
+	var received HSLColor
+	if err := received.UnmarshalText(text); err != nil || received != c {
+		t.Errorf("Incorrect color from %s. Expected: %v, Got: %v (%v).", text, c, received, err)
+	}
+	for _, bad := range []string{"0.5,1", "0.5,1,2", "a,b,c"} {
+		if err := received.UnmarshalText([]byte(bad)); err == nil {
+			t.Errorf("Incorrect error for %v. Expected an error, Got: nil.", bad)
+		}
+	}
+}
+
+func TestGob(t *testing.T) {
+	type record struct {
+		Nick QStr
+		RGB  RGBColor
+		HSL  HSLColor
+	}
+	expected := record{"^1Anti^x4afbody", RGBColor{0.1, 0.2, 0.3}, HSLColor{0.7, 0.5, 0.4}}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(expected); err != nil {
+		t.Fatal(err)
+	}
+	var received record
+	if err := gob.NewDecoder(&buf).Decode(&received); err != nil {
+		t.Fatal(err)
+	}
+	if received != expected {
+		t.Errorf("Incorrect gob round trip. Expected: %v, Got: %v.", expected, received)
+	}
+
+	var c RGBColor
+	if err := c.UnmarshalBinary([]byte{1, 2, 3}); err == nil {
+		t.Errorf("Incorrect error for a short binary color. Expected an error, Got: nil.")
+	}
+}
@@ -0,0 +1,56 @@
+package qstr
+
+import (
+	"strings"
+)
+
+// CompareOption adjusts how values are compared by Equal and EqualFold.
+type CompareOption func(*compareConfig)
+
+type compareConfig struct {
+	key map[rune]rune
+}
+
+// DecodeGlyphs translates font glyphs with key, such as XonoticDecodeKey,
+// before comparing, so that a name spelled with glyphs matches its plain
+// spelling.
+func DecodeGlyphs(key map[rune]rune) CompareOption {
+	return func(c *compareConfig) {
+		c.key = key
+	}
+}
+
+// text returns the visible text of s as compared under c
+func (c *compareConfig) text(s QStr) string {
+	text := s.Stripped()
+	if c.key == nil {
+		return text
+	}
+	return strings.Map(func(r rune) rune {
+		if d, ok := c.key[r]; ok {
+			return d
+		}
+		return r
+	}, text)
+}
+
+func newCompareConfig(opts []CompareOption) *compareConfig {
+	c := &compareConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Equal reports whether s and other show the same visible text, whatever
+// their colors, so that ^1Foo and ^x400Foo are taken for the same player.
+func (s *QStr) Equal(other QStr, opts ...CompareOption) bool {
+	c := newCompareConfig(opts)
+	return c.text(*s) == c.text(other)
+}
+
+// EqualFold is like Equal, but also ignores differences in letter case.
+func (s *QStr) EqualFold(other QStr, opts ...CompareOption) bool {
+	c := newCompareConfig(opts)
+	return strings.EqualFold(c.text(*s), c.text(other))
+}
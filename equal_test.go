@@ -0,0 +1,32 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	var equalList = []struct {
+		A, B      QStr
+		Opts      []CompareOption
+		Equal     bool
+		EqualFold bool
+	}{
+		{"^1Foo", "^x400Foo", nil, true, true},
+		{"^1Foo", "Foo^7", nil, true, true},
+		{"^1Foo", "^1foo", nil, false, true},
+		{"^1Foo", "^1Bar", nil, false, false},
+		{"^^1Foo", "^1Foo", nil, false, false},
+		{"F\ue06f\ue06f", "^2Foo", nil, false, false},
+		{"F\ue06f\ue06f", "^2Foo", []CompareOption{DecodeGlyphs(XonoticDecodeKey)}, true, true},
+		{"F\ue04f\ue04f", "^2foo", []CompareOption{DecodeGlyphs(XonoticDecodeKey)}, false, true},
+	}
+
+	for _, v := range equalList {
+		if received := v.A.Equal(v.B, v.Opts...); received != v.Equal {
+			t.Errorf("Incorrect equality of %q and %q. Expected: %v, Got: %v.", v.A, v.B, v.Equal, received)
+		}
+		if received := v.A.EqualFold(v.B, v.Opts...); received != v.EqualFold {
+			t.Errorf("Incorrect case-insensitive equality of %q and %q. Expected: %v, Got: %v.", v.A, v.B, v.EqualFold, received)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package qstr
+
+import (
+	"strings"
+)
+
+// confusables maps characters that are easily mistaken for one another onto
+// a common skeleton character. It is not exhaustive; it covers the look-alikes
+// most often seen in player names.
+var confusables = map[rune]rune{
+	'0': 'o', '1': 'l', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b',
+	'i': 'l', '|': 'l', '!': 'l', '@': 'a', '$': 's',
+	// Cyrillic
+	'а': 'a', 'в': 'b', 'е': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o',
+	'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'і': 'l', 'ј': 'j',
+	// Greek
+	'α': 'a', 'β': 'b', 'ε': 'e', 'ι': 'l', 'κ': 'k', 'ν': 'v', 'ο': 'o',
+	'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x',
+}
+
+// Report describes how two QStrs differ. Each field reports whether a kind of
+// difference is present; several may be true at once.
+type Report struct {
+	// Identical is true when the raw strings, codes included, are the same
+	Identical bool
+
+	// Colors is true when the strings use different colors
+	Colors bool
+
+	// Invisible is true when the strings contain different invisible
+	// characters, such as zero-width spaces or control characters
+	Invisible bool
+
+	// Case is true when the visible text differs in letter case
+	Case bool
+
+	// Confusables is true when the visible text uses different but
+	// look-alike characters, such as "0" and "O" or Latin and Cyrillic "a"
+	Confusables bool
+
+	// Text is true when the visible text differs in ways not explained by
+	// any of the above
+	Text bool
+}
+
+// Similar reports whether the strings would likely be read as the same name,
+// meaning their only differences are in colors, case, invisible characters,
+// or look-alike characters.
+func (r Report) Similar() bool {
+	return !r.Text
+}
+
+// String lists the kinds of difference in the report.
+func (r Report) String() string {
+	if r.Identical {
+		return "identical"
+	}
+
+	kinds := make([]string, 0, 5)
+	for _, k := range []struct {
+		set  bool
+		name string
+	}{
+		{r.Colors, "colors"},
+		{r.Invisible, "invisible characters"},
+		{r.Case, "case"},
+		{r.Confusables, "confusable characters"},
+		{r.Text, "text"},
+	} {
+		if k.set {
+			kinds = append(kinds, k.name)
+		}
+	}
+	if len(kinds) == 0 {
+		return "codes only"
+	}
+	return strings.Join(kinds, ", ")
+}
+
+// Explain reports why a and b differ. The visible text is compared in stages,
+// each one setting aside a kind of difference before the next is checked:
+// first invisible characters are removed, then case is folded, then
+// Xonotic glyphs are decoded and look-alike characters are mapped onto a common form.
+func Explain(a, b QStr) Report {
+	var r Report
+	if a == b {
+		r.Identical = true
+		return r
+	}
+
+	r.Colors = !sameColors(codeColors(a), codeColors(b))
+
+	sa, sb := a.Stripped(), b.Stripped()
+	r.Invisible = hiddenRunes(sa) != hiddenRunes(sb)
+
+	sa, sb = removeHidden(sa), removeHidden(sb)
+	if sa == sb {
+		return r
+	}
+
+	// Xonotic glyphs that decode to the same characters look alike
+	da, db := decodeString(sa), decodeString(sb)
+	r.Confusables = da == db
+	if da == db {
+		return r
+	}
+
+	la, lb := strings.ToLower(da), strings.ToLower(db)
+	r.Case = la == lb || skeleton(da) != skeleton(db) && skeleton(la) == skeleton(lb)
+	if la == lb {
+		return r
+	}
+
+	if skeleton(la) == skeleton(lb) {
+		r.Confusables = true
+	} else {
+		r.Text = true
+	}
+	return r
+}
+
+// codeColors returns the colors of the codes in s, in order of appearance
+func codeColors(s QStr) []RGBColor {
+	locs := findCodes(string(s))
+	colors := make([]RGBColor, 0, len(locs))
+	for _, loc := range locs {
+		colors = append(colors, ColorCodeToColorRGB(string(s)[loc[0]:loc[1]]))
+	}
+	return colors
+}
+
+// sameColors reports whether two lists of colors are the same
+func sameColors(a, b []RGBColor) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// hiddenRunes returns the invisible characters of s, in order
+func hiddenRunes(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		if c != ' ' && hiddenRune(c) {
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// removeHidden returns s without its invisible characters
+func removeHidden(s string) string {
+	return strings.Map(func(c rune) rune {
+		if c != ' ' && hiddenRune(c) {
+			return -1
+		}
+		return c
+	}, s)
+}
+
+// decodeString replaces the Xonotic glyphs in s with the characters they
+// represent.
+func decodeString(s string) string {
+	return strings.Map(func(c rune) rune {
+		if d, ok := XonoticDecodeKey[c]; ok {
+			return d
+		}
+		return c
+	}, s)
+}
+
+// skeleton maps every character of s onto a representative of its
+// look-alike class.
+func skeleton(s string) string {
+	return strings.Map(func(c rune) rune {
+		if d, ok := confusables[c]; ok {
+			return d
+		}
+		return c
+	}, s)
+}
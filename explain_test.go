@@ -0,0 +1,37 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	var explainList = []struct {
+		A, B     QStr
+		Expected Report
+	}{
+		{"^1Antibody", "^1Antibody", Report{Identical: true}},
+		{"^1Antibody", "^x400Antibody", Report{Colors: true}},
+		{"^1Antibody", "^1ANTIBODY", Report{Case: true}},
+		{"Antibody", "Anti\u200bbody", Report{Invisible: true}},
+		{"Antibody", "Ant1b0dy", Report{Confusables: true}},
+		{"Antibody", "\u0410NT1BODY", Report{Case: true, Confusables: true}},
+		{"Antibody", "\ue041ntibody", Report{Confusables: true}},
+		{"^1Antibody", "^2Antigen", Report{Colors: true, Text: true}},
+	}
+
+	for _, v := range explainList {
+		received := Explain(v.A, v.B)
+		if received != v.Expected {
+			t.Errorf("Incorrect explanation of %v and %v. Expected: %+v, Got: %+v.", v.A, v.B, v.Expected, received)
+		}
+	}
+}
+
+func TestReportString(t *testing.T) {
+	expected := "colors, case"
+	received := Report{Colors: true, Case: true}.String()
+
+	if received != expected {
+		t.Errorf("Incorrect report string. Expected: %v, Got: %v.", expected, received)
+	}
+}
@@ -0,0 +1,110 @@
+package qstr
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+)
+
+// ErrUnknownFont is returned for a font without a width table.
+var ErrUnknownFont = errors.New("qstr: unknown font")
+
+// fontWidths is the advance width table of a font
+type fontWidths struct {
+	unitsPerEm int
+
+	// the advance of characters without an entry
+	fallback int
+
+	ranges []widthRange
+}
+
+// widthRange holds the advances of consecutive characters starting at first
+type widthRange struct {
+	first    rune
+	advances []uint16
+}
+
+// fonts are the width tables by font name
+var fonts = map[string]*fontWidths{
+	"DejaVuSans":     &dejaVuSansWidths,
+	"DejaVuSansMono": &dejaVuSansMonoWidths,
+}
+
+// advance returns the advance width of c in font units. Xonotic glyphs are
+// measured by the character they decode to. Combining marks and format
+// characters take no room, and wide characters, which the game draws from a
+// fallback font, take up a full em.
+func (f *fontWidths) advance(c rune) int {
+	if d, ok := XonoticDecodeKey[c]; ok {
+		c = d
+	}
+	for _, r := range f.ranges {
+		if i := int(c - r.first); i >= 0 && i < len(r.advances) {
+			if a := r.advances[i]; a > 0 {
+				return int(a)
+			}
+			return f.fallback
+		}
+	}
+
+	switch {
+	case unicode.In(c, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case isWide(c):
+		return f.unitsPerEm
+	}
+	return f.fallback
+}
+
+// lookupFont returns the width table of the named font
+func lookupFont(font string) (*fontWidths, error) {
+	f, ok := fonts[font]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFont, font)
+	}
+	return f, nil
+}
+
+// MeasureWidth returns the width in pixels of the visible text of s drawn in
+// font at size pixels per em, ignoring color codes. The fonts are those the
+// game ships, "DejaVuSans" and "DejaVuSansMono", and the widths come from
+// their advance tables, so layouts computed on the server match the game.
+// Kerning is not applied. It returns ErrUnknownFont for any other font.
+func MeasureWidth(s QStr, font string, size float64) (float64, error) {
+	f, err := lookupFont(font)
+	if err != nil {
+		return 0, err
+	}
+
+	units := 0
+	for _, c := range s.Stripped() {
+		units += f.advance(c)
+	}
+	return float64(units) * size / float64(f.unitsPerEm), nil
+}
+
+// Metrics returns the FontMetrics of font at size pixels per em, for use
+// with Overlay. See MeasureWidth for the fonts available.
+func Metrics(font string, size float64) (*FontMetrics, error) {
+	f, err := lookupFont(font)
+	if err != nil {
+		return nil, err
+	}
+
+	scale := size / float64(f.unitsPerEm)
+	m := &FontMetrics{
+		Advances:       make(map[rune]float64),
+		DefaultAdvance: float64(f.fallback) * scale,
+	}
+	for _, r := range f.ranges {
+		for i := range r.advances {
+			c := r.first + rune(i)
+			m.Advances[c] = float64(f.advance(c)) * scale
+		}
+	}
+	for glyph := range XonoticDecodeKey {
+		m.Advances[glyph] = float64(f.advance(glyph)) * scale
+	}
+	return m, nil
+}
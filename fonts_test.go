@@ -0,0 +1,66 @@
+package qstr
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMeasureWidth(t *testing.T) {
+	var measureList = []struct {
+		Input    QStr
+		Font     string
+		Size     float64
+		Expected float64
+	}{
+		{"Hi", "DejaVuSans", 2048, 1540 + 569},
+		{"^1H^x444i", "DejaVuSans", 16, float64(1540+569) * 16 / 2048},
+		{"\ue0c8i", "DejaVuSans", 2048, 1540 + 569},
+		{"WWW", "DejaVuSansMono", 2048, 3 * 1233},
+		{"e\u0301", "DejaVuSans", 2048, 1260},
+		{"", "DejaVuSans", 16, 0},
+	}
+
+	for _, v := range measureList {
+		received, err := MeasureWidth(v.Input, v.Font, v.Size)
+		if err != nil || received != v.Expected {
+			t.Errorf("Incorrect width of %v in %v. Expected: %v, Got: %v (%v).", v.Input, v.Font, v.Expected, received, err)
+		}
+	}
+
+	if _, err := MeasureWidth("Hi", "Comic Sans", 16); !errors.Is(err, ErrUnknownFont) {
+		t.Errorf("Incorrect error for an unknown font. Expected: %v, Got: %v.", ErrUnknownFont, err)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	m, err := Metrics("DejaVuSans", 16)
+	if err != nil {
+		t.Fatalf("Incorrect metrics. Got error: %v.", err)
+	}
+
+	nick := QStr("^1Anti^x444body\ue0c8")
+	expected, _ := MeasureWidth(nick, "DejaVuSans", 16)
+	if received := nick.Overlay(m).Width; received != expected {
+		t.Errorf("Incorrect overlay width of %v. Expected: %v, Got: %v.", nick, expected, received)
+	}
+
+	if _, err := Metrics("Comic Sans", 16); !errors.Is(err, ErrUnknownFont) {
+		t.Errorf("Incorrect error for an unknown font. Expected: %v, Got: %v.", ErrUnknownFont, err)
+	}
+}
+
+func TestFontGlyphsKept(t *testing.T) {
+	// glyphs of the game font are measured from the font tables, so the
+	// conversions that run before measuring must leave them in place
+	nick := QStr("^1Anti\ue012body\ue0e1")
+	for _, policy := range []ForbiddenPolicy{StripForbidden, ReplaceForbidden, RejectForbidden} {
+		if received, err := nick.Sanitize(policy); received != nick || err != nil {
+			t.Errorf("Incorrect sanitizing of %q. Expected: %q, Got: %q (%v).", nick, nick, received, err)
+		}
+	}
+
+	expected, _ := MeasureWidth(nick, "DejaVuSans", 16)
+	if received, _ := MeasureWidth(QStr(nick.Stripped()), "DejaVuSans", 16); received != expected {
+		t.Errorf("Incorrect width of the stripped %q. Expected: %v, Got: %v.", nick, expected, received)
+	}
+}
@@ -0,0 +1,223 @@
+package qstr
+
+// Advance widths, in font units, of the fonts in Xonotic's font-dejavu.pk3,
+// measured from the hmtx tables of DejaVu Sans and DejaVu Sans Mono 2.37. A
+// zero marks a character the font has no glyph for.
+
+var dejaVuSansWidths = fontWidths{
+	unitsPerEm: 2048,
+	fallback:   2100,
+	ranges: []widthRange{
+		{0x20, []uint16{
+			651, 821, 942, 1716, 1303, 1946, 1597, 563, 799, 799, 1024, 1716, 651, 739, 651, 690,
+			1303, 1303, 1303, 1303, 1303, 1303, 1303, 1303, 1303, 1303, 690, 690, 1716, 1716, 1716, 1087,
+			2048, 1401, 1405, 1430, 1577, 1294, 1178, 1587, 1540, 604, 604, 1343, 1141, 1767, 1532, 1612,
+			1235, 1612, 1423, 1300, 1251, 1499, 1401, 2025, 1403, 1251, 1403, 799, 690, 799, 1716, 1024,
+			1024, 1255, 1300, 1126, 1300, 1260, 721, 1300, 1298, 569, 569, 1186, 569, 1995, 1298, 1253,
+			1300, 1300, 842, 1067, 803, 1298, 1212, 1675, 1212, 1212, 1075, 1303, 690, 1303, 1716,
+		}},
+		{0xa0, []uint16{
+			651, 821, 1303, 1303, 1303, 1303, 690, 1024, 1024, 2048, 965, 1253, 1716, 739, 2048, 1024,
+			1024, 1716, 821, 821, 1024, 1303, 1303, 651, 1024, 821, 965, 1253, 1985, 1985, 1985, 1087,
+			1401, 1401, 1401, 1401, 1401, 1401, 1995, 1430, 1294, 1294, 1294, 1294, 604, 604, 604, 604,
+			1587, 1532, 1612, 1612, 1612, 1612, 1612, 1716, 1612, 1499, 1499, 1499, 1499, 1251, 1239, 1290,
+			1255, 1255, 1255, 1255, 1255, 1255, 2011, 1126, 1260, 1260, 1260, 1260, 569, 569, 569, 569,
+			1253, 1298, 1253, 1253, 1253, 1253, 1253, 1716, 1253, 1298, 1298, 1298, 1298, 1212, 1300, 1212,
+			1401, 1255, 1401, 1255, 1401, 1255, 1430, 1126, 1430, 1126, 1430, 1126, 1430, 1126, 1577, 1300,
+			1587, 1300, 1294, 1260, 1294, 1260, 1294, 1260, 1294, 1260, 1294, 1260, 1587, 1300, 1587, 1300,
+			1587, 1300, 1587, 1300, 1540, 1298, 1876, 1423, 604, 569, 604, 569, 604, 569, 604, 569,
+			604, 569, 1208, 1138, 604, 569, 1343, 1186, 1186, 1141, 569, 1141, 569, 1141, 768, 1141,
+			700, 1151, 582, 1532, 1298, 1532, 1298, 1532, 1298, 1666, 1532, 1298, 1612, 1253, 1612, 1253,
+			1612, 1253, 2191, 2095, 1423, 842, 1423, 842, 1423, 842, 1300, 1067, 1300, 1067, 1300, 1067,
+			1300, 1067, 1251, 803, 1251, 803, 1251, 803, 1499, 1298, 1499, 1298, 1499, 1298, 1499, 1298,
+			1499, 1298, 1499, 1298, 2025, 1675, 1251, 1212, 1251, 1403, 1075, 1403, 1075, 1403, 1075, 721,
+			1300, 1505, 1405, 1300, 1405, 1300, 1440, 1430, 1126, 1587, 1677, 1405, 1300, 1253, 1294, 1612,
+			1258, 1178, 721, 1587, 1406, 2015, 724, 604, 1527, 1186, 569, 1212, 1995, 1532, 1298, 1612,
+			1870, 1253, 1943, 1555, 1335, 1300, 1423, 1300, 1067, 1294, 688, 803, 1251, 803, 1251, 1757,
+			1298, 1565, 1476, 1523, 1496, 1403, 1075, 1364, 1364, 1183, 1075, 1303, 1364, 1183, 1045, 1300,
+			604, 1008, 940, 605, 2912, 2660, 2364, 1711, 1611, 935, 1907, 1892, 1633, 1401, 1255, 604,
+			569, 1612, 1253, 1499, 1298, 1499, 1298, 1499, 1298, 1499, 1298, 1499, 1298, 1260, 1401, 1255,
+			1401, 1255, 1995, 2011, 1587, 1300, 1587, 1300, 1343, 1186, 1612, 1253, 1612, 1253, 1364, 1183,
+			569, 2912, 2660, 2364, 1587, 1300, 2279, 1397, 1532, 1298, 1401, 1255, 1995, 2011, 1612, 1253,
+			1401, 1255, 1401, 1255, 1294, 1260, 1294, 1260, 604, 569, 604, 569, 1612, 1253, 1612, 1253,
+			1423, 842, 1423, 842, 1499, 1298, 1499, 1298, 1300, 1067, 1251, 803, 1284, 1068, 1540, 1298,
+			1506, 1716, 1430, 1250, 1403, 1075, 1401, 1255, 1294, 1260, 1612, 1253, 1612, 1253, 1612, 1253,
+			1612, 1253, 1251, 1212, 972, 1726, 977, 569, 2044, 2044, 1401, 1430, 1126, 1141, 1251, 1067,
+			1075, 1235, 981, 1405, 1499, 1401, 1294, 1260, 604, 569, 1600, 1300, 1423, 842, 1251, 1212,
+		}},
+		{0x370, []uint16{
+			1340, 1163, 1765, 1326, 570, 570, 1532, 1331, 0, 0, 1024, 1125, 1126, 1125, 690, 604,
+			0, 0, 0, 0, 1024, 1024, 1418, 651, 1528, 1784, 836, 0, 1664, 0, 1689, 1691,
+			693, 1401, 1405, 1141, 1401, 1294, 1403, 1540, 1612, 604, 1343, 1401, 1767, 1532, 1294, 1612,
+			1540, 1235, 0, 1294, 1251, 1251, 1612, 1403, 1612, 1565, 604, 1251, 1350, 1107, 1298, 693,
+			1185, 1350, 1307, 1212, 1253, 1107, 1114, 1298, 1253, 693, 1207, 1212, 1303, 1144, 1142, 1253,
+			1233, 1300, 1202, 1298, 1233, 1185, 1351, 1183, 1351, 1715, 693, 1185, 1253, 1185, 1715, 1343,
+			1258, 1268, 1431, 1725, 1431, 1351, 1715, 1359, 1612, 1253, 1328, 1202, 1178, 939, 1351, 1351,
+			1772, 1285, 1912, 1715, 1553, 1350, 1621, 1259, 1406, 1243, 1572, 1280, 1432, 1253, 1251, 1098,
+			1359, 1300, 1126, 569, 1612, 1260, 1260, 1239, 1300, 1430, 1767, 1333, 1300, 1440, 1430, 1440,
+		}},
+		{0x400, []uint16{
+			1294, 1294, 1610, 1249, 1430, 1300, 604, 604, 604, 2240, 2140, 1610, 1454, 1532, 1248, 1540,
+			1401, 1405, 1405, 1249, 1600, 1294, 2206, 1313, 1532, 1532, 1454, 1540, 1767, 1540, 1612, 1540,
+			1235, 1430, 1251, 1248, 1763, 1403, 1590, 1404, 2190, 2240, 1705, 1807, 1405, 1430, 2211, 1423,
+			1255, 1263, 1207, 1076, 1416, 1260, 1845, 1089, 1331, 1331, 1237, 1309, 1545, 1339, 1253, 1339,
+			1300, 1126, 1193, 1212, 1751, 1212, 1394, 1210, 1874, 1929, 1447, 1617, 1207, 1124, 1724, 1232,
+			1260, 1260, 1280, 1076, 1124, 1067, 569, 569, 569, 1848, 1840, 1335, 1237, 1331, 1212, 1339,
+			1912, 1715, 1578, 1376, 1930, 1534, 1801, 1604, 2375, 2051, 1612, 1253, 2103, 1688, 1303, 1107,
+			1754, 1795, 1612, 1253, 1600, 1362, 1600, 1362, 2032, 1852, 1952, 1553, 2416, 2105, 1912, 1715,
+			1430, 1126, 1029, 0, 0, 0, 0, 0, 856, 856, 1582, 1386, 1405, 1207, 1235, 1300,
+			1249, 1076, 1382, 1209, 1278, 1085, 2206, 1845, 1313, 1089, 1454, 1237, 1454, 1237, 1454, 1237,
+			1754, 1703, 1540, 1353, 2077, 1796, 2214, 1875, 1798, 1419, 1430, 1126, 1251, 1193, 1251, 1212,
+			1251, 1212, 1403, 1212, 1913, 1652, 1404, 1210, 1404, 1210, 1404, 1298, 1927, 1491, 1927, 1491,
+			604, 2206, 1845, 1343, 1237, 1589, 1373, 1540, 1353, 1590, 1394, 1404, 1210, 1818, 1586, 569,
+			1401, 1255, 1401, 1255, 1995, 2011, 1294, 1260, 1612, 1260, 1612, 1260, 2206, 1845, 1313, 1089,
+			1364, 1183, 1532, 1331, 1532, 1331, 1612, 1253, 1612, 1253, 1612, 1253, 1430, 1124, 1248, 1212,
+			1248, 1212, 1248, 1212, 1404, 1210, 1249, 1076, 1807, 1617, 1382, 1209, 1403, 1212, 1403, 1212,
+		}},
+		{0x2000, []uint16{
+			1024, 2048, 1024, 2048, 675, 512, 342, 1303, 651, 409, 204, 0, 0, 0, 0, 0,
+			739, 739, 1303, 1024, 2048, 2048, 1024, 1024, 651, 651, 651, 651, 1061, 1061, 1061, 1061,
+			1024, 1024, 1208, 1208, 685, 1367, 2048, 651, 0, 0, 0, 0, 0, 0, 0, 409,
+			2748, 3554, 465, 765, 1065, 465, 765, 1065, 694, 819, 819, 1716, 994, 1087, 1024, 1646,
+			1646, 512, 2048, 1024, 342, 799, 799, 1888, 1501, 1501, 1018, 1303, 1024, 1024, 1024, 690,
+			1646, 1024, 921, 2048, 1646, 1716, 1200, 1358, 1716, 1716, 651, 1633, 1716, 651, 651, 455,
+			0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		}},
+		{0x2190, []uint16{
+			1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716,
+			1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716,
+			1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716,
+			1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716,
+			1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716,
+			1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716,
+			1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716, 1716,
+		}},
+		{0x2500, []uint16{
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1575, 1575, 1575, 1575, 1575, 1575, 1575, 1575, 1575, 1575, 1575, 1575, 1575, 1575, 1575, 1575,
+			1575, 1575, 1575, 1575, 1575, 1575, 1575, 1575, 1575, 1575, 1575, 1575, 1575, 1575, 1575, 1575,
+			1935, 1935, 1935, 1935, 1935, 1935, 1935, 1935, 1935, 1935, 1388, 1388, 1935, 1935, 1127, 1127,
+			1575, 1575, 1575, 1575, 1028, 1028, 1575, 1575, 1028, 1028, 1575, 1575, 1575, 1575, 1028, 1028,
+			1575, 1575, 1028, 1028, 1575, 1575, 1575, 1575, 1575, 1787, 1012, 1787, 1787, 1787, 1787, 1787,
+			1787, 1787, 1787, 1787, 1787, 1787, 1079, 1079, 1620, 1987, 1987, 1987, 793, 793, 793, 793,
+			1787, 1787, 1575, 1575, 1575, 1575, 1208, 1935, 1935, 1935, 1935, 1935, 1575, 1575, 1575, 2292,
+			1935, 1935, 1935, 1935, 1787, 1787, 1787, 1787, 1575, 1575, 1575, 1700, 1700, 1500, 1500, 1575,
+		}},
+	},
+}
+
+var dejaVuSansMonoWidths = fontWidths{
+	unitsPerEm: 2048,
+	fallback:   1233,
+	ranges: []widthRange{
+		{0x20, []uint16{
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+		}},
+		{0xa0, []uint16{
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 0, 0, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 0, 0, 0, 1233, 1233, 1233, 0, 1233, 1233, 0, 0, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 0, 0, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 0, 1233, 1233, 1233, 0, 0, 0, 0, 0, 0, 1233, 1233, 0, 0,
+		}},
+		{0x370, []uint16{
+			0, 0, 0, 0, 1233, 1233, 1233, 1233, 0, 0, 1233, 1233, 1233, 1233, 1233, 1233,
+			0, 0, 0, 0, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 0, 1233, 0, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 0, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 0,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+		}},
+		{0x400, []uint16{
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			0, 0, 1233, 1233, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			0, 0, 1233, 1233, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 0, 0, 0, 0,
+			0, 0, 1233, 1233, 1233, 1233, 0, 0, 0, 0, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 0, 0, 0, 0, 0, 0, 1233, 1233, 0, 0, 0, 0,
+			1233, 1233, 1233, 1233, 1233, 0, 0, 1233, 1233, 0, 0, 1233, 1233, 0, 0, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 0, 0, 0, 0, 0, 0,
+		}},
+		{0x2000, []uint16{
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 0, 0, 0, 0, 0,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 0, 0, 1233, 0, 0, 0, 0, 0, 0, 0, 0, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 0, 1233, 1233, 0, 1233, 1233, 1233, 1233,
+			0, 0, 0, 0, 0, 1233, 1233, 1233, 1233, 1233, 0, 1233, 0, 0, 0, 0,
+			0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1233,
+			0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		}},
+		{0x2190, []uint16{
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+		}},
+		{0x2500, []uint16{
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+			1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233, 1233,
+		}},
+	},
+}
@@ -0,0 +1,42 @@
+package qstr
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Format implements fmt.Formatter so that QStr values print readably. The %s
+// and %v verbs print the visible text, %q prints the raw value, codes
+// included, as a quoted string, %+v prints the segments as a list of codes
+// and quoted text, and %#v prints Go syntax. Other verbs format the raw
+// value as a string would. Since templates print values with fmt, a QStr
+// placed in a text/template also shows as its visible text.
+func (s QStr) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 's' || (verb == 'v' && !f.Flag('+') && !f.Flag('#')):
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), s.Stripped())
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprintf(f, "qstr.QStr(%q)", string(s))
+	case verb == 'v':
+		var parts []string
+		for _, seg := range DarkPlaces.Tokenize(s) {
+			parts = append(parts, string(seg.Code)+fmt.Sprintf("%q", seg.Text))
+		}
+		fmt.Fprint(f, "["+strings.Join(parts, " ")+"]")
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, verb), string(s))
+	}
+}
+
+// String formats c as a CSS hex color of the form #rrggbb. It implements
+// fmt.Stringer.
+func (c RGBColor) String() string {
+	return c.Hex()
+}
+
+// String formats c as a CSS hsl() function, such as hsl(210, 100%, 63.3%),
+// with the hue in degrees. It implements fmt.Stringer.
+func (c HSLColor) String() string {
+	return fmt.Sprintf("hsl(%g, %s, %s)", math.Round(c.H*3600)/10, percent(c.S), percent(c.L))
+}
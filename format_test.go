@@ -0,0 +1,39 @@
+package qstr
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	s := QStr("[x]^1Anti^x4afbo^^dy")
+	var formatList = []struct {
+		Format   string
+		Expected string
+	}{
+		{"%s", "[x]Antibo^dy"},
+		{"%v", "[x]Antibo^dy"},
+		{"%14s|", "  [x]Antibo^dy|"},
+		{"%-14v|", "[x]Antibo^dy  |"},
+		{"%q", `"[x]^1Anti^x4afbo^^dy"`},
+		{"%+v", `["[x]" ^1"Anti" ^x4af"bo^dy"]`},
+		{"%#v", `qstr.QStr("[x]^1Anti^x4afbo^^dy")`},
+		{"%x", "5b785d5e31416e74695e78346166626f5e5e6479"},
+	}
+
+	for _, v := range formatList {
+		if received := fmt.Sprintf(v.Format, s); received != v.Expected {
+			t.Errorf("Incorrect formatting with %v. Expected: %v, Got: %v.", v.Format, v.Expected, received)
+		}
+	}
+}
+
+func TestColorString(t *testing.T) {
+	c := RGBColor{0.2, 0.6, 1}
+	if received, expected := fmt.Sprint(c), "#3399ff"; received != expected {
+		t.Errorf("Incorrect RGB string. Expected: %v, Got: %v.", expected, received)
+	}
+	if received, expected := c.HSL().String(), "hsl(210, 100%, 60%)"; received != expected {
+		t.Errorf("Incorrect HSL string. Expected: %v, Got: %v.", expected, received)
+	}
+}
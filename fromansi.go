@@ -0,0 +1,150 @@
+package qstr
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ansiCodes are the color codes for the 16 basic terminal colors, by meaning
+// rather than by exact color, so that red text stays ^1
+var ansiCodes = [16]Code{"^0", "^1", "^2", "^3", "^4", "^6", "^5", "^7", "^9", "^1", "^2", "^3", "^4", "^6", "^5", "^7"}
+
+// FromANSI converts text colored with ANSI SGR escape sequences, as written
+// by server consoles and rcon tools, into a QStr. The 16 basic colors become
+// the ^N codes of the same meaning, and 256-color and 24-bit colors become
+// ^N codes if they are colors of the palette, or else the nearest ^xNNN code.
+// Resetting the foreground color gives ^7. Background colors, text
+// attributes, and all other escape sequences are dropped, including OSC
+// strings such as window titles and hyperlinks, which run up to BEL or ST.
+// Carets in the text are escaped.
+func FromANSI(text string) QStr {
+	var b strings.Builder
+	var current, pending Code
+	for i := 0; i < len(text); {
+		if text[i] != '\x1b' {
+			j := strings.IndexByte(text[i:], '\x1b')
+			if j < 0 {
+				j = len(text) - i
+			}
+			if pending != current {
+				b.WriteString(string(pending))
+				current = pending
+			}
+			b.WriteString(string(Escape(text[i : i+j])))
+			i += j
+			continue
+		}
+
+		// ESC [ parameters final-byte
+		if i+1 < len(text) && text[i+1] == '[' {
+			j := i + 2
+			for j < len(text) && (text[j] < 0x40 || text[j] > 0x7e) {
+				j++
+			}
+			if j < len(text) && text[j] == 'm' {
+				pending = sgrCode(text[i+2:j], pending, current)
+			}
+			i = min(j+1, len(text))
+			continue
+		}
+		// OSC, DCS, SOS, PM, and APC strings run up to BEL or ST
+		if i+1 < len(text) && strings.IndexByte("]PX^_", text[i+1]) >= 0 {
+			i += 2
+			for i < len(text) && text[i] != '\a' && !strings.HasPrefix(text[i:], "\x1b\\") {
+				i++
+			}
+			if strings.HasPrefix(text[i:], "\x1b\\") {
+				i++
+			}
+			i = min(i+1, len(text))
+			continue
+		}
+		// other escape sequences are two bytes long
+		i = min(i+2, len(text))
+	}
+	return QStr(b.String())
+}
+
+// sgrCode returns the code in effect after the SGR parameters params, given
+// the code in effect before them and the code last written
+func sgrCode(params string, code, written Code) Code {
+	reset := func() Code {
+		if written == "" {
+			return ""
+		}
+		return resetCode
+	}
+
+	fields := strings.Split(params, ";")
+	for i := 0; i < len(fields); i++ {
+		n, err := strconv.Atoi(fields[i])
+		if fields[i] == "" {
+			n, err = 0, nil
+		}
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case n == 0 || n == 39:
+			code = reset()
+		case 30 <= n && n <= 37:
+			code = ansiCodes[n-30]
+		case 90 <= n && n <= 97:
+			code = ansiCodes[n-90+8]
+		case n == 38 || n == 48:
+			// extended colors take further parameters
+			c, used := extendedColor(fields[i+1:])
+			if n == 38 && c != "" {
+				code = c
+			}
+			i += used
+		}
+	}
+	return code
+}
+
+// extendedColor returns the code for the color given by the parameters
+// following 38 or 48, or an empty code if they are invalid, and how many of
+// them it used
+func extendedColor(fields []string) (Code, int) {
+	num := func(i int) (int, bool) {
+		if i >= len(fields) {
+			return 0, false
+		}
+		n, err := strconv.Atoi(fields[i])
+		return n, err == nil && 0 <= n && n <= 255
+	}
+
+	mode, ok := num(0)
+	switch {
+	case ok && mode == 5:
+		n, ok := num(1)
+		if !ok {
+			return "", min(2, len(fields))
+		}
+		return ansi256Code(n), 2
+	case ok && mode == 2:
+		r, okR := num(1)
+		g, okG := num(2)
+		bl, okB := num(3)
+		if !okR || !okG || !okB {
+			return "", min(4, len(fields))
+		}
+		return colorCode(NewRGBColorFrom255(float64(r), float64(g), float64(bl))), 4
+	}
+	return "", min(1, len(fields))
+}
+
+// ansi256Code returns the code for an entry of the xterm 256-color palette
+func ansi256Code(n int) Code {
+	switch {
+	case n < 16:
+		return ansiCodes[n]
+	case n < 232:
+		n -= 16
+		return colorCode(NewRGBColorFrom255(float64(ansiCubeLevels[n/36]), float64(ansiCubeLevels[n/6%6]), float64(ansiCubeLevels[n%6])))
+	}
+	level := float64(8 + 10*(n-232))
+	return colorCode(NewRGBColorFrom255(level, level, level))
+}
@@ -0,0 +1,36 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestFromANSI(t *testing.T) {
+	var ansiList = []struct {
+		Input    string
+		Expected QStr
+	}{
+		{"\x1b[31mAnti\x1b[0mbody", "^1Anti^7body"},
+		{"\x1b[1;94mAnti\x1b[39m body", "^4Anti^7 body"},
+		{"\x1b[38;2;68;170;255mAnti\x1b[38;2;255;0;0mbody", "^x4AFAnti^1body"},
+		{"\x1b[38;5;196mred\x1b[38;5;2mgreen\x1b[38;5;244mgray", "^1red^2green^0gray"},
+		{"\x1b[48;2;255;0;0mbg\x1b[48;5;4m only", "bg only"},
+		{"\x1b[0mplain\x1b[K ^1", "plain ^^1"},
+		{"\x1b[31m\x1b[32mgreen", "^2green"},
+		{"\x1b[31m", ""},
+		{"trailing\x1b", "trailing"},
+		{"\x1b[31mred\x1b]0;title\x07 ok", "^1red ok"},
+		{"\x1b]8;;http://example.com\x1b\\link\x1b]8;;\x1b\\", "link"},
+		{"\x1bPunfinished", ""},
+	}
+
+	for _, v := range ansiList {
+		if received := FromANSI(v.Input); received != v.Expected {
+			t.Errorf("Incorrect conversion of %q. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+
+	nick := QStr("^1Anti^x4afbody")
+	if received := FromANSI(nick.ANSI()); received != "^1Anti^x4AFbody" {
+		t.Errorf("Incorrect round trip of %v. Expected: %v, Got: %v.", nick, "^1Anti^x4AFbody", received)
+	}
+}
@@ -0,0 +1,174 @@
+package qstr
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// blockElements are the elements around which white space is only layout
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true,
+	"body": true, "dd": true, "div": true, "dl": true, "dt": true,
+	"fieldset": true, "figure": true, "footer": true, "form": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"head": true, "header": true, "hr": true, "html": true, "li": true,
+	"main": true, "nav": true, "ol": true, "p": true, "pre": true,
+	"section": true, "table": true, "tbody": true, "td": true,
+	"tfoot": true, "th": true, "thead": true, "tr": true, "ul": true,
+}
+
+// FromHTML converts HTML markup, such as that produced by HTML or by a
+// rich-text editor, back into a QStr. The color of each piece of text is
+// taken from the innermost element around it that sets one, through a color
+// property in its style attribute, a color attribute as on <font>, or, if
+// WithClassPrefix is given, a class name as written with that prefix.
+// Declarations that aren't valid CSS colors, such as inherit, are ignored.
+// Colors of the palette, which options may replace, become ^N codes and
+// other colors the nearest ^xNNN code. Text following colored text without
+// a color of its own gets ^7. White space between block elements, such as
+// the indentation of the markup, is dropped, as is other markup; entities
+// are decoded, and carets in the text are escaped. An error is returned for
+// markup that can't be parsed.
+func FromHTML(html string, opts ...Option) (QStr, error) {
+	r := NewRenderer(opts...)
+	var classCode *regexp.Regexp
+	if r.prefix != "" {
+		classCode = regexp.MustCompile(`^` + regexp.QuoteMeta(r.prefix) + `(?:(\d)|(x[\dA-Fa-f]{3}))$`)
+	}
+
+	d := xml.NewDecoder(strings.NewReader(html))
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+
+	// the code set by each open element, empty for elements without one
+	var stack []Code
+	current := Code("")
+	var b strings.Builder
+	write := func(text string) {
+		code := Code("")
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i] != "" {
+				code = stack[i]
+				break
+			}
+		}
+		if code == "" && current != "" {
+			code = resetCode
+		}
+		if code != current && code != "" {
+			b.WriteString(string(code))
+			current = code
+		}
+		b.WriteString(string(Escape(text)))
+	}
+
+	// white space is held back until it is known not to lie between block
+	// elements
+	afterBlock := true
+	space := ""
+	flush := func(keep bool) {
+		if keep && space != "" {
+			write(space)
+		}
+		space = ""
+	}
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("qstr: invalid HTML: %w", err)
+		}
+
+		block := false
+		switch t := tok.(type) {
+		case xml.StartElement:
+			block = blockElements[strings.ToLower(t.Name.Local)]
+		case xml.EndElement:
+			block = blockElements[strings.ToLower(t.Name.Local)]
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			flush(!block)
+			stack = append(stack, r.htmlElementCode(t, classCode))
+			afterBlock = block
+		case xml.EndElement:
+			flush(!block)
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			afterBlock = block
+		case xml.CharData:
+			text := string(t)
+			if strings.TrimSpace(text) == "" {
+				if !afterBlock {
+					space += text
+				}
+				continue
+			}
+			flush(true)
+			write(text)
+			afterBlock = false
+		}
+	}
+	return QStr(b.String()), nil
+}
+
+// htmlElementCode returns the color code set by an element, if any. Classes
+// are matched with classCode if it is not nil.
+func (r *Renderer) htmlElementCode(e xml.StartElement, classCode *regexp.Regexp) Code {
+	for _, attr := range e.Attr {
+		switch strings.ToLower(attr.Name.Local) {
+		case "style":
+			for _, decl := range strings.Split(attr.Value, ";") {
+				prop, value, ok := strings.Cut(decl, ":")
+				if !ok || strings.ToLower(strings.TrimSpace(prop)) != "color" {
+					continue
+				}
+				if c, err := ParseCSSColor(value); err == nil {
+					return r.theme.Palette.code(c)
+				}
+			}
+		case "color":
+			if c, err := ParseCSSColor(attr.Value); err == nil {
+				return r.theme.Palette.code(c)
+			}
+		case "class":
+			if classCode == nil {
+				continue
+			}
+			for _, class := range strings.Fields(attr.Value) {
+				if m := classCode.FindStringSubmatch(class); m != nil {
+					if m[1] != "" {
+						return Code("^" + m[1])
+					}
+					return Code("^" + m[2])
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// colorCode returns the ^N code of XonoticPalette with color c, or else the
+// nearest ^xNNN code
+func colorCode(c RGBColor) Code {
+	return XonoticPalette.code(c)
+}
+
+// code returns the ^N code of p with color c, or else the nearest ^xNNN
+// code
+func (p *Palette) code(c RGBColor) Code {
+	for i, pc := range p {
+		if pc.CSS() == c.CSS() {
+			return Code(fmt.Sprintf("^%d", i))
+		}
+	}
+	return HexCode(c)
+}
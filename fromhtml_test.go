@@ -0,0 +1,63 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestFromHTML(t *testing.T) {
+	var htmlList = []struct {
+		Input    string
+		Expected QStr
+	}{
+		{"<span style='color:rgb(255,0,0)'>Anti<span style=\"color:rgb(68,170,255)\">body</span></span>", "^1Anti^x4AFbody"},
+		{"plain <b>bold</b> &amp; &lt;caret&gt; ^1", "plain bold & <caret> ^^1"},
+		{"<span style=\"font-weight:bold; color: #ff0000\">red</span> after", "^1red^7 after"},
+		{"<font color=\"tomato\">tomato</font>", "^xF64tomato"},
+		{"<span style=\"color: inherit\">a</span><font color=\"red !important\">b</font>", "ab"},
+		{"<div>\n  <span style=\"color:red\">a</span>\n</div>\n", "^1a"},
+		{"<p>a <b>b</b> <i>c</i></p>\n<p> d</p>", "a b c d"},
+		{"<p>a<br>b</p>", "ab"},
+		{"", ""},
+	}
+
+	for _, v := range htmlList {
+		received, err := FromHTML(v.Input)
+		if err != nil || received != v.Expected {
+			t.Errorf("Incorrect conversion of %v. Expected: %v, Got: %v (%v).", v.Input, v.Expected, received, err)
+		}
+	}
+
+	nick := QStr("^1Anti^5body ^^^3!")
+	received, err := FromHTML(string(nick.HTML()))
+	if err != nil || received != nick {
+		t.Errorf("Incorrect round trip of %v. Expected: %v, Got: %v (%v).", nick, nick, received, err)
+	}
+
+}
+
+func TestFromHTMLClasses(t *testing.T) {
+	var htmlList = []struct {
+		Input    string
+		Options  []Option
+		Expected QStr
+	}{
+		{"<span class=\"q-2\">green</span><span class=\"name q-x4af\">blue</span>", []Option{WithClassPrefix("q-")}, "^2green^x4afblue"},
+		{"<span class=\"topic1\">a</span><span class=\"q-1\">b</span>", []Option{WithClassPrefix("q-")}, "a^1b"},
+		{"<span class=\"q-2\">green</span>", nil, "green"},
+		{"<span style=\"color:rgb(200,0,0)\">a</span>", []Option{WithPalette(Palette{1: NewRGBColorFrom255(200, 0, 0)})}, "^1a"},
+	}
+
+	for _, v := range htmlList {
+		received, err := FromHTML(v.Input, v.Options...)
+		if err != nil || received != v.Expected {
+			t.Errorf("Incorrect conversion of %q. Expected: %q, Got: %q (%v).", v.Input, v.Expected, received, err)
+		}
+	}
+
+	nick := QStr("^1Anti^x4afbody")
+	opts := []Option{WithClassPrefix("qstr-"), WithClassesOnly()}
+	received, err := FromHTML(string(nick.HTML(opts...)), opts...)
+	if err != nil || received != nick {
+		t.Errorf("Incorrect round trip of %q. Expected: %q, Got: %q (%v).", nick, nick, received, err)
+	}
+}
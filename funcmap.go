@@ -0,0 +1,49 @@
+package qstr
+
+import (
+	"fmt"
+	"html/template"
+)
+
+// FuncMap returns template functions for rendering QStr values, ready to be
+// passed to the Funcs method of an html/template or text/template Template.
+// The functions accept a QStr or a plain string:
+//
+//	qstrHTML      renders the HTML representation
+//	qstrANSI      renders the ANSI representation
+//	qstrStrip     returns the stripped string
+//	qstrTruncate  takes a length first, so that it can be used in pipelines
+//	              such as {{.Nick | qstrTruncate 12 | qstrHTML}}, and
+//	              truncates with an ellipsis
+//
+// The renderings use a Renderer built from opts.
+func FuncMap(opts ...Option) map[string]interface{} {
+	r := NewRenderer(opts...)
+	return map[string]interface{}{
+		"qstrHTML": func(v interface{}) template.HTML {
+			return r.HTML(toQStr(v))
+		},
+		"qstrANSI": func(v interface{}) string {
+			return r.ANSI(toQStr(v))
+		},
+		"qstrStrip": func(v interface{}) string {
+			s := toQStr(v)
+			return s.Stripped()
+		},
+		"qstrTruncate": func(n int, v interface{}) QStr {
+			s := toQStr(v)
+			return s.Truncate(n, "…")
+		},
+	}
+}
+
+// toQStr converts a template argument to a QStr
+func toQStr(v interface{}) QStr {
+	switch s := v.(type) {
+	case QStr:
+		return s
+	case string:
+		return QStr(s)
+	}
+	return QStr(fmt.Sprint(v))
+}
@@ -0,0 +1,38 @@
+package qstr
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"testing"
+	texttemplate "text/template"
+)
+
+func TestFuncMapHTMLTemplate(t *testing.T) {
+	tmpl := htmltemplate.Must(htmltemplate.New("t").Funcs(FuncMap()).Parse(
+		`{{.Nick | qstrHTML}}|{{.Nick | qstrStrip}}|{{.Nick | qstrTruncate 3 | qstrHTML}}|{{"^2<b>" | qstrHTML}}`))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct{ Nick QStr }{"^1Anti^x4afbody"}); err != nil {
+		t.Fatal(err)
+	}
+	expected := "<span style='color:rgb(255,0,0)'>Anti<span style=\"color:rgb(68,170,255)\">body</span></span>|Antibody|" +
+		"<span style='color:rgb(255,0,0)'>An…</span>|<span style='color:rgb(51,255,0)'>&lt;b&gt;</span>"
+	if received := buf.String(); received != expected {
+		t.Errorf("Incorrect template output. Expected: %v, Got: %v.", expected, received)
+	}
+}
+
+func TestFuncMapTextTemplate(t *testing.T) {
+	tmpl := texttemplate.Must(texttemplate.New("t").Funcs(FuncMap(WithColorDepth(Color16))).Parse(
+		`{{qstrANSI .}} {{qstrStrip .}}`))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, "^1Anti"); err != nil {
+		t.Fatal(err)
+	}
+	nick := QStr("^1Anti")
+	expected := nick.ANSI(WithColorDepth(Color16)) + " Anti"
+	if received := buf.String(); received != expected {
+		t.Errorf("Incorrect template output. Expected: %q, Got: %q.", expected, received)
+	}
+}
@@ -0,0 +1,139 @@
+package qstr
+
+import (
+	"strings"
+	"unicode"
+)
+
+// GradientOption adjusts how Gradient colors text.
+type GradientOption func(*gradientConfig)
+
+type gradientConfig struct {
+	tolerance             float64
+	saturation, lightness float64
+	space                 Space
+}
+
+// GradientTolerance coalesces neighboring characters whose colors differ by
+// at most tolerance, measured as in DeltaE, so that they share a single color
+// code. A tolerance of about 2 saves codes without a visible difference;
+// larger ones trade smoothness for shorter strings, which matters for nicks
+// with a length limit. Characters whose codes are identical always share
+// one.
+func GradientTolerance(tolerance float64) GradientOption {
+	return func(c *gradientConfig) {
+		c.tolerance = tolerance
+	}
+}
+
+// GradientLinear makes Gradient interpolate between its stops in linear
+// light, as MixLinear does, rather than in OKLab. The midpoints come out
+// brighter, like light from the two stops blended together. It is the same
+// as GradientSpace(SpaceLinearRGB).
+func GradientLinear() GradientOption {
+	return GradientSpace(SpaceLinearRGB)
+}
+
+// GradientSpace makes Gradient interpolate between its stops in space
+// rather than in OKLab. See Lerp.
+func GradientSpace(space Space) GradientOption {
+	return func(c *gradientConfig) {
+		c.space = space
+	}
+}
+
+// RainbowSaturation sets the HSL saturation of the colors Rainbow uses, in
+// the range [0, 1]. The default is 1.
+func RainbowSaturation(saturation float64) GradientOption {
+	return func(c *gradientConfig) {
+		c.saturation = saturation
+	}
+}
+
+// RainbowLightness sets the HSL lightness of the colors Rainbow uses, in the
+// range [0, 1]. The default is 0.5, which gives fully saturated colors; pick
+// a higher lightness for pastels.
+func RainbowLightness(lightness float64) GradientOption {
+	return func(c *gradientConfig) {
+		c.lightness = lightness
+	}
+}
+
+// Gradient returns text colored with a gradient running through stops,
+// which are spread evenly over the visible characters. Each character gets
+// the ^xNNN code nearest to its color, interpolated in OKLab so that the
+// gradient looks even, or in the space set with GradientSpace. Whitespace
+// takes no codes, and carets in text are escaped. Without stops, text is
+// returned uncolored.
+func Gradient(text string, stops []RGBColor, opts ...GradientOption) QStr {
+	if len(stops) == 0 {
+		return Escape(text)
+	}
+
+	c := newGradientConfig(opts)
+	return colorGraphemes(text, func(t float64) RGBColor {
+		return LerpStops(stops, t, c.space)
+	}, c)
+}
+
+// Rainbow returns text colored with the hues of the rainbow, from red
+// through to violet over the visible characters, as Gradient does. The
+// saturation and lightness of the colors can be set with RainbowSaturation
+// and RainbowLightness.
+func Rainbow(text string, opts ...GradientOption) QStr {
+	c := newGradientConfig(opts)
+	return colorGraphemes(text, func(t float64) RGBColor {
+		// stop at violet rather than coming back round to red
+		h := HSLColor{t * 5 / 6, c.saturation, c.lightness}
+		return h.RGB()
+	}, c)
+}
+
+// newGradientConfig returns the configuration set by opts
+func newGradientConfig(opts []GradientOption) gradientConfig {
+	c := gradientConfig{saturation: 1, lightness: 0.5, space: SpaceOKLab}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// colorGraphemes colors each visible grapheme cluster of text with
+// colorAt(t), where t runs from 0 for the first to 1 for the last.
+func colorGraphemes(text string, colorAt func(t float64) RGBColor, c gradientConfig) QStr {
+	clusters := graphemes(text)
+	visible := 0
+	for _, g := range clusters {
+		if !isSpace(g) {
+			visible++
+		}
+	}
+
+	var b strings.Builder
+	var last Code
+	var lastColor RGBColor
+	i := 0
+	for _, g := range clusters {
+		if !isSpace(g) {
+			t := 0.0
+			if visible > 1 {
+				t = float64(i) / float64(visible-1)
+			}
+			i++
+
+			code := HexCode(colorAt(t))
+			color := code.Color(nil)
+			if code != last && (last == "" || DeltaE(color, lastColor) > c.tolerance) {
+				b.WriteString(string(code))
+				last, lastColor = code, color
+			}
+		}
+		b.WriteString(string(Escape(g)))
+	}
+	return QStr(b.String())
+}
+
+// isSpace reports whether a grapheme cluster is whitespace
+func isSpace(g string) bool {
+	return strings.TrimFunc(g, unicode.IsSpace) == ""
+}
@@ -0,0 +1,54 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestGradient(t *testing.T) {
+	red, blue := RGBColor{1, 0, 0}, RGBColor{0, 0, 1}
+	var gradientList = []struct {
+		Text     string
+		Stops    []RGBColor
+		Opts     []GradientOption
+		Expected QStr
+	}{
+		{"abc", []RGBColor{red, blue}, nil, "^xF00a^x85Ab^x00Fc"},
+		{"a c", []RGBColor{red, blue}, nil, "^xF00a ^x00Fc"},
+		{"abc", []RGBColor{red}, nil, "^xF00abc"},
+		{"a^1", []RGBColor{red}, nil, "^xF00a^^1"},
+		{"abc", nil, nil, "abc"},
+		{"", []RGBColor{red, blue}, nil, ""},
+		{"abcd", []RGBColor{red, RGBColor{0.95, 0, 0}}, []GradientOption{GradientTolerance(10)}, "^xF00abcd"},
+		{"abcd", []RGBColor{red, RGBColor{0.95, 0, 0}}, nil, "^xF00ab^xE00cd"},
+	}
+
+	for _, v := range gradientList {
+		received := Gradient(v.Text, v.Stops, v.Opts...)
+		if received != v.Expected {
+			t.Errorf("Incorrect gradient of %v. Expected: %v, Got: %v.", v.Text, v.Expected, received)
+		}
+		if stripped := received.Stripped(); stripped != v.Text {
+			t.Errorf("Incorrect stripping applied to %v. Expected: %v, Got: %v.", received, v.Text, stripped)
+		}
+	}
+}
+
+func TestRainbow(t *testing.T) {
+	var rainbowList = []struct {
+		Text     string
+		Opts     []GradientOption
+		Expected QStr
+	}{
+		{"abc", nil, "^xF00a^x0F8b^xF0Fc"},
+		{"abc", []GradientOption{RainbowLightness(0.75)}, "^xF88a^x8FBb^xF8Fc"},
+		{"abc", []GradientOption{RainbowSaturation(0)}, "^x888abc"},
+		{"a", nil, "^xF00a"},
+	}
+
+	for _, v := range rainbowList {
+		received := Rainbow(v.Text, v.Opts...)
+		if received != v.Expected {
+			t.Errorf("Incorrect rainbow of %v. Expected: %v, Got: %v.", v.Text, v.Expected, received)
+		}
+	}
+}
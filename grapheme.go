@@ -0,0 +1,81 @@
+package qstr
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// graphemes splits text into grapheme clusters: the units a reader perceives
+// as single characters. It follows the most important rules of Unicode's
+// extended grapheme clusters, keeping combining marks, variation selectors,
+// emoji modifiers and zero-width-joiner sequences attached to their base
+// character and pairing up regional indicators into flags.
+func graphemes(text string) []string {
+	clusters := make([]string, 0, len(text))
+	for len(text) > 0 {
+		n := nextGrapheme(text)
+		clusters = append(clusters, text[:n])
+		text = text[n:]
+	}
+	return clusters
+}
+
+// graphemeCount returns the number of grapheme clusters in text
+func graphemeCount(text string) int {
+	n := 0
+	for len(text) > 0 {
+		text = text[nextGrapheme(text):]
+		n++
+	}
+	return n
+}
+
+// nextGrapheme returns the length in bytes of the grapheme cluster at the
+// start of text.
+func nextGrapheme(text string) int {
+	first, i := utf8.DecodeRuneInString(text)
+	if first == '\r' && len(text) > 1 && text[1] == '\n' {
+		return 2
+	}
+	if unicode.IsControl(first) {
+		return i
+	}
+
+	prev := first
+	indicators := 0
+	if isRegionalIndicator(first) {
+		indicators = 1
+	}
+	for i < len(text) {
+		c, n := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case isGraphemeExtend(c):
+		case prev == '\u200d' && !unicode.IsControl(c):
+			// a zero-width joiner glues the next character on
+		case isRegionalIndicator(c) && indicators == 1:
+			indicators++
+		default:
+			return i
+		}
+		prev = c
+		i += n
+	}
+	return i
+}
+
+// isGraphemeExtend reports whether c extends the grapheme cluster before it
+func isGraphemeExtend(c rune) bool {
+	return unicode.In(c, unicode.Mn, unicode.Me, unicode.Mc) ||
+		c == '\u200d' ||
+		('\ufe00' <= c && c <= '\ufe0f') || // variation selectors
+		(0x1f3fb <= c && c <= 0x1f3ff) || // emoji skin tone modifiers
+		(0xe0020 <= c && c <= 0xe007f) || // tags, used by subdivision flags
+		(0xe0100 <= c && c <= 0xe01ef) || // variation selectors supplement
+		(0x1160 <= c && c <= 0x11ff) // Hangul vowel and trailing jamo
+}
+
+// isRegionalIndicator reports whether c is one of the letters that pair up
+// into flag emoji
+func isRegionalIndicator(c rune) bool {
+	return 0x1f1e6 <= c && c <= 0x1f1ff
+}
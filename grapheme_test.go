@@ -0,0 +1,27 @@
+package qstr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	var graphemeList = []struct {
+		Input    string
+		Expected []string
+	}{
+		{"abc", []string{"a", "b", "c"}},
+		{"éa", []string{"é", "a"}},
+		{"\U0001f468\u200d\U0001f469\u200d\U0001f467!", []string{"\U0001f468\u200d\U0001f469\u200d\U0001f467", "!"}},
+		{"\U0001f44d\U0001f3fd", []string{"\U0001f44d\U0001f3fd"}},
+		{"\U0001f1e9\U0001f1ea\U0001f1eb\U0001f1f7", []string{"\U0001f1e9\U0001f1ea", "\U0001f1eb\U0001f1f7"}},
+		{"a\r\nb", []string{"a", "\r\n", "b"}},
+	}
+
+	for _, v := range graphemeList {
+		received := graphemes(v.Input)
+		if !reflect.DeepEqual(received, v.Expected) {
+			t.Errorf("Incorrect grapheme clusters for %q. Expected: %q, Got: %q.", v.Input, v.Expected, received)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package qstr
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"hash/fnv"
+)
+
+// Hash returns a 64-bit FNV-1a hash of the text identifying s, ignoring
+// colors, case, font glyphs, invisible characters, and surrounding white
+// space, so that the same player hashes the same across recolorings. The
+// hash is stable across processes and versions, so it may be stored as a
+// deduplication key, but it is not collision resistant; see HashKeyed.
+func (s *QStr) Hash() uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(normalizedKey(*s)))
+	return h.Sum64()
+}
+
+// HashKeyed returns the HMAC-SHA256 of the text Hash uses, keyed with key,
+// for deduplication keys that must resist deliberate collisions or must not
+// reveal the names they were made from.
+func (s *QStr) HashKeyed(key []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(normalizedKey(*s)))
+	return h.Sum(nil)
+}
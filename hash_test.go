@@ -0,0 +1,28 @@
+package qstr
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	a, b, c := QStr("^1Anti^2body"), QStr(" ^x4afANTIBODY^7 "), QStr("^1Antibody2")
+	if a.Hash() != b.Hash() {
+		t.Errorf("Incorrect hash. Expected: %v, Got: %v.", a.Hash(), b.Hash())
+	}
+	if a.Hash() == c.Hash() {
+		t.Errorf("Incorrect hash. Expected a hash other than %v.", a.Hash())
+	}
+	// the FNV-1a hash of "antibody"
+	if received, expected := a.Hash(), uint64(0x6ea766fab84bd1bd); received != expected {
+		t.Errorf("Incorrect hash. Expected: %#x, Got: %#x.", expected, received)
+	}
+
+	key := []byte("secret")
+	if !bytes.Equal(a.HashKeyed(key), b.HashKeyed(key)) {
+		t.Errorf("Incorrect keyed hash. Expected: %x, Got: %x.", a.HashKeyed(key), b.HashKeyed(key))
+	}
+	if bytes.Equal(a.HashKeyed(key), a.HashKeyed([]byte("other"))) {
+		t.Errorf("Incorrect keyed hash. Expected a hash depending on the key.")
+	}
+}
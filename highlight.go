@@ -0,0 +1,163 @@
+package qstr
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MentionPattern matches @mentions such as "@Antibody".
+var MentionPattern = regexp.MustCompile(`@[\pL\pN_\-]+`)
+
+// WordsPattern returns a pattern matching any of words as whole words,
+// ignoring case. It is handy for rules highlighting player or map names.
+func WordsPattern(words ...string) *regexp.Regexp {
+	quoted := make([]string, 0, len(words))
+	for _, w := range words {
+		quoted = append(quoted, regexp.QuoteMeta(w))
+	}
+	// longer words first, so one that contains another still matches fully
+	sort.Slice(quoted, func(i, j int) bool {
+		return len(quoted[i]) > len(quoted[j])
+	})
+	return regexp.MustCompile(`(?i)(?:^|\b)(?:` + strings.Join(quoted, "|") + `)(?:\b|$)`)
+}
+
+// HighlightRule highlights the parts of the visible text matched by Pattern.
+type HighlightRule struct {
+	// Name identifies the rule in the matches it produces
+	Name string
+
+	// Pattern is matched against the visible text
+	Pattern *regexp.Regexp
+
+	// Color is the color given to matches when highlighting a QStr
+	Color RGBColor
+
+	// Class is the CSS class of the <mark> element placed around matches
+	// when highlighting HTML
+	Class string
+}
+
+// Highlight is a match of a HighlightRule in the visible text of a QStr.
+type Highlight struct {
+	Rule *HighlightRule
+
+	// Start and End are byte offsets of the match within the visible text
+	Start, End int
+
+	// Text is the matched visible text
+	Text string
+}
+
+// Highlighter marks up chat lines and names according to a list of rules.
+// When matches of different rules overlap, the one starting first wins, and
+// for matches starting at the same place the earlier rule wins.
+type Highlighter struct {
+	Rules []HighlightRule
+}
+
+// NewHighlighter returns a Highlighter applying the given rules.
+func NewHighlighter(rules ...HighlightRule) *Highlighter {
+	return &Highlighter{Rules: rules}
+}
+
+// Find returns the non-overlapping matches of the rules in the visible text
+// of s, in order.
+func (h *Highlighter) Find(s QStr) []Highlight {
+	return h.find(segmentsText(DarkPlaces.Tokenize(s)))
+}
+
+func (h *Highlighter) find(text string) []Highlight {
+	all := make([]Highlight, 0)
+	for i := range h.Rules {
+		rule := &h.Rules[i]
+		for _, m := range rule.Pattern.FindAllStringIndex(text, -1) {
+			if m[0] < m[1] {
+				all = append(all, Highlight{Rule: rule, Start: m[0], End: m[1], Text: text[m[0]:m[1]]})
+			}
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Start < all[j].Start
+	})
+
+	res := make([]Highlight, 0, len(all))
+	end := 0
+	for _, m := range all {
+		if m.Start >= end {
+			res = append(res, m)
+			end = m.End
+		}
+	}
+	return res
+}
+
+// QStr returns s with each match recolored with its rule's color. The color
+// that was in effect is restored after each match.
+func (h *Highlighter) QStr(s QStr) QStr {
+	segments := DarkPlaces.Tokenize(s)
+	matches := h.find(segmentsText(segments))
+	if len(matches) == 0 {
+		return s
+	}
+
+	offsets := make([]int, 0, 2*len(matches))
+	for _, m := range matches {
+		offsets = append(offsets, m.Start, m.End)
+	}
+	segments = splitSegments(segments, offsets)
+
+	pos := 0
+	for i := range segments {
+		for len(matches) > 0 && matches[0].End <= pos {
+			matches = matches[1:]
+		}
+		if len(matches) > 0 && matches[0].Start <= pos {
+			segments[i].Code = HexCode(matches[0].Rule.Color)
+			segments[i].Color = matches[0].Rule.Color
+		}
+		pos += len(segments[i].Text)
+	}
+
+	return joinSegments(segments)
+}
+
+// Highlight returns s with each case-insensitive match of query in its
+// visible text recolored with color, restoring the color that was in effect
+// after each match. Matches may span color codes. An empty query leaves s
+// as it is.
+func (s *QStr) Highlight(query string, color RGBColor) QStr {
+	if query == "" {
+		return *s
+	}
+	h := NewHighlighter(HighlightRule{
+		Pattern: regexp.MustCompile(`(?i)` + regexp.QuoteMeta(query)),
+		Color:   color,
+	})
+	return h.QStr(*s)
+}
+
+// HTML returns the HTML representation of s as produced by r, with each match
+// wrapped in a <mark> element carrying its rule's class. If r is nil, a
+// Renderer with the default options is used.
+func (h *Highlighter) HTML(s QStr, r *Renderer) template.HTML {
+	if r == nil {
+		r = NewRenderer()
+	}
+
+	matches := h.find(r.dialect.Strip(s))
+	annotations := make([]annotation, 0, len(matches))
+	for _, m := range matches {
+		open := "<mark>"
+		if m.Rule.Class != "" {
+			open = fmt.Sprintf("<mark class=\"%s\">", html.EscapeString(m.Rule.Class))
+		}
+		annotations = append(annotations, annotation{start: m.Start, end: m.End, open: open, close: "</mark>"})
+	}
+
+	return r.annotatedHTML(s, annotations)
+}
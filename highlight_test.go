@@ -0,0 +1,76 @@
+package qstr
+
+import (
+	"html/template"
+	"testing"
+)
+
+var testHighlighter = NewHighlighter(
+	HighlightRule{Name: "mention", Pattern: MentionPattern, Color: RGBColor{1, 1, 0}, Class: "mention"},
+	HighlightRule{Name: "map", Pattern: WordsPattern("afterslime", "solarium"), Color: RGBColor{0, 1, 1}, Class: "map"},
+)
+
+func TestHighlighterFind(t *testing.T) {
+	line := QStr("^1@Anti^2body: next map is ^xF00Solarium!")
+	matches := testHighlighter.Find(line)
+
+	expected := []string{"@Antibody", "Solarium"}
+	if len(matches) != len(expected) {
+		t.Fatalf("Incorrect number of matches in %v. Expected: %v, Got: %v.", line, len(expected), len(matches))
+	}
+	for i, m := range matches {
+		if m.Text != expected[i] {
+			t.Errorf("Incorrect match in %v. Expected: %v, Got: %v.", line, expected[i], m.Text)
+		}
+	}
+}
+
+func TestHighlighterQStr(t *testing.T) {
+	var highlightList = []struct {
+		Input    QStr
+		Expected QStr
+	}{
+		{"^1@Anti^2body: next map is ^xF00Solarium!", "^xFF0@Antibody^2: next map is ^x0FFSolarium^xF00!"},
+		{"hi @Antibody, gg", "hi ^xFF0@Antibody^7, gg"},
+		{"no matches", "no matches"},
+	}
+
+	for _, v := range highlightList {
+		received := testHighlighter.QStr(v.Input)
+		if received != v.Expected {
+			t.Errorf("Incorrect highlighting of %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestQStrHighlight(t *testing.T) {
+	var highlightList = []struct {
+		Input    QStr
+		Query    string
+		Expected QStr
+	}{
+		{"^1Anti^2body", "IBO", "^1Ant^xFF0ibo^2dy"},
+		{"^1Anti^2body", "body", "^1Anti^xFF0body"},
+		{"xanti and anti", "ANTI", "x^xFF0anti^7 and ^xFF0anti"},
+		{"a.b axb", ".", "a^xFF0.^7b axb"},
+		{"^1Anti", "", "^1Anti"},
+	}
+
+	for _, v := range highlightList {
+		received := v.Input.Highlight(v.Query, RGBColor{1, 1, 0})
+		if received != v.Expected {
+			t.Errorf("Incorrect highlighting of %q in %v. Expected: %v, Got: %v.", v.Query, v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestHighlighterHTML(t *testing.T) {
+	line := QStr("^1gg @Anti^2body")
+	expected := template.HTML("<span style='color:rgb(255,0,0)'>gg <mark class=\"mention\">@Anti" +
+		"<span style='color:rgb(51,255,0)'>body</span></mark></span>")
+	received := testHighlighter.HTML(line, nil)
+
+	if received != expected {
+		t.Errorf("Incorrect HTML value returned. Expected: %v, Got: %v.", expected, received)
+	}
+}
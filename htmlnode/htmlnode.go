@@ -0,0 +1,43 @@
+// Package htmlnode renders QStr values as golang.org/x/net/html node trees,
+// so callers can post-process the markup, for instance to inject links,
+// trim, or merge runs, before serializing it with html.Render. Working on
+// nodes avoids brittle string surgery on the rendered HTML.
+package htmlnode
+
+import (
+	"strings"
+
+	"github.com/antzucaro/qstr"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Render returns the HTML representation of s, as produced by a
+// qstr.Renderer with the given options, as the children of a document node.
+// Passing the result to html.Render writes the same markup as the Renderer,
+// up to quoting and escaping.
+func Render(s qstr.QStr, opts ...qstr.Option) *html.Node {
+	return RenderWith(qstr.NewRenderer(opts...), s)
+}
+
+// RenderWith is like Render, but uses an existing Renderer.
+func RenderWith(r *qstr.Renderer, s qstr.QStr) *html.Node {
+	doc := &html.Node{Type: html.DocumentNode}
+	for _, n := range Nodes(r, s) {
+		doc.AppendChild(n)
+	}
+	return doc
+}
+
+// Nodes returns the top-level nodes of the HTML representation of s as
+// produced by r, for appending to an existing tree.
+func Nodes(r *qstr.Renderer, s qstr.QStr) []*html.Node {
+	context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(string(r.HTML(s))), context)
+	if err != nil {
+		// reading from a string can't fail, but fall back to the plain
+		// text just in case
+		return []*html.Node{{Type: html.TextNode, Data: s.Stripped()}}
+	}
+	return nodes
+}
@@ -0,0 +1,57 @@
+package htmlnode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/antzucaro/qstr"
+	"golang.org/x/net/html"
+)
+
+func TestRender(t *testing.T) {
+	var renderList = []struct {
+		Input    qstr.QStr
+		Opts     []qstr.Option
+		Expected string
+	}{
+		{"^1Anti^x444body", nil, `<span style="color:rgb(255,0,0)">Anti<span style="color:rgb(127,127,127)">body</span></span>`},
+		{"a<b>", nil, `a&lt;b&gt;`},
+		{"^1see http://x.org", []qstr.Option{qstr.WithLinks()}, `<span style="color:rgb(255,0,0)">see <a href="http://x.org" rel="nofollow">http://x.org</a></span>`},
+	}
+
+	for _, v := range renderList {
+		var b strings.Builder
+		if err := html.Render(&b, Render(v.Input, v.Opts...)); err != nil {
+			t.Fatal(err)
+		}
+		if received := b.String(); received != v.Expected {
+			t.Errorf("Incorrect nodes for %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestNodesPostProcess(t *testing.T) {
+	nodes := Nodes(qstr.NewRenderer(), "^1Anti^2body")
+	if len(nodes) != 1 || nodes[0].Data != "span" {
+		t.Fatalf("Incorrect top-level nodes. Expected a single span, Got: %v.", len(nodes))
+	}
+
+	// mark every colored run, as a caller post-processing the tree would
+	var mark func(n *html.Node)
+	mark = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "span" {
+			n.Attr = append(n.Attr, html.Attribute{Key: "class", Val: "nick"})
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			mark(c)
+		}
+	}
+	mark(nodes[0])
+
+	var b strings.Builder
+	html.Render(&b, nodes[0])
+	expected := `<span style="color:rgb(255,0,0)" class="nick">Anti<span style="color:rgb(51,255,0)" class="nick">body</span></span>`
+	if received := b.String(); received != expected {
+		t.Errorf("Incorrect post-processed nodes. Expected: %v, Got: %v.", expected, received)
+	}
+}
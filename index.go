@@ -0,0 +1,167 @@
+package qstr
+
+import (
+	"sort"
+	"sync"
+)
+
+// NickIndex finds the nicks closest to a query by edit distance, for "did you
+// mean" searches over many players. Nicks are compared by their normalized
+// text, so colors, case, glyph choice, and invisible characters don't count.
+// Lookups use a BK-tree and don't scan every nick. It is safe for concurrent
+// use.
+type NickIndex struct {
+	mu   sync.RWMutex
+	root *bkNode
+	size int
+}
+
+// Match is a nick found in a NickIndex along with its edit distance from the
+// query.
+type Match struct {
+	Nick     QStr
+	Distance int
+}
+
+// bkNode is a node of a BK-tree, holding every nick that normalizes to key.
+// Each child lies at the distance from key given by its map key.
+type bkNode struct {
+	key      []rune
+	nicks    []indexedNick
+	children map[int]*bkNode
+}
+
+// indexedNick is a nick along with the order in which it was added
+type indexedNick struct {
+	nick QStr
+	seq  int
+}
+
+// NewNickIndex returns a NickIndex holding the given nicks.
+func NewNickIndex(nicks ...QStr) *NickIndex {
+	idx := &NickIndex{}
+	for _, nick := range nicks {
+		idx.Add(nick)
+	}
+	return idx
+}
+
+// Add adds a nick to the index.
+func (idx *NickIndex) Add(nick QStr) {
+	key := []rune(normalizedKey(nick))
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	entry := indexedNick{nick, idx.size}
+	idx.size++
+	if idx.root == nil {
+		idx.root = &bkNode{key: key, nicks: []indexedNick{entry}}
+		return
+	}
+
+	node := idx.root
+	for {
+		d := levenshtein(key, node.key)
+		if d == 0 {
+			node.nicks = append(node.nicks, entry)
+			return
+		}
+		child, ok := node.children[d]
+		if !ok {
+			if node.children == nil {
+				node.children = make(map[int]*bkNode)
+			}
+			node.children[d] = &bkNode{key: key, nicks: []indexedNick{entry}}
+			return
+		}
+		node = child
+	}
+}
+
+// Len returns the number of nicks in the index.
+func (idx *NickIndex) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return idx.size
+}
+
+// Closest returns the nicks within maxDist edits of q, closest first. Nicks
+// at the same distance are returned in the order they were added.
+func (idx *NickIndex) Closest(q QStr, maxDist int) []Match {
+	key := []rune(normalizedKey(q))
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	type found struct {
+		node *bkNode
+		dist int
+	}
+	var nodes []found
+	if idx.root != nil {
+		pending := []*bkNode{idx.root}
+		for len(pending) > 0 {
+			node := pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+
+			d := levenshtein(key, node.key)
+			if d <= maxDist {
+				nodes = append(nodes, found{node, d})
+			}
+
+			// by the triangle inequality only these children can match
+			for cd, child := range node.children {
+				if cd >= d-maxDist && cd <= d+maxDist {
+					pending = append(pending, child)
+				}
+			}
+		}
+	}
+
+	type ranked struct {
+		Match
+		seq int
+	}
+	var all []ranked
+	for _, f := range nodes {
+		for _, entry := range f.node.nicks {
+			all = append(all, ranked{Match{Nick: entry.nick, Distance: f.dist}, entry.seq})
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Distance != all[j].Distance {
+			return all[i].Distance < all[j].Distance
+		}
+		return all[i].seq < all[j].seq
+	})
+
+	matches := make([]Match, len(all))
+	for i, r := range all {
+		matches[i] = r.Match
+	}
+	return matches
+}
+
+// levenshtein returns the number of single-rune insertions, deletions, and
+// substitutions needed to turn a into b.
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
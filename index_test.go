@@ -0,0 +1,51 @@
+package qstr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNickIndex(t *testing.T) {
+	idx := NewNickIndex("^1Antibody", "^x444Anti^5body", "Antibodies", "Morphed", "^2MORPHED", "Samual", "Ant")
+
+	var closestList = []struct {
+		Query    QStr
+		MaxDist  int
+		Expected []Match
+	}{
+		{"antibody", 0, []Match{{"^1Antibody", 0}, {"^x444Anti^5body", 0}}},
+		{"^3antibdy", 2, []Match{{"^1Antibody", 1}, {"^x444Anti^5body", 1}}},
+		{"antibodys", 2, []Match{{"^1Antibody", 1}, {"^x444Anti^5body", 1}, {"Antibodies", 2}}},
+		{"morph", 2, []Match{{"Morphed", 2}, {"^2MORPHED", 2}}},
+		{"nobody", 1, []Match{}},
+	}
+
+	for _, v := range closestList {
+		received := idx.Closest(v.Query, v.MaxDist)
+		if !reflect.DeepEqual(received, v.Expected) {
+			t.Errorf("Incorrect matches for %v. Expected: %v, Got: %v.", v.Query, v.Expected, received)
+		}
+	}
+
+	if received := idx.Len(); received != 7 {
+		t.Errorf("Incorrect index length. Expected: %v, Got: %v.", 7, received)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	var distanceList = []struct {
+		A, B     string
+		Expected int
+	}{
+		{"", "", 0},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+		{"ünï", "uni", 2},
+	}
+
+	for _, v := range distanceList {
+		if received := levenshtein([]rune(v.A), []rune(v.B)); received != v.Expected {
+			t.Errorf("Incorrect distance between %v and %v. Expected: %v, Got: %v.", v.A, v.B, v.Expected, received)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package qstr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// mIRC control characters
+const (
+	ircColor     = '\x03'
+	ircHexColor  = '\x04'
+	ircBold      = '\x02'
+	ircItalic    = '\x1d'
+	ircUnderline = '\x1f'
+	ircStrike    = '\x1e'
+	ircMonospace = '\x11'
+	ircReverse   = '\x16'
+	ircReset     = '\x0f'
+)
+
+// ircColors holds the 16 standard mIRC colors
+var ircColors = [16]RGBColor{
+	NewRGBColorFrom255(255, 255, 255),
+	NewRGBColorFrom255(0, 0, 0),
+	NewRGBColorFrom255(0, 0, 127),
+	NewRGBColorFrom255(0, 147, 0),
+	NewRGBColorFrom255(255, 0, 0),
+	NewRGBColorFrom255(127, 0, 0),
+	NewRGBColorFrom255(156, 0, 156),
+	NewRGBColorFrom255(252, 127, 0),
+	NewRGBColorFrom255(255, 255, 0),
+	NewRGBColorFrom255(0, 252, 0),
+	NewRGBColorFrom255(0, 147, 147),
+	NewRGBColorFrom255(0, 255, 255),
+	NewRGBColorFrom255(0, 0, 252),
+	NewRGBColorFrom255(255, 0, 255),
+	NewRGBColorFrom255(127, 127, 127),
+	NewRGBColorFrom255(210, 210, 210),
+}
+
+// IRC returns s with its color codes converted into mIRC color codes, each
+// color mapped onto the nearest of the 16 standard mIRC colors. Codes are
+// always written with two digits so text starting with a digit is not
+// mistaken for part of the code. mIRC control characters in the text are
+// dropped, so a name can't forge or cancel colors and formatting of its own.
+func (s *QStr) IRC() string {
+	var b strings.Builder
+	colored := false
+	for _, seg := range DarkPlaces.Tokenize(*s) {
+		if seg.Code != "" {
+			fmt.Fprintf(&b, "%c%02d", ircColor, nearestColor(seg.Color, ircColors[:]))
+			colored = true
+		} else if colored {
+			b.WriteByte(ircColor)
+			colored = false
+		}
+		b.WriteString(ircText(seg.Text))
+	}
+	return b.String()
+}
+
+// ircText returns text with the mIRC control characters removed
+func ircText(text string) string {
+	return strings.Map(func(c rune) rune {
+		switch c {
+		case ircColor, ircHexColor, ircBold, ircItalic, ircUnderline, ircStrike, ircMonospace, ircReverse, ircReset:
+			return -1
+		}
+		return c
+	}, text)
+}
+
+// FromIRC converts the mIRC color codes in s into color codes. Colors,
+// including IRCv3 hex colors, become ^xNNN codes, a bare color code or a
+// reset returns to the default color, and backgrounds and other formatting
+// are dropped.
+func FromIRC(s string) QStr {
+	segments := make([]Segment, 0)
+	var state Segment
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			state.Text = text.String()
+			segments = append(segments, state)
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case ircColor:
+			flush()
+			i++
+			fg, n := ircNumber(s[i:])
+			if n == 0 {
+				state = Segment{}
+				continue
+			}
+			i += n
+			if fg < len(ircColors) {
+				state.Color = ircColors[fg]
+				state.Code = HexCode(state.Color)
+			}
+			if i+1 < len(s) && s[i] == ',' {
+				if _, m := ircNumber(s[i+1:]); m > 0 {
+					i += m + 1
+				}
+			}
+		case ircHexColor:
+			flush()
+			i++
+			if len(s) < i+6 {
+				state = Segment{}
+				continue
+			}
+			var r, g, bl int
+			if _, err := fmt.Sscanf(s[i:i+6], "%02x%02x%02x", &r, &g, &bl); err != nil {
+				state = Segment{}
+				continue
+			}
+			state.Color = NewRGBColorFrom255(float64(r), float64(g), float64(bl))
+			state.Code = HexCode(state.Color)
+			i += 6
+		case ircReset:
+			flush()
+			state = Segment{}
+			i++
+		case ircBold, ircItalic, ircUnderline, ircStrike, ircMonospace, ircReverse:
+			i++
+		default:
+			text.WriteByte(s[i])
+			i++
+		}
+	}
+	flush()
+
+	return joinSegments(segments)
+}
+
+// ircNumber parses the one or two digit color number at the start of s,
+// returning it along with the number of bytes it takes up.
+func ircNumber(s string) (int, int) {
+	n := 0
+	for n < 2 && n < len(s) && isDigit(s[n]) {
+		n++
+	}
+	v := 0
+	for _, c := range s[:n] {
+		v = v*10 + int(c-'0')
+	}
+	return v, n
+}
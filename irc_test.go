@@ -0,0 +1,45 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestIRC(t *testing.T) {
+	var ircList = []struct {
+		Input    QStr
+		Expected string
+	}{
+		{"^1Anti^x444body", "\x0304Anti\x0314body"},
+		{"^x0F0Anti^7body", "\x0309Anti\x0300body"},
+		{"plain^3 1st", "plain\x0308 1st"},
+		{"^1An\x0f\x0302ti\x02bo\x1fdy", "\x0304An02tibody"},
+		{"\x0312\x04ff0000\x1d\x1e\x11\x16x", "12ff0000x"},
+	}
+
+	for _, v := range ircList {
+		received := v.Input.IRC()
+		if received != v.Expected {
+			t.Errorf("Incorrect IRC conversion of %v. Expected: %q, Got: %q.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestFromIRC(t *testing.T) {
+	var ircList = []struct {
+		Input    string
+		Expected QStr
+	}{
+		{"\x0304Anti\x0312body", "^xF00Anti^x00Fbody"},
+		{"\x034,12Anti\x03body", "^xF00Anti^7body"},
+		{"\x02bold\x0f \x04ff8800hex", "bold ^xF80hex"},
+		{"\x031,2", ""},
+		{"\x0399x", "x"},
+	}
+
+	for _, v := range ircList {
+		received := FromIRC(v.Input)
+		if received != v.Expected {
+			t.Errorf("Incorrect conversion of %q. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package qstr
+
+import (
+	"strings"
+)
+
+// JoinOption adjusts how Join and Append combine values.
+type JoinOption func(*joinConfig)
+
+type joinConfig struct {
+	reset Code
+}
+
+// JoinReset sets the code inserted to stop a color from carrying over into
+// the next piece. The default is ^7, the engine's default text color.
+func JoinReset(code Code) JoinOption {
+	return func(c *joinConfig) {
+		c.reset = code
+	}
+}
+
+// Join concatenates parts with sep between them. Wherever the text so far
+// ends in a color other than the reset color and the next piece, part or
+// separator, does not start with a color code of its own, the reset code is
+// inserted so that one player's trailing color does not bleed into the next
+// piece. A caret left unfinished at the end of a piece is escaped, so that it
+// can't combine with the start of the next one.
+func Join(parts []QStr, sep QStr, opts ...JoinOption) QStr {
+	c := joinConfig{reset: resetCode}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	var b strings.Builder
+	// the piece added last, which is written once it is known whether
+	// anything follows it
+	last := ""
+	colored := false
+	add := func(piece QStr) {
+		if piece == "" {
+			return
+		}
+		raw := string(piece)
+		// a caret left unfinished at the end of the previous piece would
+		// combine with what follows
+		b.WriteString(escapeUnfinished(last))
+		if colored && basicCodeLen(raw) == 0 {
+			b.WriteString(string(c.reset))
+		}
+		last = raw
+		// without codes of its own the piece was preceded by a reset
+		colored = false
+		if codes := findCodes(raw); len(codes) > 0 {
+			last := codes[len(codes)-1]
+			colored = Code(raw[last[0]:last[1]]) != c.reset
+		}
+	}
+
+	for i, part := range parts {
+		if i > 0 {
+			add(sep)
+		}
+		add(part)
+	}
+	b.WriteString(last)
+	return QStr(b.String())
+}
+
+// Append returns s followed by other, inserting a reset code between them
+// if s would otherwise color the start of other. See Join.
+func (s *QStr) Append(other QStr, opts ...JoinOption) QStr {
+	return Join([]QStr{*s, other}, "", opts...)
+}
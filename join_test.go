@@ -0,0 +1,56 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	var joinList = []struct {
+		Parts    []QStr
+		Sep      QStr
+		Expected QStr
+	}{
+		{[]QStr{"^1Anti", "body"}, ", ", "^1Anti^7, body"},
+		{[]QStr{"^1Anti", "^2body"}, "", "^1Anti^2body"},
+		{[]QStr{"^1Anti", "^2body"}, " vs ", "^1Anti^7 vs ^2body"},
+		{[]QStr{"Anti", "body"}, " ", "Anti body"},
+		{[]QStr{"^1Anti^7", "body"}, " ", "^1Anti^7 body"},
+		{[]QStr{"^1a", "", "b"}, "^3|", "^1a^3|^3|^7b"},
+		{[]QStr{"^x4afa"}, ",", "^x4afa"},
+		{nil, ",", ""},
+		{[]QStr{"a^", "^1b"}, "", "a^^^1b"},
+		{[]QStr{"^1a^", "b"}, "", "^1a^^^7b"},
+		{[]QStr{"a^x4", "af"}, "", "a^^x4af"},
+	}
+
+	for _, v := range joinList {
+		received := Join(v.Parts, v.Sep)
+		if received != v.Expected {
+			t.Errorf("Incorrect join of %q with %q. Expected: %q, Got: %q.", v.Parts, v.Sep, v.Expected, received)
+		}
+	}
+
+	parts := []QStr{"a^", "^1b"}
+	if received := Join(parts, ""); received.Stripped() != "a^b" {
+		t.Errorf("Incorrect text of the join of %q. Expected: %v, Got: %v.", parts, "a^b", received.Stripped())
+	}
+}
+
+func TestAppend(t *testing.T) {
+	nick := QStr("^1Anti")
+
+	expected := QStr("^1Anti^7body")
+	if received := nick.Append("body"); received != expected {
+		t.Errorf("Incorrect append to %v. Expected: %v, Got: %v.", nick, expected, received)
+	}
+
+	expected = QStr("^1Anti^0body")
+	if received := nick.Append("body", JoinReset("^0")); received != expected {
+		t.Errorf("Incorrect append to %v. Expected: %v, Got: %v.", nick, expected, received)
+	}
+
+	expected = QStr("^1Anti^0")
+	if received := expected.Append("body", JoinReset("^0")); received != "^1Anti^0body" {
+		t.Errorf("Incorrect append to %v. Expected: %v, Got: %v.", expected, "^1Anti^0body", received)
+	}
+}
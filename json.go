@@ -0,0 +1,124 @@
+package qstr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONFormat selects how a QStr is represented in JSON.
+type JSONFormat int
+
+const (
+	// JSONRaw encodes the raw string, color codes and all. This is the
+	// default.
+	JSONRaw JSONFormat = iota
+
+	// JSONStripped encodes the string with its color codes removed.
+	JSONStripped
+
+	// JSONObject encodes an object holding the raw string, the stripped
+	// string, and the HTML representation, under the keys "raw",
+	// "stripped", and "html".
+	JSONObject
+)
+
+// DefaultJSONFormat is the format QStr values are encoded in. It should be
+// set once, before any encoding takes place. Fields that need a particular
+// format regardless of it can use the RawJSON, StrippedJSON, and ObjectJSON
+// types instead of QStr.
+var DefaultJSONFormat = JSONRaw
+
+// RawJSON is a QStr that is always encoded in JSON as its raw string.
+type RawJSON QStr
+
+// StrippedJSON is a QStr that is always encoded in JSON as its stripped
+// string.
+type StrippedJSON QStr
+
+// ObjectJSON is a QStr that is always encoded in JSON as an object. See
+// JSONObject.
+type ObjectJSON QStr
+
+// qstrJSON is the JSON object form of a QStr
+type qstrJSON struct {
+	Raw      string `json:"raw"`
+	Stripped string `json:"stripped"`
+	HTML     string `json:"html"`
+}
+
+// marshalJSON encodes s in format f
+func marshalJSON(s QStr, f JSONFormat) ([]byte, error) {
+	switch f {
+	case JSONRaw:
+		return json.Marshal(string(s))
+	case JSONStripped:
+		return json.Marshal(s.Stripped())
+	case JSONObject:
+		return json.Marshal(qstrJSON{Raw: string(s), Stripped: s.Stripped(), HTML: string(s.HTML())})
+	}
+	return nil, fmt.Errorf("qstr: unknown JSON format %d", f)
+}
+
+// unmarshalJSON decodes a QStr encoded as a string or as an object. A string
+// is taken as the raw form, so a stripped string decodes to itself.
+func unmarshalJSON(b []byte) (QStr, error) {
+	if b = bytes.TrimSpace(b); len(b) > 0 && b[0] == '{' {
+		var j qstrJSON
+		if err := json.Unmarshal(b, &j); err != nil {
+			return "", err
+		}
+		return QStr(j.Raw), nil
+	}
+	var raw string
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return "", err
+	}
+	return QStr(raw), nil
+}
+
+// MarshalJSON encodes s in DefaultJSONFormat.
+func (s QStr) MarshalJSON() ([]byte, error) {
+	return marshalJSON(s, DefaultJSONFormat)
+}
+
+// UnmarshalJSON decodes a QStr encoded in any of the JSON formats. Values
+// encoded as JSONStripped decode to the stripped string.
+func (s *QStr) UnmarshalJSON(b []byte) error {
+	v, err := unmarshalJSON(b)
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
+
+// MarshalJSON encodes s as JSONRaw.
+func (s RawJSON) MarshalJSON() ([]byte, error) {
+	return marshalJSON(QStr(s), JSONRaw)
+}
+
+// UnmarshalJSON decodes a QStr encoded in any of the JSON formats.
+func (s *RawJSON) UnmarshalJSON(b []byte) error {
+	return (*QStr)(s).UnmarshalJSON(b)
+}
+
+// MarshalJSON encodes s as JSONStripped.
+func (s StrippedJSON) MarshalJSON() ([]byte, error) {
+	return marshalJSON(QStr(s), JSONStripped)
+}
+
+// UnmarshalJSON decodes a QStr encoded in any of the JSON formats.
+func (s *StrippedJSON) UnmarshalJSON(b []byte) error {
+	return (*QStr)(s).UnmarshalJSON(b)
+}
+
+// MarshalJSON encodes s as JSONObject.
+func (s ObjectJSON) MarshalJSON() ([]byte, error) {
+	return marshalJSON(QStr(s), JSONObject)
+}
+
+// UnmarshalJSON decodes a QStr encoded in any of the JSON formats.
+func (s *ObjectJSON) UnmarshalJSON(b []byte) error {
+	return (*QStr)(s).UnmarshalJSON(b)
+}
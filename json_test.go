@@ -0,0 +1,57 @@
+package qstr
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestQStrJSON(t *testing.T) {
+	type player struct {
+		Nick     QStr         `json:"nick"`
+		Raw      RawJSON      `json:"raw"`
+		Stripped StrippedJSON `json:"stripped"`
+		Object   ObjectJSON   `json:"object"`
+	}
+	p := player{Nick: "^1Anti", Raw: "^1Anti", Stripped: "^1Anti", Object: "^1Anti"}
+
+	var jsonList = []struct {
+		Format   JSONFormat
+		Expected string
+	}{
+		{JSONRaw, `"^1Anti"`},
+		{JSONStripped, `"Anti"`},
+		{JSONObject, `{"raw":"^1Anti","stripped":"Anti","html":"\u003cspan style='color:rgb(255,0,0)'\u003eAnti\u003c/span\u003e"}`},
+	}
+
+	defer func() { DefaultJSONFormat = JSONRaw }()
+	for _, v := range jsonList {
+		DefaultJSONFormat = v.Format
+		b, err := json.Marshal(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `{"nick":` + v.Expected + `,"raw":"^1Anti","stripped":"Anti","object":` + jsonList[2].Expected + `}`
+		if string(b) != expected {
+			t.Errorf("Incorrect JSON for format %v. Expected: %v, Got: %v.", v.Format, expected, string(b))
+		}
+
+		var decoded player
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		expectedNick := QStr("^1Anti")
+		if v.Format == JSONStripped {
+			expectedNick = "Anti"
+		}
+		if decoded.Nick != expectedNick || decoded.Raw != "^1Anti" || decoded.Object != "^1Anti" {
+			t.Errorf("Incorrect decoding of %v. Expected: %v, Got: %+v.", string(b), expectedNick, decoded)
+		}
+	}
+}
+
+func TestQStrJSONInvalid(t *testing.T) {
+	var s QStr
+	if err := json.Unmarshal([]byte(`12`), &s); err == nil {
+		t.Errorf("Incorrect error. Expected an error, Got: nil.")
+	}
+}
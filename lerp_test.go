@@ -0,0 +1,62 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestLerp(t *testing.T) {
+	red, green, blue := RGBColor{1, 0, 0}, RGBColor{0, 1, 0}, RGBColor{0, 0, 1}
+	grey := RGBColor{0.5, 0.5, 0.5}
+
+	var lerpList = []struct {
+		A, B     RGBColor
+		T        float64
+		Space    Space
+		Expected string
+	}{
+		{red, green, 0.5, SpaceRGB, "#808000"},
+		{red, green, 0.5, SpaceLinearRGB, "#bcbc00"},
+		{red, green, 0.5, SpaceHSL, "#ffff00"},
+		{red, blue, 0.5, SpaceHSL, "#ff00ff"},
+		{red, green, 0, SpaceOKLCH, "#ff0000"},
+		{red, green, 1, SpaceOKLCH, "#00ff00"},
+		{red, green, 2, SpaceOKLab, "#00ff00"},
+		{grey, red, 0.5, SpaceHSL, "#bf4040"},
+	}
+
+	for _, v := range lerpList {
+		received := Lerp(v.A, v.B, v.T, v.Space)
+		if received.Hex() != v.Expected {
+			t.Errorf("Incorrect interpolation from %v to %v at %v in space %d. Expected: %v, Got: %v.", v.A, v.B, v.T, v.Space, v.Expected, received)
+		}
+	}
+
+	// the OKLCH midpoint of red and blue keeps its chroma, unlike OKLab's
+	mid, flat := Lerp(red, blue, 0.5, SpaceOKLCH), Lerp(red, blue, 0.5, SpaceOKLab)
+	if midLCH, flatLCH := mid.OKLCH(), flat.OKLCH(); midLCH.C <= flatLCH.C {
+		t.Errorf("Incorrect OKLCH chroma. Expected: more than %v, Got: %v.", flatLCH.C, midLCH.C)
+	}
+}
+
+func TestLerpStops(t *testing.T) {
+	stops := []RGBColor{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	var stopList = []struct {
+		T        float64
+		Expected string
+	}{
+		{0, "#ff0000"},
+		{0.25, "#808000"},
+		{0.5, "#00ff00"},
+		{0.75, "#008080"},
+		{1, "#0000ff"},
+	}
+
+	for _, v := range stopList {
+		if received := LerpStops(stops, v.T, SpaceRGB); received.Hex() != v.Expected {
+			t.Errorf("Incorrect color at %v. Expected: %v, Got: %v.", v.T, v.Expected, received)
+		}
+	}
+	if received := LerpStops(nil, 0.5, SpaceRGB); received != (RGBColor{}) {
+		t.Errorf("Incorrect color without stops. Expected: %v, Got: %v.", RGBColor{}, received)
+	}
+}
@@ -0,0 +1,71 @@
+package qstr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrLimitExceeded is returned by CheckLimits for values beyond the limits
+// set with WithCodeLimit or WithSizeLimit.
+var ErrLimitExceeded = errors.New("qstr: limit exceeded")
+
+// WithCodeLimit caps the number of color codes turned into markup or escape
+// sequences at n, so that a name made of thousands of codes can't inflate
+// the output. Text after the last code within the limit is shown in that
+// code's color, and text following any later codes is appended stripped of
+// its colors, as when a deadline passes; see HTMLContext. Codes that color
+// no text are not counted, as they produce no output. The limit applies to
+// HTML and ANSI output. Zero means no limit.
+func WithCodeLimit(n int) Option {
+	return func(r *Renderer) {
+		r.codeLimit = n
+	}
+}
+
+// WithSizeLimit cuts values longer than n bytes down to n bytes before
+// rendering them as HTML or ANSI, as TruncateBytes does, bounding the work
+// done and the size of the output. Zero means no limit.
+func WithSizeLimit(n int) Option {
+	return func(r *Renderer) {
+		r.sizeLimit = n
+	}
+}
+
+// CheckLimits returns an error wrapping ErrLimitExceeded if s is longer than
+// the size limit or holds more codes than the code limit, for services that
+// would rather reject such values than render them degraded.
+func (r *Renderer) CheckLimits(s QStr) error {
+	if r.sizeLimit > 0 && len(s) > r.sizeLimit {
+		return fmt.Errorf("%w: %d bytes, at most %d allowed", ErrLimitExceeded, len(s), r.sizeLimit)
+	}
+	if r.codeLimit > 0 {
+		codes := 0
+		r.dialect.scan(s, func(seg Segment) bool {
+			if seg.Code != "" {
+				codes++
+			}
+			return true
+		})
+		if codes > r.codeLimit {
+			return fmt.Errorf("%w: %d color codes, at most %d allowed", ErrLimitExceeded, codes, r.codeLimit)
+		}
+	}
+	return nil
+}
+
+// limit returns s cut down to the size limit, and whether it had to be cut
+func (r *Renderer) limit(s QStr) (QStr, bool) {
+	if r.sizeLimit <= 0 || len(s) <= r.sizeLimit {
+		return s, false
+	}
+	return s.TruncateBytes(r.sizeLimit), true
+}
+
+// newDeadline returns a deadline for ctx that also expires once the code
+// limit is reached.
+func (r *Renderer) newDeadline(ctx context.Context) *deadline {
+	d := newDeadline(ctx)
+	d.codeLimit = r.codeLimit
+	return d
+}
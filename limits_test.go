@@ -0,0 +1,68 @@
+package qstr
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"strings"
+	"testing"
+)
+
+func TestCodeLimit(t *testing.T) {
+	r := NewRenderer(WithCodeLimit(2))
+	nick := QStr("[x]^1An^2ti^3bo^4dy")
+
+	expected := template.HTML("[x]<span style='color:rgb(255,0,0)'>An<span style='color:rgb(51,255,0)'>ti</span></span>body")
+	received, ok := r.HTMLContext(context.Background(), nick)
+	if ok || received != expected {
+		t.Errorf("Incorrect HTML value returned for %q. Expected: %v (%v), Got: %v (%v).", nick, expected, false, received, ok)
+	}
+
+	expectedANSI := "[x]\x1b[38;2;255;0;0mAn\x1b[0;38;2;51;255;0mti\x1b[0mbody"
+	if received := r.ANSI(nick); received != expectedANSI {
+		t.Errorf("Incorrect ANSI value returned for %q. Expected: %q, Got: %q.", nick, expectedANSI, received)
+	}
+
+	// codes coloring nothing don't count
+	empty := QStr(strings.Repeat("^x123", 1000) + "^1An^2ti")
+	if received, ok := r.HTMLContext(context.Background(), empty); !ok || received != empty.HTML() {
+		t.Errorf("Incorrect HTML value returned for %q. Expected: %v (%v), Got: %v (%v).", empty, empty.HTML(), true, received, ok)
+	}
+}
+
+func TestSizeLimit(t *testing.T) {
+	r := NewRenderer(WithSizeLimit(8))
+	nick := QStr("^1Anti^2body")
+
+	expected := template.HTML("<span style='color:rgb(255,0,0)'>Anti</span>")
+	received, ok := r.HTMLContext(context.Background(), nick)
+	if ok || received != expected {
+		t.Errorf("Incorrect HTML value returned for %q. Expected: %v (%v), Got: %v (%v).", nick, expected, false, received, ok)
+	}
+	short := QStr("^1Anti")
+	if received, ok := r.HTMLContext(context.Background(), short); !ok || received != short.HTML() {
+		t.Errorf("Incorrect HTML value returned for %q. Expected: %v (%v), Got: %v (%v).", short, short.HTML(), true, received, ok)
+	}
+}
+
+func TestCheckLimits(t *testing.T) {
+	var limitList = []struct {
+		Input    QStr
+		Opts     []Option
+		Exceeded bool
+	}{
+		{"^1Anti^2body", nil, false},
+		{"^1Anti^2body", []Option{WithCodeLimit(2)}, false},
+		{"^1Anti^2bo^3dy", []Option{WithCodeLimit(2)}, true},
+		{"^1^2^3^4Antibody", []Option{WithCodeLimit(2)}, false},
+		{"^1Anti^2body", []Option{WithSizeLimit(12)}, false},
+		{"^1Anti^2body", []Option{WithSizeLimit(11)}, true},
+	}
+
+	for _, v := range limitList {
+		err := NewRenderer(v.Opts...).CheckLimits(v.Input)
+		if errors.Is(err, ErrLimitExceeded) != v.Exceeded {
+			t.Errorf("Incorrect limit check of %q. Expected exceeded: %v, Got: %v.", v.Input, v.Exceeded, err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package qstr
+
+// LinearRGBColor is a color in linear-light RGB with the sRGB primaries.
+// Light adds up in this space, so blending and averaging colors here gives
+// the results the eye expects, without the dark, muddy midpoints that mixing
+// sRGB values gives.
+type LinearRGBColor struct {
+	R, G, B float64
+}
+
+// Linear converts c into linear light, undoing the sRGB transfer curve.
+func (c *RGBColor) Linear() LinearRGBColor {
+	return LinearRGBColor{linearize(c.R), linearize(c.G), linearize(c.B)}
+}
+
+// RGB converts c back into an sRGB color, applying the sRGB transfer curve.
+func (c *LinearRGBColor) RGB() RGBColor {
+	return RGBColor{delinearize(c.R), delinearize(c.G), delinearize(c.B)}
+}
+
+// Mix returns the color a fraction t of the way from a to b, mixing the
+// sRGB values directly. t is clamped to [0, 1].
+func Mix(a, b RGBColor, t float64) RGBColor {
+	t = clamp01(t)
+	return RGBColor{a.R + (b.R-a.R)*t, a.G + (b.G-a.G)*t, a.B + (b.B-a.B)*t}
+}
+
+// MixLinear is like Mix, but mixes the colors in linear light, as light from
+// the two colors would mix. The midpoint of red and green is then a bright
+// yellow rather than a dark olive.
+func MixLinear(a, b RGBColor, t float64) RGBColor {
+	t = clamp01(t)
+	la, lb := a.Linear(), b.Linear()
+	mixed := LinearRGBColor{la.R + (lb.R-la.R)*t, la.G + (lb.G-la.G)*t, la.B + (lb.B-la.B)*t}
+	return mixed.RGB()
+}
@@ -0,0 +1,57 @@
+package qstr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLinearRoundTrip(t *testing.T) {
+	for _, c := range []RGBColor{{0, 0, 0}, {1, 1, 1}, {0.02, 0.5, 0.8}} {
+		lin := c.Linear()
+		if received := lin.RGB(); !closeRGB(received, c) {
+			t.Errorf("Incorrect linear round trip of %v. Got: %v.", c, received)
+		}
+	}
+
+	gray := RGBColor{0.5, 0.5, 0.5}
+	if lin := gray.Linear(); math.Abs(lin.R-0.2140) > 0.0001 {
+		t.Errorf("Incorrect linear value of %v. Expected: %v, Got: %v.", gray, 0.2140, lin.R)
+	}
+}
+
+func TestMix(t *testing.T) {
+	red, green := RGBColor{1, 0, 0}, RGBColor{0, 1, 0}
+	if received := Mix(red, green, 0.5); !closeRGB(received, RGBColor{0.5, 0.5, 0}) {
+		t.Errorf("Incorrect mix. Expected: %v, Got: %v.", RGBColor{0.5, 0.5, 0}, received)
+	}
+
+	expected := RGBColor{delinearize(0.5), delinearize(0.5), 0}
+	if received := MixLinear(red, green, 0.5); !closeRGB(received, expected) {
+		t.Errorf("Incorrect linear mix. Expected: %v, Got: %v.", expected, received)
+	}
+	if received := MixLinear(red, green, 2); !closeRGB(received, green) {
+		t.Errorf("Incorrect linear mix past the end. Expected: %v, Got: %v.", green, received)
+	}
+
+	c := NewRGBAColor(RGBColor{1, 1, 1}, 0.5)
+	if received := c.OverLinear(RGBColor{0, 0, 0}); !closeRGB(received, RGBColor{delinearize(0.5), delinearize(0.5), delinearize(0.5)}) {
+		t.Errorf("Incorrect linear blend of %v. Got: %v.", c, received)
+	}
+}
+
+func TestGradientLinear(t *testing.T) {
+	stops := []RGBColor{{1, 0, 0}, {0, 1, 0}}
+	var gradientList = []struct {
+		Opts     []GradientOption
+		Expected QStr
+	}{
+		{nil, "^xF00a^xCA0b^x0F0c"},
+		{[]GradientOption{GradientLinear()}, "^xF00a^xBB0b^x0F0c"},
+	}
+
+	for _, v := range gradientList {
+		if received := Gradient("abc", stops, v.Opts...); received != v.Expected {
+			t.Errorf("Incorrect gradient. Expected: %v, Got: %v.", v.Expected, received)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package qstr
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// URLs starting with a scheme or with "www."
+var urlPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"]+`)
+
+// findLinks returns <a> annotations for the URLs in the visible text of
+// segments.
+func findLinks(segments []Segment) []annotation {
+	text := segmentsText(segments)
+
+	matches := urlPattern.FindAllStringIndex(text, -1)
+	links := make([]annotation, 0, len(matches))
+	for _, m := range matches {
+		// punctuation ending a sentence is not part of the URL
+		url := strings.TrimRight(text[m[0]:m[1]], ".,;:!?)'")
+		end := m[0] + len(url)
+		if strings.HasPrefix(strings.ToLower(url), "www.") {
+			url = "http://" + url
+		}
+		links = append(links, annotation{
+			start: m[0],
+			end:   end,
+			open:  fmt.Sprintf("<a href=\"%s\" rel=\"nofollow\">", html.EscapeString(url)),
+			close: "</a>",
+		})
+	}
+	return links
+}
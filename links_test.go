@@ -0,0 +1,35 @@
+package qstr
+
+import (
+	"html/template"
+	"testing"
+)
+
+func TestHTMLLinks(t *testing.T) {
+	var linkList = []struct {
+		Input    QStr
+		Expected template.HTML
+	}{
+		{
+			"^1see http://xonotic.org.",
+			"<span style='color:rgb(255,0,0)'>see <a href=\"http://xonotic.org\" rel=\"nofollow\">http://xonotic.org</a>.</span>",
+		},
+		{
+			"^1demo: https://exa^2mple.com/^3a?b=1&c=2 ok",
+			"<span style='color:rgb(255,0,0)'>demo: <a href=\"https://example.com/a?b=1&amp;c=2\" rel=\"nofollow\">https://exa" +
+				"<span style='color:rgb(51,255,0)'>mple.com/<span style='color:rgb(255,255,0)'>a?b=1&amp;c=2</span></span></a>" +
+				"<span style='color:rgb(255,255,0)'> ok</span></span>",
+		},
+		{
+			"www.xonotic.org",
+			"<a href=\"http://www.xonotic.org\" rel=\"nofollow\">www.xonotic.org</a>",
+		},
+	}
+
+	for _, v := range linkList {
+		received := v.Input.HTML(WithLinks())
+		if received != v.Expected {
+			t.Errorf("Incorrect HTML value returned for %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+// Package logparse recognizes the lines of DarkPlaces and Xonotic server logs
+// that concern players, such as joins, chat and frags, and extracts player
+// names and messages as qstr.QStr values with their color codes intact.
+//
+// Two kinds of line are understood: the machine-readable event log written
+// with sv_eventlog enabled, whose lines start with a colon, and chat lines as
+// printed to the server console, which start with a \x01 byte. Frags are only
+// logged in the event log.
+package logparse
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/antzucaro/qstr"
+)
+
+// Kind is the kind of an Event.
+type Kind int
+
+const (
+	// Join is a player entering the server.
+	Join Kind = iota
+
+	// Part is a player leaving the server.
+	Part
+
+	// Rename is a player changing their name. Player is the new name and
+	// Other the old one.
+	Rename
+
+	// Chat is a public chat message.
+	Chat
+
+	// TeamChat is a chat message to the player's team.
+	TeamChat
+
+	// Frag is a player, Player, killing another, Other.
+	Frag
+
+	// TeamKill is a player, Player, killing a teammate, Other.
+	TeamKill
+
+	// Suicide is a player dying by their own hand or by accident.
+	Suicide
+)
+
+var kindNames = []string{"join", "part", "rename", "chat", "team chat", "frag", "team kill", "suicide"}
+
+// String returns the name of k.
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return "Kind(" + strconv.Itoa(int(k)) + ")"
+	}
+	return kindNames[k]
+}
+
+// Event is a recognized log line.
+type Event struct {
+	Kind Kind
+
+	// PlayerID is the server's id for Player, or 0 if the line does not
+	// give one, as in console chat lines.
+	PlayerID int
+
+	// Player is the player the event is about: the player joining,
+	// leaving or chatting, or the killer.
+	Player qstr.QStr
+
+	// OtherID and Other are the victim of a frag or team kill.
+	OtherID int
+	Other   qstr.QStr
+
+	// Message is the text of a chat message.
+	Message qstr.QStr
+
+	// DeathType is the cause of death of a kill, such as
+	// "weapon:vortex", as given by the event log.
+	DeathType string
+
+	// IP is the address of a joining player, or "bot" for bots.
+	IP string
+}
+
+// Parser parses the lines of a single server log. Event log lines refer to
+// players by id after they join, so a Parser remembers the name of every
+// player present to fill in Player and Other. A Parser is not safe for
+// concurrent use.
+type Parser struct {
+	names map[int]qstr.QStr
+}
+
+// NewParser returns a Parser that knows of no players yet.
+func NewParser() *Parser {
+	return &Parser{names: make(map[int]qstr.QStr)}
+}
+
+// Parse parses a line of the log, without its trailing newline, reporting
+// whether it was recognized. Lines must be passed in log order, including
+// the ones that are not recognized, so that names are known when needed.
+func (p *Parser) Parse(line string) (Event, bool) {
+	line = strings.TrimRight(line, "\r\n")
+	switch {
+	case strings.HasPrefix(line, ":"):
+		return p.parseEvent(line[1:])
+	case strings.HasPrefix(line, "\x01"):
+		return parseChat(line[1:])
+	}
+	return Event{}, false
+}
+
+// parseEvent parses an event log line without its leading colon
+func (p *Parser) parseEvent(line string) (Event, bool) {
+	kind, rest, _ := strings.Cut(line, ":")
+	switch kind {
+	case "join":
+		// :join:<id>:<slot>:<ip>:<name>, the name being the rest of the
+		// line as it may hold colons itself
+		f := strings.SplitN(rest, ":", 4)
+		if len(f) != 4 {
+			return Event{}, false
+		}
+		id, ok := playerID(f[0])
+		if !ok {
+			return Event{}, false
+		}
+		p.names[id] = qstr.QStr(f[3])
+		return Event{Kind: Join, PlayerID: id, Player: qstr.QStr(f[3]), IP: f[2]}, true
+
+	case "part":
+		id, ok := playerID(rest)
+		if !ok {
+			return Event{}, false
+		}
+		name := p.names[id]
+		delete(p.names, id)
+		return Event{Kind: Part, PlayerID: id, Player: name}, true
+
+	case "name":
+		f, name, _ := strings.Cut(rest, ":")
+		id, ok := playerID(f)
+		if !ok {
+			return Event{}, false
+		}
+		old := p.names[id]
+		p.names[id] = qstr.QStr(name)
+		return Event{Kind: Rename, PlayerID: id, Player: qstr.QStr(name), Other: old}, true
+
+	case "chat", "chat_team":
+		f, msg, found := strings.Cut(rest, ":")
+		id, ok := playerID(f)
+		if !found || !ok {
+			return Event{}, false
+		}
+		e := Event{Kind: Chat, PlayerID: id, Player: p.names[id], Message: qstr.QStr(msg)}
+		if kind == "chat_team" {
+			e.Kind = TeamChat
+		}
+		return e, true
+
+	case "kill":
+		// :kill:<frag|tk|suicide|accident>:<killer>:<victim>:type=<type>:...
+		f := strings.Split(rest, ":")
+		if len(f) < 3 {
+			return Event{}, false
+		}
+		killer, ok := playerID(f[1])
+		if !ok {
+			return Event{}, false
+		}
+		victim, ok := playerID(f[2])
+		if !ok {
+			return Event{}, false
+		}
+
+		e := Event{PlayerID: killer, Player: p.names[killer], OtherID: victim, Other: p.names[victim]}
+		switch f[0] {
+		case "frag":
+			e.Kind = Frag
+		case "tk":
+			e.Kind = TeamKill
+		case "suicide", "accident":
+			e.Kind = Suicide
+		default:
+			return Event{}, false
+		}
+		// death types are themselves written with a colon, as in
+		// type=weapon:vortex
+		if i := strings.Index(rest, ":type="); i >= 0 {
+			t := rest[i+len(":type="):]
+			if end := strings.Index(t, ":items="); end >= 0 {
+				t = t[:end]
+			}
+			e.DeathType = t
+		}
+		return e, true
+	}
+	return Event{}, false
+}
+
+// parseChat parses a console chat line without its leading \x01. Public
+// messages are written as <name>^7: <message> and team messages as
+// \r^N(<name>^N) ^7<message>, ^N being the team's color.
+func parseChat(line string) (Event, bool) {
+	if team, ok := strings.CutPrefix(line, "\r"); ok {
+		if len(team) < 3 || team[0] != '^' || team[2] != '(' {
+			return Event{}, false
+		}
+		end := team[:2] + ") ^7"
+		name, msg, found := strings.Cut(team[3:], end)
+		if !found {
+			return Event{}, false
+		}
+		return Event{Kind: TeamChat, Player: qstr.QStr(name), Message: qstr.QStr(msg)}, true
+	}
+
+	name, msg, found := strings.Cut(line, "^7: ")
+	if !found {
+		return Event{}, false
+	}
+	return Event{Kind: Chat, Player: qstr.QStr(name), Message: qstr.QStr(msg)}, true
+}
+
+// playerID parses a player id
+func playerID(s string) (int, bool) {
+	id, err := strconv.Atoi(s)
+	return id, err == nil && id > 0
+}
+
+// ParseAll parses every line read from r, returning the recognized events
+// in log order.
+func ParseAll(r io.Reader) ([]Event, error) {
+	p := NewParser()
+	var events []Event
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if e, ok := p.Parse(scanner.Text()); ok {
+			events = append(events, e)
+		}
+	}
+	return events, scanner.Err()
+}
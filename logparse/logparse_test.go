@@ -0,0 +1,65 @@
+package logparse
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	p := NewParser()
+	tests := []struct {
+		line     string
+		expected Event
+		ok       bool
+	}{
+		{":join:1:1:127.0.0.1:^1Anti^5body", Event{Kind: Join, PlayerID: 1, Player: "^1Anti^5body", IP: "127.0.0.1"}, true},
+		{":join:2:2:bot:^x4afbot:tom", Event{Kind: Join, PlayerID: 2, Player: "^x4afbot:tom", IP: "bot"}, true},
+		{":chat:1:^3gg ^7all", Event{Kind: Chat, PlayerID: 1, Player: "^1Anti^5body", Message: "^3gg ^7all"}, true},
+		{":chat_team:2:push", Event{Kind: TeamChat, PlayerID: 2, Player: "^x4afbot:tom", Message: "push"}, true},
+		{":kill:frag:1:2:type=weapon:vortex:items=4:victimitems=8", Event{Kind: Frag, PlayerID: 1, Player: "^1Anti^5body", OtherID: 2, Other: "^x4afbot:tom", DeathType: "weapon:vortex"}, true},
+		{":kill:tk:2:1:type=weapon:shotgun", Event{Kind: TeamKill, PlayerID: 2, Player: "^x4afbot:tom", OtherID: 1, Other: "^1Anti^5body", DeathType: "weapon:shotgun"}, true},
+		{":kill:suicide:1:1:type=lava", Event{Kind: Suicide, PlayerID: 1, Player: "^1Anti^5body", OtherID: 1, Other: "^1Anti^5body", DeathType: "lava"}, true},
+		{":name:1:^2Body", Event{Kind: Rename, PlayerID: 1, Player: "^2Body", Other: "^1Anti^5body"}, true},
+		{":part:1", Event{Kind: Part, PlayerID: 1, Player: "^2Body"}, true},
+		{":chat:1:who?", Event{Kind: Chat, PlayerID: 1, Message: "who?"}, true},
+		{"\x01^1Anti^5body^7: hi ^2there", Event{Kind: Chat, Player: "^1Anti^5body", Message: "hi ^2there"}, true},
+		{"\x01\r^4(^1Anti^5body^4) ^7go ^1red", Event{Kind: TeamChat, Player: "^1Anti^5body", Message: "go ^1red"}, true},
+		{":gamestart:dm_stormkeep:1", Event{}, false},
+		{":join:x:1:bot:nick", Event{}, false},
+		{":kill:frag:1", Event{}, false},
+		{"\x01no separator", Event{}, false},
+		{"Server listening on address 0.0.0.0:26000", Event{}, false},
+	}
+
+	for _, tt := range tests {
+		e, ok := p.Parse(tt.line)
+		if ok != tt.ok || !reflect.DeepEqual(e, tt.expected) {
+			t.Errorf("Incorrect event for %q. Expected: %+v %v, Got: %+v %v.", tt.line, tt.expected, tt.ok, e, ok)
+		}
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	log := ":join:3:1:10.0.0.1:^1a\r\nnoise\n:kill:frag:3:4:type=weapon:mortar\n"
+	events, err := ParseAll(strings.NewReader(log))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Event{
+		{Kind: Join, PlayerID: 3, Player: "^1a", IP: "10.0.0.1"},
+		{Kind: Frag, PlayerID: 3, Player: "^1a", OtherID: 4, DeathType: "weapon:mortar"},
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Incorrect events. Expected: %+v, Got: %+v.", expected, events)
+	}
+}
+
+func TestKindString(t *testing.T) {
+	if s := TeamChat.String(); s != "team chat" {
+		t.Errorf("Incorrect kind name. Expected: %v, Got: %v.", "team chat", s)
+	}
+	if s := Kind(42).String(); s != "Kind(42)" {
+		t.Errorf("Incorrect kind name. Expected: %v, Got: %v.", "Kind(42)", s)
+	}
+}
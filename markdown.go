@@ -0,0 +1,23 @@
+package qstr
+
+import (
+	"strings"
+)
+
+// markdownEscaper escapes the characters with a meaning in Markdown, as
+// used by chat platforms
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"|", `\|`,
+	"~", `\~`,
+)
+
+// Markdown returns the stripped form of s with the Markdown metacharacters
+// \, *, _, `, |, and ~ escaped with backslashes, so a name such as |_*x*_|
+// shows as it is in bot messages and can't break table formatting.
+func (s *QStr) Markdown() string {
+	return markdownEscaper.Replace(s.Stripped())
+}
@@ -0,0 +1,24 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestMarkdown(t *testing.T) {
+	var markdownList = []struct {
+		Input    QStr
+		Expected string
+	}{
+		{"^1Anti^x4afbody", "Antibody"},
+		{"^1|_*x*_|", `\|\_\*x\*\_\|`},
+		{"~~`code`~~", "\\~\\~\\`code\\`\\~\\~"},
+		{`back\slash`, `back\\slash`},
+		{"^^1 caret", "^1 caret"},
+	}
+
+	for _, v := range markdownList {
+		if received := v.Input.Markdown(); received != v.Expected {
+			t.Errorf("Incorrect Markdown for %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package qstr
+
+import (
+	"hash/fnv"
+)
+
+// ColorOption adjusts the colors picked by ColorFromString.
+type ColorOption func(*colorConfig)
+
+type colorConfig struct {
+	minSaturation, maxSaturation float64
+	minLightness, maxLightness   float64
+}
+
+func newColorConfig(opts []ColorOption) *colorConfig {
+	c := &colorConfig{
+		minSaturation: 0.6,
+		maxSaturation: 0.9,
+		minLightness:  0.55,
+		maxLightness:  0.7,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ColorSaturation bounds the HSL saturation of the colors picked, in the
+// range [0, 1]. The default is [0.6, 0.9].
+func ColorSaturation(lo, hi float64) ColorOption {
+	return func(c *colorConfig) {
+		c.minSaturation, c.maxSaturation = lo, hi
+	}
+}
+
+// ColorLightness bounds the HSL lightness of the colors picked, in the
+// range [0, 1]. The default is [0.55, 0.7], which reads well on a dark
+// background.
+func ColorLightness(lo, hi float64) ColorOption {
+	return func(c *colorConfig) {
+		c.minLightness, c.maxLightness = lo, hi
+	}
+}
+
+// ColorTheme bounds the lightness of the colors picked to the middle half of
+// the theme's lightness bounds, so they stay readable on its background
+// without washing out to white or black.
+func ColorTheme(theme Theme) ColorOption {
+	span := theme.MaxLightness - theme.MinLightness
+	return ColorLightness(theme.MinLightness+span/4, theme.MaxLightness-span/4)
+}
+
+// ColorFromString returns a color derived from a hash of s. The same string
+// always gets the same color, so players without color codes in their nick
+// can be colored consistently across page loads. Pass a normalized form of
+// the nick, such as its lower-cased stripped text, to give every spelling
+// of a name the same color.
+func ColorFromString(s string, opts ...ColorOption) RGBColor {
+	c := newColorConfig(opts)
+
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	sum := h.Sum64()
+
+	// independent bits of the hash pick each component
+	hue := float64(sum&0xffff) / 0xffff
+	sat := c.minSaturation + float64(sum>>16&0xffff)/0xffff*(c.maxSaturation-c.minSaturation)
+	light := c.minLightness + float64(sum>>32&0xffff)/0xffff*(c.maxLightness-c.minLightness)
+
+	hsl := HSLColor{hue, sat, light}
+	return hsl.RGB()
+}
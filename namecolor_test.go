@@ -0,0 +1,30 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestColorFromString(t *testing.T) {
+	names := []string{"antibody", "someone", "player", ""}
+	for _, name := range names {
+		c := ColorFromString(name)
+		if again := ColorFromString(name); again != c {
+			t.Errorf("Incorrect color for %q. Expected the same color, Got: %v and %v.", name, c, again)
+		}
+
+		hsl := c.HSL()
+		if hsl.L < 0.55-1e-9 || hsl.L > 0.7+1e-9 || hsl.S < 0.6-1e-9 || hsl.S > 0.9+1e-9 {
+			t.Errorf("Incorrect color bounds for %q. Got: %+v.", name, hsl)
+		}
+
+		c = ColorFromString(name, ColorTheme(LightTheme), ColorSaturation(0.5, 0.5))
+		hsl = c.HSL()
+		if hsl.L < 0.125-1e-9 || hsl.L > 0.375+1e-9 || hsl.S < 0.5-1e-9 || hsl.S > 0.5+1e-9 {
+			t.Errorf("Incorrect light theme color bounds for %q. Got: %+v.", name, hsl)
+		}
+	}
+
+	if ColorFromString("antibody") == ColorFromString("someone") {
+		t.Errorf("Incorrect colors. Expected different names to get different colors.")
+	}
+}
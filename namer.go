@@ -0,0 +1,142 @@
+package qstr
+
+import (
+	"strings"
+)
+
+// Namer names colors, for transcripts read out by screen readers.
+type Namer interface {
+	// Name returns the name of the color nearest to c
+	Name(c RGBColor) string
+}
+
+// NamedColor is a color along with its name.
+type NamedColor struct {
+	Name  string
+	Color RGBColor
+}
+
+// EnglishColors are the colors named by EnglishNamer.
+var EnglishColors = []NamedColor{
+	{"black", NewRGBColorFrom255(0, 0, 0)},
+	{"dark gray", NewRGBColorFrom255(64, 64, 64)},
+	{"gray", NewRGBColorFrom255(128, 128, 128)},
+	{"light gray", NewRGBColorFrom255(192, 192, 192)},
+	{"white", NewRGBColorFrom255(255, 255, 255)},
+	{"red", NewRGBColorFrom255(255, 0, 0)},
+	{"dark red", NewRGBColorFrom255(128, 0, 0)},
+	{"pink", NewRGBColorFrom255(255, 102, 178)},
+	{"orange", NewRGBColorFrom255(255, 128, 0)},
+	{"brown", NewRGBColorFrom255(128, 64, 0)},
+	{"yellow", NewRGBColorFrom255(255, 255, 0)},
+	{"green", NewRGBColorFrom255(0, 255, 0)},
+	{"dark green", NewRGBColorFrom255(0, 128, 0)},
+	{"cyan", NewRGBColorFrom255(0, 255, 255)},
+	{"light blue", NewRGBColorFrom255(102, 178, 255)},
+	{"blue", NewRGBColorFrom255(0, 64, 255)},
+	{"navy", NewRGBColorFrom255(0, 0, 128)},
+	{"purple", NewRGBColorFrom255(128, 0, 128)},
+	{"magenta", NewRGBColorFrom255(255, 0, 255)},
+}
+
+// EnglishNamer names colors in English. It is the default Namer.
+var EnglishNamer = NewNamer(EnglishColors)
+
+// CSSNamer names colors after the nearest of CSSColors.
+var CSSNamer = NewNamer(CSSColors)
+
+// listNamer names colors after the perceptually nearest of a list
+type listNamer struct {
+	names []NamedColor
+	labs  []LabColor
+}
+
+// NewNamer returns a Namer naming each color after the perceptually nearest
+// of colors.
+func NewNamer(colors []NamedColor) Namer {
+	n := &listNamer{names: colors, labs: make([]LabColor, len(colors))}
+	for i, c := range colors {
+		n.labs[i] = c.Color.Lab()
+	}
+	return n
+}
+
+// Name returns the name of the color nearest to c.
+func (n *listNamer) Name(c RGBColor) string {
+	if len(n.names) == 0 {
+		return ""
+	}
+	lab := c.Lab()
+	best := 0
+	for i := range n.labs {
+		if lab.distance(n.labs[i]) < lab.distance(n.labs[best]) {
+			best = i
+		}
+	}
+	return n.names[best].Name
+}
+
+// translatedNamer looks up the names of another Namer in a dictionary
+type translatedNamer struct {
+	base         Namer
+	translations map[string]string
+}
+
+// Translate returns a Namer that names colors as base does, translated using
+// translations. Names missing from translations are returned untranslated,
+// so EnglishNamer can be localized without redefining its colors:
+//
+//	Translate(EnglishNamer, map[string]string{"red": "rot", "blue": "blau"})
+func Translate(base Namer, translations map[string]string) Namer {
+	return &translatedNamer{base, translations}
+}
+
+// Name returns the translated name of the color nearest to c.
+func (n *translatedNamer) Name(c RGBColor) string {
+	name := n.base.Name(c)
+	if t, ok := n.translations[name]; ok {
+		return t
+	}
+	return name
+}
+
+// Annotated returns a plain-text transcript of s for screen readers, in
+// which each run of colored text is followed by the name of its color in
+// parentheses, such as "Anti (red) body (blue)". Runs whose colors share a
+// name are merged, uncolored text is left as it is, and runs are separated
+// by single spaces. If namer is nil, EnglishNamer is used.
+func (s *QStr) Annotated(namer Namer) string {
+	if namer == nil {
+		namer = EnglishNamer
+	}
+
+	parts := make([]string, 0)
+	var run strings.Builder
+	name := ""
+	flush := func() {
+		text := strings.TrimSpace(run.String())
+		run.Reset()
+		if text == "" {
+			return
+		}
+		if name != "" {
+			text += " (" + name + ")"
+		}
+		parts = append(parts, text)
+	}
+
+	for _, seg := range DarkPlaces.Tokenize(*s) {
+		next := ""
+		if seg.Code != "" {
+			next = namer.Name(seg.Color)
+		}
+		if next != name {
+			flush()
+			name = next
+		}
+		run.WriteString(seg.Text)
+	}
+	flush()
+
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,39 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestAnnotated(t *testing.T) {
+	german := Translate(EnglishNamer, map[string]string{"red": "rot", "blue": "blau", "dark gray": "dunkelgrau"})
+
+	var annotatedList = []struct {
+		Input    QStr
+		Namer    Namer
+		Expected string
+	}{
+		{"^1Anti^4body", nil, "Anti (red) body (blue)"},
+		{"^1Anti^xF00body", nil, "Antibody (red)"},
+		{"[X] ^1Anti^7", nil, "[X] Anti (red)"},
+		{"^1Anti^x444bo^4dy", german, "Anti (rot) bo (dunkelgrau) dy (blau)"},
+		{"Antibody", german, "Antibody"},
+	}
+
+	for _, v := range annotatedList {
+		received := v.Input.Annotated(v.Namer)
+		if received != v.Expected {
+			t.Errorf("Incorrect transcript of %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestNewNamer(t *testing.T) {
+	n := NewNamer([]NamedColor{{"dark", RGBColor{0, 0, 0}}, {"light", RGBColor{1, 1, 1}}})
+
+	if received := n.Name(NewRGBColorFrom255(200, 180, 190)); received != "light" {
+		t.Errorf("Incorrect color name. Expected: %v, Got: %v.", "light", received)
+	}
+	if received := NewNamer(nil).Name(RGBColor{}); received != "" {
+		t.Errorf("Incorrect color name. Expected: %v, Got: %v.", "", received)
+	}
+}
@@ -0,0 +1,78 @@
+package qstr
+
+import (
+	"bytes"
+)
+
+// the first of the gold numeral glyphs, 0 through 9, of the Quake charset
+const goldNumeralBase = '\ue012'
+
+// DecodeOption adjusts the translation done by Decode and Encode.
+type DecodeOption func(key map[rune]rune)
+
+// GoldNumerals translates the alternate "gold" numeral glyphs of the Quake
+// charset, U+E012 through U+E01B, to the ASCII digits during Decode, and the
+// digits back to them during Encode. This overrides whatever the key maps
+// those glyphs to, so numbers embedded in names parse as numbers.
+func GoldNumerals() DecodeOption {
+	return func(key map[rune]rune) {
+		for i := rune(0); i < 10; i++ {
+			key[goldNumeralBase+i] = '0' + i
+		}
+	}
+}
+
+// Encode is the reverse of Decode: it converts characters within a QStr
+// into the glyphs that key maps onto them, leaving color codes alone. Where
+// several glyphs map onto the same character, the lowest one is used, except
+// for digits when GoldNumerals is given.
+func (s *QStr) Encode(key map[rune]rune, opts ...DecodeOption) QStr {
+	reverse := make(map[rune]rune, len(key))
+	for glyph, c := range key {
+		if prev, ok := reverse[c]; !ok || glyph < prev {
+			reverse[c] = glyph
+		}
+	}
+	if len(opts) > 0 {
+		numerals := make(map[rune]rune)
+		for _, opt := range opts {
+			opt(numerals)
+		}
+		for glyph, c := range numerals {
+			reverse[c] = glyph
+		}
+	}
+
+	// color codes and escapes are copied as they are
+	raw := string(*s)
+	var buffer bytes.Buffer
+	prev := 0
+	for _, loc := range append(findCodesAndEscapes(raw), []int{len(raw), len(raw)}) {
+		for _, c := range raw[prev:loc[0]] {
+			if v, ok := reverse[c]; ok {
+				buffer.WriteRune(v)
+			} else {
+				buffer.WriteRune(c)
+			}
+		}
+		buffer.WriteString(raw[loc[0]:loc[1]])
+		prev = loc[1]
+	}
+	return QStr(buffer.String())
+}
+
+// withDecodeOptions returns key adjusted by opts. The key itself is left
+// untouched.
+func withDecodeOptions(key map[rune]rune, opts []DecodeOption) map[rune]rune {
+	if len(opts) == 0 {
+		return key
+	}
+	adjusted := make(map[rune]rune, len(key)+10)
+	for glyph, c := range key {
+		adjusted[glyph] = c
+	}
+	for _, opt := range opts {
+		opt(adjusted)
+	}
+	return adjusted
+}
@@ -0,0 +1,50 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestGoldNumerals(t *testing.T) {
+	nick := QStr("^1Score: \ue013\ue012\ue01b")
+
+	expected := QStr("^1Score: 109")
+	if received := nick.Decode(XonoticDecodeKey, GoldNumerals()); received != expected {
+		t.Errorf("Incorrect decoding of %v. Expected: %v, Got: %v.", nick, expected, received)
+	}
+
+	// without the option the key's own mapping applies
+	if received := nick.Decode(XonoticDecodeKey); received == expected {
+		t.Errorf("Incorrect decoding of %v. Expected gold numerals to stay untranslated, Got: %v.", nick, received)
+	}
+	if XonoticDecodeKey['\ue012'] == '0' {
+		t.Errorf("Incorrect decode key. Expected GoldNumerals to leave the key untouched.")
+	}
+
+	if received := expected.Encode(nil, GoldNumerals()); received != nick {
+		t.Errorf("Incorrect encoding of %v. Expected: %v, Got: %v.", expected, nick, received)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	key := map[rune]rune{'\ue0e1': 'a', '\ue061': 'a', '\ue062': 'b', '\ue031': '1'}
+	nick := QStr("^1abc1")
+
+	expected := QStr("^1\ue061\ue062c\ue031")
+	if received := nick.Encode(key); received != expected {
+		t.Errorf("Incorrect encoding of %v. Expected: %v, Got: %v.", nick, expected, received)
+	}
+	if received := expected.Decode(key); received != nick {
+		t.Errorf("Incorrect decoding of %v. Expected: %v, Got: %v.", expected, nick, received)
+	}
+}
+
+func TestEncodeXonoticSymbols(t *testing.T) {
+	nick := QStr("^1Anti😃body « ^^ »")
+	expected := QStr("^1Anti\ue013body \ue018 ^^ \ue019")
+	if received := nick.Encode(XonoticSymbolKey); received != expected {
+		t.Errorf("Incorrect encoding of %v. Expected: %+q, Got: %+q.", nick, expected, received)
+	}
+	if received := expected.Decode(XonoticDecodeKey); received != nick {
+		t.Errorf("Incorrect decoding of %v. Expected: %v, Got: %v.", expected, nick, received)
+	}
+}
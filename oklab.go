@@ -0,0 +1,138 @@
+package qstr
+
+import (
+	"math"
+)
+
+// OKLabColor is a color in the OKLab space, which predicts perceived
+// lightness, chroma, and hue more evenly than CIE L*a*b*. L is in the range
+// [0, 1].
+type OKLabColor struct {
+	// Lightness, and the green-red and blue-yellow axes
+	L, A, B float64
+}
+
+// OKLCHColor is an OKLab color in polar form. L is in the range [0, 1], C is
+// the chroma, and H is the hue angle in degrees in the range [0, 360).
+type OKLCHColor struct {
+	// Lightness, Chroma, and Hue
+	L, C, H float64
+}
+
+// linearize converts an sRGB channel to linear light
+func linearize(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// delinearize converts a linear light channel to sRGB
+func delinearize(v float64) float64 {
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+// OKLab converts an sRGB color into an OKLabColor.
+func (c *RGBColor) OKLab() OKLabColor {
+	r, g, b := linearize(c.R), linearize(c.G), linearize(c.B)
+
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	return OKLabColor{
+		0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+// OKLCH converts an sRGB color into an OKLCHColor.
+func (c *RGBColor) OKLCH() OKLCHColor {
+	lab := c.OKLab()
+	return lab.LCH()
+}
+
+// RGB converts an OKLabColor into an sRGB color. Colors outside the sRGB
+// gamut have channels outside the range [0, 1].
+func (c *OKLabColor) RGB() RGBColor {
+	l := c.L + 0.3963377774*c.A + 0.2158037573*c.B
+	m := c.L - 0.1055613458*c.A - 0.0638541728*c.B
+	s := c.L - 0.0894841775*c.A - 1.2914855480*c.B
+	l, m, s = l*l*l, m*m*m, s*s*s
+
+	return RGBColor{
+		delinearize(4.0767416621*l - 3.3077115913*m + 0.2309699292*s),
+		delinearize(-1.2684380046*l + 2.6097574011*m - 0.3413193965*s),
+		delinearize(-0.0041960863*l - 0.7034186147*m + 1.7076147010*s),
+	}
+}
+
+// LCH converts an OKLabColor into polar form.
+func (c *OKLabColor) LCH() OKLCHColor {
+	h := math.Atan2(c.B, c.A) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return OKLCHColor{c.L, math.Hypot(c.A, c.B), h}
+}
+
+// OKLab converts an OKLCHColor into rectangular form.
+func (c *OKLCHColor) OKLab() OKLabColor {
+	h := c.H * math.Pi / 180
+	return OKLabColor{c.L, c.C * math.Cos(h), c.C * math.Sin(h)}
+}
+
+// RGB converts an OKLCHColor into an sRGB color. Colors outside the sRGB
+// gamut have their chroma reduced until they fit, keeping their lightness
+// and hue.
+func (c *OKLCHColor) RGB() RGBColor {
+	inGamut := func(rgb RGBColor) bool {
+		const eps = 1e-9
+		return rgb.R >= -eps && rgb.R <= 1+eps && rgb.G >= -eps && rgb.G <= 1+eps && rgb.B >= -eps && rgb.B <= 1+eps
+	}
+
+	lab := c.OKLab()
+	rgb := lab.RGB()
+	if !inGamut(rgb) {
+		lo, hi := 0.0, c.C
+		for i := 0; i < 32; i++ {
+			mid := OKLCHColor{c.L, (lo + hi) / 2, c.H}
+			lab = mid.OKLab()
+			if inGamut(lab.RGB()) {
+				lo = mid.C
+			} else {
+				hi = mid.C
+			}
+		}
+		lower := OKLCHColor{c.L, lo, c.H}
+		lab = lower.OKLab()
+		rgb = lab.RGB()
+	}
+
+	return RGBColor{clamp01(rgb.R), clamp01(rgb.G), clamp01(rgb.B)}
+}
+
+// CapLightnessOKLCH is like CapLightness, but trims the OKLCH lightness of
+// the color instead of its HSL lightness. Since OKLCH lightness follows the
+// perceived lightness, bright yellows are darkened as much as they need to
+// be and dark blues lightened, while their hues are kept.
+func (c *RGBColor) CapLightnessOKLCH(floor float64, ceiling float64) RGBColor {
+	// check invalid values
+	if floor >= ceiling || floor < 0 || ceiling > 1 {
+		return *c
+	}
+
+	lch := c.OKLCH()
+	if lch.L < floor {
+		lch.L = floor
+	} else if lch.L > ceiling {
+		lch.L = ceiling
+	} else {
+		return *c
+	}
+	return lch.RGB()
+}
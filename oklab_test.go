@@ -0,0 +1,80 @@
+package qstr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOKLab(t *testing.T) {
+	// reference values from the OKLab specification
+	var oklabList = []struct {
+		RGB      RGBColor
+		Expected OKLabColor
+	}{
+		{RGBColor{1, 1, 1}, OKLabColor{1, 0, 0}},
+		{RGBColor{1, 0, 0}, OKLabColor{0.62796, 0.22486, 0.12585}},
+		{RGBColor{0, 1, 0}, OKLabColor{0.86644, -0.23389, 0.17950}},
+		{RGBColor{0, 0, 1}, OKLabColor{0.45201, -0.03246, -0.31153}},
+	}
+
+	for _, v := range oklabList {
+		received := v.RGB.OKLab()
+		if math.Abs(received.L-v.Expected.L) > 0.0001 || math.Abs(received.A-v.Expected.A) > 0.0001 || math.Abs(received.B-v.Expected.B) > 0.0001 {
+			t.Errorf("Incorrect OKLab conversion of %v. Expected: %v, Got: %v.", v.RGB, v.Expected, received)
+		}
+
+		rgb := received.RGB()
+		if math.Abs(rgb.R-v.RGB.R) > 1e-5 || math.Abs(rgb.G-v.RGB.G) > 1e-5 || math.Abs(rgb.B-v.RGB.B) > 1e-5 {
+			t.Errorf("Incorrect RGB conversion of %v. Expected: %v, Got: %v.", received, v.RGB, rgb)
+		}
+
+		lch := v.RGB.OKLCH()
+		back := lch.RGB()
+		if math.Abs(back.R-v.RGB.R) > 1e-5 || math.Abs(back.G-v.RGB.G) > 1e-5 || math.Abs(back.B-v.RGB.B) > 1e-5 {
+			t.Errorf("Incorrect RGB conversion of %v. Expected: %v, Got: %v.", lch, v.RGB, back)
+		}
+	}
+}
+
+func TestCapLightnessOKLCH(t *testing.T) {
+	yellow := RGBColor{1, 1, 0}
+	capped := yellow.CapLightnessOKLCH(0.5, 0.8)
+	lch, orig := capped.OKLCH(), yellow.OKLCH()
+	if math.Abs(lch.L-0.8) > 0.001 {
+		t.Errorf("Incorrect lightness of capped %v. Expected: %v, Got: %v.", yellow, 0.8, lch.L)
+	}
+	if math.Abs(lch.H-orig.H) > 0.5 {
+		t.Errorf("Incorrect hue of capped %v. Expected: %v, Got: %v.", yellow, orig.H, lch.H)
+	}
+
+	blue := RGBColor{0, 0, 0.5}
+	capped = blue.CapLightnessOKLCH(0.5, 0.8)
+	if lch := capped.OKLCH(); math.Abs(lch.L-0.5) > 0.001 {
+		t.Errorf("Incorrect lightness of capped %v. Expected: %v, Got: %v.", blue, 0.5, lch.L)
+	}
+
+	gray := RGBColor{0.6, 0.6, 0.6}
+	if received := gray.CapLightnessOKLCH(0.5, 0.8); received != gray {
+		t.Errorf("Incorrect capping of %v. Expected: %v, Got: %v.", gray, gray, received)
+	}
+	if received := yellow.CapLightnessOKLCH(0.8, 0.5); received != yellow {
+		t.Errorf("Incorrect capping with invalid bounds. Expected: %v, Got: %v.", yellow, received)
+	}
+}
+
+func TestWithOKLCH(t *testing.T) {
+	nick := QStr("^xFF0Anti")
+	yellow := RGBColor{1, 1, 0}
+
+	capped := yellow.CapLightnessOKLCH(0.2, 0.6)
+	expected := capped.SpanStr() + "Anti</span>"
+	if received := nick.HTML(WithOKLCH(), WithLightnessBounds(0.2, 0.6)); string(received) != expected {
+		t.Errorf("Incorrect HTML value returned for %v. Expected: %v, Got: %v.", nick, expected, received)
+	}
+
+	capped = yellow.CapLightness(0.2, 0.6)
+	expected = capped.SpanStr() + "Anti</span>"
+	if received := nick.HTML(WithLightnessBounds(0.2, 0.6)); string(received) != expected {
+		t.Errorf("Incorrect HTML value returned for %v. Expected: %v, Got: %v.", nick, expected, received)
+	}
+}
@@ -0,0 +1,329 @@
+package qstr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// RenderOptionsVersion is the version of the JSON form of RenderOptions
+// written by this version of the library. Stored options with a newer
+// version are rejected rather than replayed differently.
+const RenderOptionsVersion = 1
+
+// ErrUnsupportedVersion is returned when decoding RenderOptions stored by a
+// newer version of the library.
+var ErrUnsupportedVersion = errors.New("qstr: unsupported render options version")
+
+// RenderOptions is a serializable form of the Renderer options, so that a
+// rendering configuration can be stored, for instance per site in a
+// database, and replayed identically later. Options that take functions or
+// arbitrary values, such as WithDialect, WithClassFunc, WithEscaping, and
+// WithWidthFunc, can't be stored and must be appended to the result of
+// Options by the caller.
+type RenderOptions struct {
+	// Version is the version of the JSON form. It is set to
+	// RenderOptionsVersion when encoding if zero.
+	Version int
+
+	// Palette replaces the colors of the basic color codes if set.
+	Palette *Palette
+
+	// LightnessBounds replaces the lower and upper lightness bounds if set.
+	// It is applied after Email.
+	LightnessBounds *[2]float64
+
+	// OKLCH caps lightness in the OKLCH space. See WithOKLCH.
+	OKLCH bool
+
+	Email         bool
+	ClassPrefix   string
+	ClassesOnly   bool
+	Background    BackgroundMode
+	ColorDepth    ColorDepth
+	CopyMode      CopyMode
+	Reveal        bool
+	BidiIsolation bool
+	Title         bool
+	Links         bool
+	Canonical     bool
+	FlatSpans     bool
+	RawData       bool
+
+	// Tag is passed to WithTag if set.
+	Tag string
+
+	// MinContrast and ContrastBackground are passed to WithMinContrast if
+	// MinContrast is set.
+	MinContrast        float64
+	ContrastBackground RGBColor
+
+	// CodeLimit and SizeLimit are passed to WithCodeLimit and
+	// WithSizeLimit if set.
+	CodeLimit int
+	SizeLimit int
+
+	// DecodeXonotic translates Xonotic font glyphs with XonoticDecodeKey.
+	DecodeXonotic bool
+
+	// Replacement is passed to WithReplacement if set.
+	Replacement *string
+}
+
+// Options returns the Renderer options described by o.
+func (o *RenderOptions) Options() []Option {
+	var opts []Option
+	if o.Palette != nil {
+		opts = append(opts, WithPalette(*o.Palette))
+	}
+	if o.Email {
+		opts = append(opts, WithEmail())
+	}
+	if o.LightnessBounds != nil {
+		opts = append(opts, WithLightnessBounds(o.LightnessBounds[0], o.LightnessBounds[1]))
+	}
+	if o.OKLCH {
+		opts = append(opts, WithOKLCH())
+	}
+	if o.ClassPrefix != "" {
+		opts = append(opts, WithClassPrefix(o.ClassPrefix))
+	}
+	if o.ClassesOnly {
+		opts = append(opts, WithClassesOnly())
+	}
+	opts = append(opts,
+		WithBackground(o.Background),
+		WithColorDepth(o.ColorDepth),
+		WithCopyData(o.CopyMode))
+	if o.Reveal {
+		opts = append(opts, WithReveal())
+	}
+	if o.BidiIsolation {
+		opts = append(opts, WithBidiIsolation())
+	}
+	if o.Title {
+		opts = append(opts, WithTitle())
+	}
+	if o.Links {
+		opts = append(opts, WithLinks())
+	}
+	if o.Canonical {
+		opts = append(opts, WithCanonical())
+	}
+	if o.FlatSpans {
+		opts = append(opts, WithFlatSpans())
+	}
+	if o.RawData {
+		opts = append(opts, WithRawData())
+	}
+	if o.Tag != "" {
+		opts = append(opts, WithTag(o.Tag))
+	}
+	if o.MinContrast > 0 {
+		opts = append(opts, WithMinContrast(o.ContrastBackground, o.MinContrast))
+	}
+	if o.CodeLimit > 0 {
+		opts = append(opts, WithCodeLimit(o.CodeLimit))
+	}
+	if o.SizeLimit > 0 {
+		opts = append(opts, WithSizeLimit(o.SizeLimit))
+	}
+	if o.DecodeXonotic {
+		opts = append(opts, WithDecodeKey(XonoticDecodeKey))
+	}
+	if o.Replacement != nil {
+		opts = append(opts, WithReplacement(*o.Replacement))
+	}
+	return opts
+}
+
+// the names the modes are stored under
+var (
+	backgroundNames = []string{"foreground", "background", "foreground-and-background"}
+	depthNames      = []string{"truecolor", "256", "16"}
+	copyModeNames   = []string{"none", "stripped", "raw"}
+)
+
+// renderOptionsJSON is the JSON form of RenderOptions
+type renderOptionsJSON struct {
+	Version         int         `json:"version"`
+	Palette         []string    `json:"palette,omitempty"`
+	LightnessBounds *[2]float64 `json:"lightness_bounds,omitempty"`
+	OKLCH           bool        `json:"oklch,omitempty"`
+	Email           bool        `json:"email,omitempty"`
+	ClassPrefix     string      `json:"class_prefix,omitempty"`
+	ClassesOnly     bool        `json:"classes_only,omitempty"`
+	Background      string      `json:"background"`
+	ColorDepth      string      `json:"color_depth"`
+	CopyMode        string      `json:"copy_mode"`
+	Reveal          bool        `json:"reveal,omitempty"`
+	BidiIsolation   bool        `json:"bidi_isolation,omitempty"`
+	Title           bool        `json:"title,omitempty"`
+	Links           bool        `json:"links,omitempty"`
+	Canonical       bool        `json:"canonical,omitempty"`
+	FlatSpans       bool        `json:"flat_spans,omitempty"`
+	RawData         bool        `json:"raw_data,omitempty"`
+	Tag             string      `json:"tag,omitempty"`
+	MinContrast     float64     `json:"min_contrast,omitempty"`
+	ContrastBg      string      `json:"contrast_background,omitempty"`
+	CodeLimit       int         `json:"code_limit,omitempty"`
+	SizeLimit       int         `json:"size_limit,omitempty"`
+	DecodeXonotic   bool        `json:"decode_xonotic,omitempty"`
+	Replacement     *string     `json:"replacement,omitempty"`
+}
+
+// MarshalJSON encodes o with colors written as #rrggbb and modes by name.
+func (o RenderOptions) MarshalJSON() ([]byte, error) {
+	j := renderOptionsJSON{
+		Version:         o.Version,
+		LightnessBounds: o.LightnessBounds,
+		OKLCH:           o.OKLCH,
+		Email:           o.Email,
+		ClassPrefix:     o.ClassPrefix,
+		ClassesOnly:     o.ClassesOnly,
+		Reveal:          o.Reveal,
+		BidiIsolation:   o.BidiIsolation,
+		Title:           o.Title,
+		Links:           o.Links,
+		Canonical:       o.Canonical,
+		FlatSpans:       o.FlatSpans,
+		RawData:         o.RawData,
+		Tag:             o.Tag,
+		MinContrast:     o.MinContrast,
+		CodeLimit:       o.CodeLimit,
+		SizeLimit:       o.SizeLimit,
+		DecodeXonotic:   o.DecodeXonotic,
+		Replacement:     o.Replacement,
+	}
+	if j.Version == 0 {
+		j.Version = RenderOptionsVersion
+	}
+	if o.MinContrast > 0 {
+		j.ContrastBg = o.ContrastBackground.Hex()
+	}
+	if o.Palette != nil {
+		for _, c := range o.Palette {
+			j.Palette = append(j.Palette, c.Hex())
+		}
+	}
+
+	var err error
+	if j.Background, err = modeName(backgroundNames, int(o.Background), "background mode"); err != nil {
+		return nil, err
+	}
+	if j.ColorDepth, err = modeName(depthNames, int(o.ColorDepth), "color depth"); err != nil {
+		return nil, err
+	}
+	if j.CopyMode, err = modeName(copyModeNames, int(o.CopyMode), "copy mode"); err != nil {
+		return nil, err
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes options encoded by MarshalJSON. It returns
+// ErrUnsupportedVersion for options of a version it doesn't know.
+func (o *RenderOptions) UnmarshalJSON(b []byte) error {
+	var j renderOptionsJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	if j.Version < 1 || j.Version > RenderOptionsVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, j.Version)
+	}
+
+	res := RenderOptions{
+		Version:         j.Version,
+		LightnessBounds: j.LightnessBounds,
+		OKLCH:           j.OKLCH,
+		Email:           j.Email,
+		ClassPrefix:     j.ClassPrefix,
+		ClassesOnly:     j.ClassesOnly,
+		Reveal:          j.Reveal,
+		BidiIsolation:   j.BidiIsolation,
+		Title:           j.Title,
+		Links:           j.Links,
+		Canonical:       j.Canonical,
+		FlatSpans:       j.FlatSpans,
+		RawData:         j.RawData,
+		Tag:             j.Tag,
+		MinContrast:     j.MinContrast,
+		CodeLimit:       j.CodeLimit,
+		SizeLimit:       j.SizeLimit,
+		DecodeXonotic:   j.DecodeXonotic,
+		Replacement:     j.Replacement,
+	}
+	if j.Palette != nil {
+		if len(j.Palette) != len(Palette{}) {
+			return fmt.Errorf("qstr: palette has %d colors, expected %d", len(j.Palette), len(Palette{}))
+		}
+		var p Palette
+		for i, h := range j.Palette {
+			c, err := parseHexColor(h)
+			if err != nil {
+				return err
+			}
+			p[i] = c
+		}
+		res.Palette = &p
+	}
+	if j.ContrastBg != "" {
+		c, err := parseHexColor(j.ContrastBg)
+		if err != nil {
+			return err
+		}
+		res.ContrastBackground = c
+	}
+
+	var err error
+	var mode int
+	if mode, err = modeValue(backgroundNames, j.Background, "background mode"); err != nil {
+		return err
+	}
+	res.Background = BackgroundMode(mode)
+	if mode, err = modeValue(depthNames, j.ColorDepth, "color depth"); err != nil {
+		return err
+	}
+	res.ColorDepth = ColorDepth(mode)
+	if mode, err = modeValue(copyModeNames, j.CopyMode, "copy mode"); err != nil {
+		return err
+	}
+	res.CopyMode = CopyMode(mode)
+
+	*o = res
+	return nil
+}
+
+// modeName returns the stored name of a mode
+func modeName(names []string, mode int, kind string) (string, error) {
+	if mode < 0 || mode >= len(names) {
+		return "", fmt.Errorf("qstr: unknown %s %d", kind, mode)
+	}
+	return names[mode], nil
+}
+
+// modeValue returns the mode stored under name. An empty name selects the
+// default.
+func modeValue(names []string, name, kind string) (int, error) {
+	if name == "" {
+		return 0, nil
+	}
+	for i, n := range names {
+		if n == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("qstr: unknown %s %q", kind, name)
+}
+
+// parseHexColor parses a color written as #rrggbb
+func parseHexColor(s string) (RGBColor, error) {
+	if len(s) != 7 || s[0] != '#' {
+		return RGBColor{}, fmt.Errorf("qstr: invalid color %q", s)
+	}
+	v, err := strconv.ParseUint(s[1:], 16, 32)
+	if err != nil {
+		return RGBColor{}, fmt.Errorf("qstr: invalid color %q", s)
+	}
+	return NewRGBColorFrom255(float64(v>>16), float64(v>>8&0xff), float64(v&0xff)), nil
+}
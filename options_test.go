@@ -0,0 +1,91 @@
+package qstr
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRenderOptionsRoundTrip(t *testing.T) {
+	palette := XonoticPalette
+	palette[1] = NewRGBColorFrom255(200, 10, 10)
+	replacement := "?"
+	opts := RenderOptions{
+		Palette:         &palette,
+		LightnessBounds: &[2]float64{0.2, 0.8},
+		OKLCH:           true,
+		ClassPrefix:     "q-",
+		Background:      BackgroundOnly,
+		ColorDepth:      Color256,
+		CopyMode:        CopyRaw,
+		Title:           true,
+		Canonical:       true,
+		MinContrast:     ContrastAA,
+		CodeLimit:       64,
+		SizeLimit:       1024,
+		DecodeXonotic:   true,
+		Replacement:     &replacement,
+	}
+	opts.ContrastBackground = RGBColor{1, 1, 1}
+
+	b, err := json.Marshal(opts)
+	if err != nil {
+		t.Fatalf("Incorrect encoding of options. Got error: %v.", err)
+	}
+	var received RenderOptions
+	if err := json.Unmarshal(b, &received); err != nil {
+		t.Fatalf("Incorrect decoding of %s. Got error: %v.", b, err)
+	}
+
+	expected := opts
+	expected.Version = RenderOptionsVersion
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("Incorrect round trip of options. Expected: %+v, Got: %+v.", expected, received)
+	}
+
+	nick := QStr("^1Anti^x444body")
+	if a, b := nick.HTML(opts.Options()...), nick.HTML(received.Options()...); a != b {
+		t.Errorf("Incorrect output with decoded options. Expected: %v, Got: %v.", a, b)
+	}
+}
+
+func TestRenderOptionsJSON(t *testing.T) {
+	b, err := json.Marshal(RenderOptions{Email: true})
+	if err != nil {
+		t.Fatalf("Incorrect encoding of options. Got error: %v.", err)
+	}
+	expected := `{"version":1,"email":true,"background":"foreground","color_depth":"truecolor","copy_mode":"none"}`
+	if string(b) != expected {
+		t.Errorf("Incorrect encoding of options. Expected: %v, Got: %s.", expected, b)
+	}
+
+	var opts RenderOptions
+	if err := json.Unmarshal([]byte(`{"version":1}`), &opts); err != nil || !reflect.DeepEqual(opts, RenderOptions{Version: 1}) {
+		t.Errorf("Incorrect decoding of minimal options. Got: %+v (%v).", opts, err)
+	}
+}
+
+func TestRenderOptionsErrors(t *testing.T) {
+	for _, in := range []string{`{"version":2}`, `{}`} {
+		var opts RenderOptions
+		if err := json.Unmarshal([]byte(in), &opts); !errors.Is(err, ErrUnsupportedVersion) {
+			t.Errorf("Incorrect error for %v. Expected: %v, Got: %v.", in, ErrUnsupportedVersion, err)
+		}
+	}
+
+	for _, in := range []string{
+		`{"version":1,"background":"sideways"}`,
+		`{"version":1,"palette":["#000000"]}`,
+		`{"version":1,"palette":["#000000","#000000","#000000","#000000","#000000","#000000","#000000","#000000","#000000","red"]}`,
+	} {
+		var opts RenderOptions
+		if err := json.Unmarshal([]byte(in), &opts); err == nil {
+			t.Errorf("Incorrect decoding of %v. Expected an error, Got: %+v.", in, opts)
+		}
+	}
+
+	if _, err := json.Marshal(RenderOptions{ColorDepth: 7}); err == nil {
+		t.Errorf("Incorrect encoding of an unknown color depth. Expected an error, Got: nil.")
+	}
+}
@@ -0,0 +1,107 @@
+package qstr
+
+import (
+	"encoding/json"
+	"io"
+	"unicode/utf8"
+)
+
+// FontMetrics gives the advance widths of the glyphs of a font at the size
+// it will be drawn at, so that Overlay can position text the way the client
+// will draw it.
+type FontMetrics struct {
+	// Advances maps characters to their advance widths in pixels.
+	Advances map[rune]float64
+
+	// DefaultAdvance is the advance of characters missing from Advances.
+	// Characters that CellWidth measures as two cells wide take twice as
+	// much, and combining marks none.
+	DefaultAdvance float64
+}
+
+// MonospaceMetrics returns the FontMetrics of a monospaced font of the given
+// size in pixels, whose glyphs are about 0.6em wide.
+func MonospaceMetrics(size float64) *FontMetrics {
+	return &FontMetrics{DefaultAdvance: size * 0.6}
+}
+
+// advance returns the advance width of a grapheme cluster, taken from its
+// first character.
+func (m *FontMetrics) advance(grapheme string) float64 {
+	c, _ := utf8.DecodeRuneInString(grapheme)
+	if a, ok := m.Advances[c]; ok {
+		return a
+	}
+	return m.DefaultAdvance * float64(CellWidth(grapheme))
+}
+
+// GlyphRun is a run of text drawn in a single color, positioned on the
+// baseline of an overlay.
+type GlyphRun struct {
+	// Text is the text of the run, translated as in HTML output.
+	Text string `json:"text"`
+
+	// X is the offset of the start of the run in pixels.
+	X float64 `json:"x"`
+
+	// Width is the total advance of the run in pixels.
+	Width float64 `json:"width"`
+
+	// Color is the text color as #rrggbb.
+	Color string `json:"color"`
+
+	// Background is the background color as #rrggbb, if the run has one.
+	Background string `json:"background,omitempty"`
+
+	// Advances holds the advance width of each grapheme cluster of Text.
+	Advances []float64 `json:"advances"`
+}
+
+// Overlay is the layout of a QStr as glyph runs, ready for a HUD or stream
+// overlay to draw.
+type Overlay struct {
+	// Width is the total advance of all runs in pixels.
+	Width float64 `json:"width"`
+
+	Runs []GlyphRun `json:"runs"`
+}
+
+// WriteJSON writes o to w as JSON.
+func (o *Overlay) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(o)
+}
+
+// Overlay lays s out as glyph runs measured with m. Options may be given to
+// alter the output; see Renderer.
+func (s *QStr) Overlay(m *FontMetrics, opts ...Option) Overlay {
+	return NewRenderer(opts...).Overlay(*s, m)
+}
+
+// Overlay lays s out as glyph runs measured with m, one run per segment.
+// Text without a color is drawn in ^7's color, and hex colors are capped to
+// the theme's lightness bounds, as in HTML output.
+func (r *Renderer) Overlay(s QStr, m *FontMetrics) Overlay {
+	o := Overlay{Runs: []GlyphRun{}}
+	for _, seg := range r.dialect.Tokenize(s) {
+		c := r.color(Segment{Code: resetCode})
+		if seg.Code != "" {
+			c = r.color(seg)
+			if seg.Code.IsHex() {
+				c = r.capLightness(c)
+			}
+		}
+
+		run := GlyphRun{Text: r.text(seg.Text), X: o.Width, Color: c.Hex(), Advances: []float64{}}
+		if seg.HasBackground {
+			run.Background = seg.Background.Hex()
+		}
+		for _, g := range graphemes(run.Text) {
+			a := m.advance(g)
+			run.Advances = append(run.Advances, a)
+			run.Width += a
+		}
+		o.Width += run.Width
+		o.Runs = append(o.Runs, run)
+	}
+	return o
+}
@@ -0,0 +1,45 @@
+package qstr
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOverlay(t *testing.T) {
+	m := &FontMetrics{Advances: map[rune]float64{'i': 4, 'W': 14}, DefaultAdvance: 8}
+	nick := QStr("Wi^1ab^x000日")
+
+	o := nick.Overlay(m)
+	expected := Overlay{
+		Width: 8*2 + 4 + 14 + 16,
+		Runs: []GlyphRun{
+			{Text: "Wi", X: 0, Width: 18, Color: "#ffffff", Advances: []float64{14, 4}},
+			{Text: "ab", X: 18, Width: 16, Color: "#ff0000", Advances: []float64{8, 8}},
+			{Text: "日", X: 34, Width: 16, Color: o.Runs[2].Color, Advances: []float64{16}},
+		},
+	}
+	if !reflect.DeepEqual(o, expected) {
+		t.Errorf("Incorrect overlay of %v. Expected: %+v, Got: %+v.", nick, expected, o)
+	}
+
+	// hex colors are capped like in HTML output
+	if o.Runs[2].Color == "#000000" {
+		t.Errorf("Incorrect color for ^x000. Expected a capped color, Got: %v.", o.Runs[2].Color)
+	}
+}
+
+func TestOverlayJSON(t *testing.T) {
+	nick := QStr("^1a")
+	o := nick.Overlay(MonospaceMetrics(10))
+
+	var b bytes.Buffer
+	if err := o.WriteJSON(&b); err != nil {
+		t.Fatalf("Incorrect JSON output. Got error: %v.", err)
+	}
+	expected := `{"width":6,"runs":[{"text":"a","x":0,"width":6,"color":"#ff0000","advances":[6]}]}`
+	if received := strings.TrimSpace(b.String()); received != expected {
+		t.Errorf("Incorrect JSON for %v. Expected: %v, Got: %v.", nick, expected, received)
+	}
+}
@@ -0,0 +1,86 @@
+package qstr
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// Palette holds the colors of the basic color codes, ^0 through ^9.
+type Palette [10]RGBColor
+
+// XonoticPalette is the palette used by Xonotic.
+var XonoticPalette = Palette{
+	NewRGBColorFrom255(128, 128, 128),
+	NewRGBColorFrom255(255, 0, 0),
+	NewRGBColorFrom255(51, 255, 0),
+	NewRGBColorFrom255(255, 255, 0),
+	NewRGBColorFrom255(51, 102, 255),
+	NewRGBColorFrom255(51, 255, 255),
+	NewRGBColorFrom255(255, 51, 102),
+	NewRGBColorFrom255(255, 255, 255),
+	NewRGBColorFrom255(153, 153, 153),
+	NewRGBColorFrom255(128, 128, 128),
+}
+
+// Quake3Palette is the palette used by Quake III Arena, which has eight
+// colors; ^8 and ^9 wrap around to black and red.
+var Quake3Palette = Palette{
+	{0, 0, 0},
+	{1, 0, 0},
+	{0, 1, 0},
+	{1, 1, 0},
+	{0, 0, 1},
+	{0, 1, 1},
+	{1, 0, 1},
+	{1, 1, 1},
+	{0, 0, 0},
+	{1, 0, 0},
+}
+
+// QuakeLivePalette is the palette used by Quake Live, which kept the colors
+// of Quake III Arena.
+var QuakeLivePalette = Quake3Palette
+
+// DarkPlacesPalette is the palette of the DarkPlaces engine, used by mods
+// that don't define their own. Its ^8 is a half-transparent white, which is
+// given here as opaque white.
+var DarkPlacesPalette = Palette{
+	{0, 0, 0},
+	{1, 0, 0},
+	{0, 1, 0},
+	{1, 1, 0},
+	{0, 0, 1},
+	{0, 1, 1},
+	{1, 0, 1},
+	{1, 1, 1},
+	{1, 1, 1},
+	{0.5, 0.5, 0.5},
+}
+
+// color returns the foreground color of seg, taking the basic color codes
+// from the palette.
+func (p *Palette) color(seg Segment) RGBColor {
+	if len(seg.Code) == 2 && isDigit(seg.Code[1]) {
+		return p[seg.Code[1]-'0']
+	}
+	return seg.Color
+}
+
+// LegendHTML returns an HTML table showing a swatch of each color in the
+// palette next to the code that produces it, along with a sample of colored
+// text.
+func (p *Palette) LegendHTML() template.HTML {
+	var b strings.Builder
+
+	b.WriteString("<table class=\"qstr-legend\">")
+	b.WriteString("<tr><th>Code</th><th>Color</th><th>Sample</th></tr>")
+	for i, c := range p {
+		fmt.Fprintf(&b, "<tr><td><code>^%d</code></td>", i)
+		fmt.Fprintf(&b, "<td><span style=\"display:inline-block;width:1em;height:1em;background-color:%s\"></span></td>", c.CSS())
+		fmt.Fprintf(&b, "<td><span style=\"color:%s\">Sample</span></td></tr>", c.CSS())
+	}
+	b.WriteString("</table>")
+
+	return template.HTML(b.String())
+}
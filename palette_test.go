@@ -0,0 +1,73 @@
+package qstr
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLegendHTML(t *testing.T) {
+	legend := string(XonoticPalette.LegendHTML())
+
+	expectedRows := []string{
+		"<td><code>^0</code></td><td><span style=\"display:inline-block;width:1em;height:1em;background-color:rgb(128,128,128)\"></span></td>",
+		"<td><code>^4</code></td><td><span style=\"display:inline-block;width:1em;height:1em;background-color:rgb(51,102,255)\"></span></td>",
+		"<td><span style=\"color:rgb(255,255,255)\">Sample</span></td>",
+	}
+	for _, expected := range expectedRows {
+		if !strings.Contains(legend, expected) {
+			t.Errorf("Legend is missing %v. Got: %v.", expected, legend)
+		}
+	}
+
+	if n := strings.Count(legend, "<tr>"); n != 11 {
+		t.Errorf("Incorrect number of legend rows. Expected: %v, Got: %v.", 11, n)
+	}
+}
+
+func TestPalettePresets(t *testing.T) {
+	nick := QStr("^4Anti^x0F0body")
+
+	var presetList = []struct {
+		Palette  Palette
+		HTML     string
+		Decimal  QStr
+		Colors   []RGBColor
+		Inverted QStr
+	}{
+		{
+			XonoticPalette,
+			"<span style='color:rgb(51,102,255)'>Anti<span style=\"color:rgb(0,255,0)\">body</span></span>",
+			"^4Anti^2body",
+			[]RGBColor{NewRGBColorFrom255(51, 102, 255), {0, 1, 0}},
+			"^xC90Anti^xF0Fbody",
+		},
+		{
+			Quake3Palette,
+			"<span style=\"color:rgb(0,0,255)\">Anti<span style=\"color:rgb(0,255,0)\">body</span></span>",
+			"^4Anti^2body",
+			[]RGBColor{{0, 0, 1}, {0, 1, 0}},
+			"^xFF0Anti^xF0Fbody",
+		},
+	}
+
+	for _, v := range presetList {
+		if received := string(nick.HTML(WithPalette(v.Palette))); received != v.HTML {
+			t.Errorf("Incorrect HTML value returned for %v. Expected: %v, Got: %v.", nick, v.HTML, received)
+		}
+		if received := v.Palette.ToDecimalCodes(nick); received != v.Decimal {
+			t.Errorf("Incorrect conversion of %v. Expected: %v, Got: %v.", nick, v.Decimal, received)
+		}
+		if received := v.Palette.Colors(nick); !reflect.DeepEqual(received, v.Colors) {
+			t.Errorf("Incorrect colors of %v. Expected: %v, Got: %v.", nick, v.Colors, received)
+		}
+		invert := func(c RGBColor) RGBColor { return RGBColor{1 - c.R, 1 - c.G, 1 - c.B} }
+		if received := v.Palette.MapColors(nick, invert); received != v.Inverted {
+			t.Errorf("Incorrect mapping of %v. Expected: %v, Got: %v.", nick, v.Inverted, received)
+		}
+	}
+
+	if QuakeLivePalette != Quake3Palette || DarkPlacesPalette[9] != (RGBColor{0.5, 0.5, 0.5}) {
+		t.Errorf("Incorrect palette presets.")
+	}
+}
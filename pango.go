@@ -0,0 +1,58 @@
+package qstr
+
+import (
+	"html"
+	"strings"
+)
+
+// Pango returns s as Pango markup, with each colored run wrapped in a span
+// element, for GTK labels. Options may be given to alter the output; see
+// Renderer.
+func (s *QStr) Pango(opts ...Option) string {
+	return NewRenderer(opts...).Pango(*s)
+}
+
+// Pango returns s as Pango markup. Each segment with a color or style is
+// wrapped in its own <span> element, with the color in its foreground
+// attribute, any dialect background in its background attribute, and bold,
+// italic, and underlined text given by the weight, style, and underline
+// attributes. Hex colors are capped to the theme's lightness bounds, as in
+// HTML output. Spans are never nested.
+func (r *Renderer) Pango(s QStr) string {
+	var b strings.Builder
+	for _, seg := range r.dialect.Tokenize(s) {
+		text := html.EscapeString(r.text(seg.Text))
+		if text == "" {
+			continue
+		}
+
+		var attrs []string
+		if seg.Code != "" {
+			c := r.color(seg)
+			if seg.Code.IsHex() {
+				c = r.capLightness(c)
+			}
+			attrs = append(attrs, `foreground="`+c.Hex()+`"`)
+		}
+		if seg.HasBackground {
+			attrs = append(attrs, `background="`+seg.Background.Hex()+`"`)
+		}
+		for _, a := range []struct {
+			style Style
+			attr  string
+		}{{Bold, `weight="bold"`}, {Italic, `style="italic"`}, {Underline, `underline="single"`}} {
+			if seg.Style.Has(a.style) {
+				attrs = append(attrs, a.attr)
+			}
+		}
+
+		if len(attrs) == 0 {
+			b.WriteString(text)
+			continue
+		}
+		b.WriteString("<span " + strings.Join(attrs, " ") + ">")
+		b.WriteString(text)
+		b.WriteString("</span>")
+	}
+	return b.String()
+}
@@ -0,0 +1,24 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestPango(t *testing.T) {
+	var pangoList = []struct {
+		Input    QStr
+		Options  []Option
+		Expected string
+	}{
+		{"^1Anti^x444body", nil, `<span foreground="#ff0000">Anti</span><span foreground="#808080">body</span>`},
+		{"<X>^5A&B", nil, `&lt;X&gt;<span foreground="#33ffff">A&amp;B</span>`},
+		{"^1^2", nil, ""},
+		{"^bAnti^1body", []Option{WithDialect(&Dialect{Codes: []ExtCode{StyleCode("b", Bold)}})}, `<span weight="bold">Anti</span><span foreground="#ff0000" weight="bold">body</span>`},
+	}
+
+	for _, v := range pangoList {
+		if received := v.Input.Pango(v.Options...); received != v.Expected {
+			t.Errorf("Incorrect Pango markup for %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
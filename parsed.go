@@ -0,0 +1,136 @@
+package qstr
+
+import (
+	"hash/fnv"
+	"html/template"
+)
+
+// ParsedQStr is an immutable, preprocessed QStr. The segments, stripped
+// text, visible length, and hashes are computed once by Parse, so a
+// ParsedQStr is cheap to pass around caches and request handlers, and it
+// offers every output format of QStr as a method. It is safe for concurrent
+// use.
+type ParsedQStr struct {
+	raw        QStr
+	segments   []Segment
+	stripped   string
+	visibleLen int
+	hash       uint64
+	keyHash    uint64
+}
+
+// Parse returns the ParsedQStr for s.
+func Parse(s QStr) *ParsedQStr {
+	segments := DarkPlaces.Tokenize(s)
+	raw, stripped, key := Store(s)
+	return &ParsedQStr{
+		raw:        s,
+		segments:   segments,
+		stripped:   stripped,
+		visibleLen: visibleLen(segments),
+		hash:       hashString(raw),
+		keyHash:    hashString(key),
+	}
+}
+
+// Store returns the three forms of s that player databases persist: the raw
+// value with its color codes, the stripped text for display and search, and
+// the normalized key used to match players regardless of colors, case, glyph
+// choice, and invisible characters. The color codes are parsed only once.
+func Store(s QStr) (raw, stripped, key string) {
+	stripped = s.Stripped()
+	return string(s), stripped, strippedKey(stripped)
+}
+
+// QStr returns the original value.
+func (p *ParsedQStr) QStr() QStr {
+	return p.raw
+}
+
+// Raw returns the original value, color codes and all.
+func (p *ParsedQStr) Raw() string {
+	return string(p.raw)
+}
+
+// Segments returns a copy of the segments of the value.
+func (p *ParsedQStr) Segments() []Segment {
+	return append([]Segment(nil), p.segments...)
+}
+
+// Stripped returns the value without its color codes.
+func (p *ParsedQStr) Stripped() string {
+	return p.stripped
+}
+
+// VisibleLen returns the number of visible characters, as QStr.VisibleLen
+// does.
+func (p *ParsedQStr) VisibleLen() int {
+	return p.visibleLen
+}
+
+// Hash returns a 64-bit FNV-1a hash of the raw value.
+func (p *ParsedQStr) Hash() uint64 {
+	return p.hash
+}
+
+// KeyHash returns a 64-bit FNV-1a hash of the value's normalized text, which
+// ignores colors, case, glyph choice, and invisible characters. Values that
+// identify the same player share a key hash.
+func (p *ParsedQStr) KeyHash() uint64 {
+	return p.keyHash
+}
+
+// HTML returns the HTML representation of the value, as QStr.HTML does.
+func (p *ParsedQStr) HTML(opts ...Option) template.HTML {
+	return NewRenderer(opts...).HTML(p.raw)
+}
+
+// ANSI returns the value with ANSI escape sequences, as QStr.ANSI does.
+func (p *ParsedQStr) ANSI(opts ...Option) string {
+	return NewRenderer(opts...).ANSI(p.raw)
+}
+
+// IRC returns the value with mIRC color codes, as QStr.IRC does.
+func (p *ParsedQStr) IRC() string {
+	return p.raw.IRC()
+}
+
+// BBCode returns the value with BBCode color tags, as QStr.BBCode does.
+func (p *ParsedQStr) BBCode(opts ...Option) string {
+	return NewRenderer(opts...).BBCode(p.raw)
+}
+
+// Markdown returns the stripped value with its Markdown metacharacters
+// escaped, as QStr.Markdown does.
+func (p *ParsedQStr) Markdown() string {
+	return p.raw.Markdown()
+}
+
+// Pango returns the value as Pango markup, as QStr.Pango does.
+func (p *ParsedQStr) Pango(opts ...Option) string {
+	return NewRenderer(opts...).Pango(p.raw)
+}
+
+// DiscordANSI returns the value with the ANSI SGR sequences that Discord
+// renders in code blocks, as QStr.DiscordANSI does.
+func (p *ParsedQStr) DiscordANSI(opts ...Option) string {
+	return NewRenderer(opts...).DiscordANSI(p.raw)
+}
+
+// RTF returns the value as an RTF document, as QStr.RTF does.
+func (p *ParsedQStr) RTF(opts ...Option) string {
+	return NewRenderer(opts...).RTF(p.raw)
+}
+
+// SectionCodes returns the value with Minecraft-style §N codes, as
+// QStr.SectionCodes does.
+func (p *ParsedQStr) SectionCodes(opts ...Option) string {
+	return p.raw.SectionCodes(opts...)
+}
+
+// hashString returns the 64-bit FNV-1a hash of s
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
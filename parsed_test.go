@@ -0,0 +1,101 @@
+package qstr
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	nick := QStr("^x444Anti^5body")
+	p := Parse(nick)
+
+	if p.QStr() != nick || p.Raw() != string(nick) {
+		t.Errorf("Incorrect raw value. Expected: %v, Got: %v.", nick, p.Raw())
+	}
+	if p.Stripped() != "Antibody" {
+		t.Errorf("Incorrect stripped value. Expected: %v, Got: %v.", "Antibody", p.Stripped())
+	}
+	if p.VisibleLen() != 8 {
+		t.Errorf("Incorrect visible length. Expected: %v, Got: %v.", 8, p.VisibleLen())
+	}
+	if !reflect.DeepEqual(p.Segments(), nick.Tokenize()) {
+		t.Errorf("Incorrect segments. Expected: %+v, Got: %+v.", nick.Tokenize(), p.Segments())
+	}
+	if p.HTML() != nick.HTML() {
+		t.Errorf("Incorrect HTML value returned. Expected: %v, Got: %v.", nick.HTML(), p.HTML())
+	}
+
+	var outputList = []struct {
+		Format   string
+		Received string
+		Expected string
+	}{
+		{"ANSI", p.ANSI(), nick.ANSI()},
+		{"IRC", p.IRC(), nick.IRC()},
+		{"BBCode", p.BBCode(), nick.BBCode()},
+		{"Markdown", p.Markdown(), nick.Markdown()},
+		{"Pango", p.Pango(), nick.Pango()},
+		{"DiscordANSI", p.DiscordANSI(), nick.DiscordANSI()},
+		{"RTF", p.RTF(), nick.RTF()},
+		{"SectionCodes", p.SectionCodes(), nick.SectionCodes()},
+	}
+	for _, v := range outputList {
+		if v.Received != v.Expected {
+			t.Errorf("Incorrect %v value returned. Expected: %q, Got: %q.", v.Format, v.Expected, v.Received)
+		}
+	}
+
+	other := Parse("^1ANTIBODY")
+	if other.Hash() == p.Hash() {
+		t.Errorf("Incorrect hash. Expected hashes of %v and %v to differ.", other.Raw(), p.Raw())
+	}
+	if other.KeyHash() != p.KeyHash() {
+		t.Errorf("Incorrect key hash. Expected: %v, Got: %v.", p.KeyHash(), other.KeyHash())
+	}
+
+	// the segments handed out can't alter the parsed value
+	p.Segments()[0].Text = "changed"
+	if p.Stripped() != "Antibody" || p.Segments()[0].Text != "Anti" {
+		t.Errorf("Incorrect segments after modification. Expected: %v, Got: %v.", "Anti", p.Segments()[0].Text)
+	}
+}
+
+func TestStore(t *testing.T) {
+	var storeList = []struct {
+		Input    QStr
+		Stripped string
+		Key      string
+	}{
+		{"^x444Anti^5body", "Antibody", "antibody"},
+		{"  ^1ANTI\u200bbody^7 ", "  ANTI\u200bbody ", "antibody"},
+		{"^1\ue0c1\ue0ee\ue0f4\ue0e9", "\ue0c1\ue0ee\ue0f4\ue0e9", "anti"},
+		{"", "", ""},
+	}
+
+	for _, v := range storeList {
+		raw, stripped, key := Store(v.Input)
+		if raw != string(v.Input) || stripped != v.Stripped || key != v.Key {
+			t.Errorf("Incorrect storage forms of %q. Expected: %q, %q, %q, Got: %q, %q, %q.", v.Input, v.Input, v.Stripped, v.Key, raw, stripped, key)
+		}
+		if key != normalizedKey(v.Input) {
+			t.Errorf("Incorrect key of %q. Expected: %q, Got: %q.", v.Input, normalizedKey(v.Input), key)
+		}
+	}
+}
+
+func TestParseConcurrent(t *testing.T) {
+	p := Parse("^1Anti^2body")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.HTML()
+			p.Segments()
+			p.VisibleLen()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,344 @@
+package qstr
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Stage is a named step of a Pipeline.
+type Stage struct {
+	Name  string
+	Apply Transform
+
+	// Lossless marks a stage that must not alter the visible text. The
+	// pipeline verifies its output and discards any result that does.
+	Lossless bool
+
+	// segments, if set, applies the stage to tokenized segments, reporting
+	// whether it changed them, so that runs of such stages share a single
+	// tokenizer pass
+	segments func([]Segment) ([]Segment, bool)
+}
+
+// StageMetrics holds the running totals for one stage of a Pipeline.
+type StageMetrics struct {
+	Name string
+
+	// Calls is the number of values the stage has processed
+	Calls int64
+
+	// Changed is the number of values the stage altered
+	Changed int64
+
+	// Violations is the number of results of a lossless stage that were
+	// discarded because they altered the visible text
+	Violations int64
+
+	// Duration is the total time spent in the stage
+	Duration time.Duration
+}
+
+// Pipeline runs incoming values through an ordered list of stages, such as
+// decoding glyphs, censoring words, and capping colors, keeping metrics for
+// each stage. Consecutive stages created by this package, such as
+// DecodeStage and TruncateStage, are fused: the value is tokenized once,
+// each of them rewrites the segments in place, and the result is assembled
+// once, instead of every stage rewriting the whole string. Fused stages
+// never alter the visible text unless that is their purpose, so their
+// output is not verified. It is safe for concurrent use.
+type Pipeline struct {
+	mu      sync.RWMutex
+	stages  []Stage
+	metrics []StageMetrics
+}
+
+// NewPipeline returns a Pipeline running the given stages in order.
+func NewPipeline(stages ...Stage) *Pipeline {
+	p := &Pipeline{}
+	for _, stage := range stages {
+		p.Register(stage)
+	}
+	return p
+}
+
+// Register appends a stage to the end of the pipeline.
+func (p *Pipeline) Register(stage Stage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.stages = append(p.stages, stage)
+	p.metrics = append(p.metrics, StageMetrics{Name: stage.Name})
+}
+
+// Process runs s through every stage and returns the result.
+func (p *Pipeline) Process(s QStr) QStr {
+	p.mu.RLock()
+	stages := p.stages
+	p.mu.RUnlock()
+
+	for i := 0; i < len(stages); {
+		j := i + 1
+		for j < len(stages) && stages[i].segments != nil && stages[j].segments != nil {
+			j++
+		}
+		if j-i > 1 {
+			s = p.processFused(s, stages[i:j], i)
+		} else {
+			s = p.processStage(s, stages[i], i)
+		}
+		i = j
+	}
+	return s
+}
+
+// processStage runs s through stage, the ith of the pipeline.
+func (p *Pipeline) processStage(s QStr, stage Stage, i int) QStr {
+	start := time.Now()
+	out := stage.Apply(s)
+	elapsed := time.Since(start)
+
+	violated := stage.Lossless && Verify(s, out) != nil
+	if violated {
+		out = s
+	}
+	p.record(i, elapsed, out != s, violated)
+	return out
+}
+
+// processFused runs s through stages, which all work on segments and start
+// at the ith of the pipeline, tokenizing and joining s only once.
+func (p *Pipeline) processFused(s QStr, stages []Stage, i int) QStr {
+	segments := DarkPlaces.Tokenize(s)
+	changed := false
+	for k, stage := range stages {
+		start := time.Now()
+		out, c := stage.segments(segments)
+		p.record(i+k, time.Since(start), c, false)
+		segments = out
+		changed = changed || c
+	}
+	if !changed {
+		return s
+	}
+	return joinSegments(segments)
+}
+
+// record adds a call to the metrics of the ith stage.
+func (p *Pipeline) record(i int, elapsed time.Duration, changed, violated bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.metrics[i].Calls++
+	p.metrics[i].Duration += elapsed
+	if violated {
+		p.metrics[i].Violations++
+	}
+	if changed {
+		p.metrics[i].Changed++
+	}
+}
+
+// Metrics returns a snapshot of the metrics of each stage, in order.
+func (p *Pipeline) Metrics() []StageMetrics {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return append([]StageMetrics(nil), p.metrics...)
+}
+
+// DecodeStage returns a stage named "decode" that translates glyphs using key.
+func DecodeStage(key map[rune]rune) Stage {
+	return Stage{Name: "decode", Apply: Decode(key), segments: func(segments []Segment) ([]Segment, bool) {
+		return decodeSegments(segments, key)
+	}}
+}
+
+// CensorStage returns a stage named "censor" that masks each of words in the
+// visible text with asterisks, even when color codes are interleaved in the
+// word. Words are matched whole and without regard to case.
+func CensorStage(words ...string) Stage {
+	pattern := WordsPattern(words...)
+	return Stage{Name: "censor", Apply: Censor(words...), segments: func(segments []Segment) ([]Segment, bool) {
+		return censorSegments(segments, pattern, '*')
+	}}
+}
+
+// CapColorsStage returns a stage named "cap colors" that caps the lightness
+// of every color between floor and ceiling, as CapLightness does. The stage
+// is lossless.
+func CapColorsStage(floor, ceiling float64) Stage {
+	return Stage{Name: "cap colors", Apply: CapColors(floor, ceiling), Lossless: true, segments: func(segments []Segment) ([]Segment, bool) {
+		return capColorSegments(segments, floor, ceiling)
+	}}
+}
+
+// SanitizeStage returns a stage named "sanitize" that handles forbidden
+// characters as QStr.Sanitize does. As a stage can't fail, values holding
+// forbidden characters become empty under RejectForbidden.
+func SanitizeStage(policy ForbiddenPolicy, opts ...SanitizeOption) Stage {
+	c := &sanitizeConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	apply := func(s QStr) QStr {
+		out, _ := s.Sanitize(policy, opts...)
+		return out
+	}
+	return Stage{Name: "sanitize", Apply: apply, segments: func(segments []Segment) ([]Segment, bool) {
+		return sanitizeSegments(segments, c, policy)
+	}}
+}
+
+// NormalizeStage returns a stage named "normalize" that drops redundant
+// color codes, as QStr.Normalize does. The stage is lossless. It works on
+// the value as written rather than on segments, so it is not fused with the
+// stages around it.
+func NormalizeStage() Stage {
+	apply := func(s QStr) QStr {
+		return s.Normalize()
+	}
+	return Stage{Name: "normalize", Apply: apply, Lossless: true}
+}
+
+// QuantizeStage returns a stage named "quantize" that snaps every color to
+// the nearest color of palette, as QStr.Quantize does. The stage is
+// lossless.
+func QuantizeStage(palette []RGBColor) Stage {
+	nearest := quantizer(palette)
+	apply := func(s QStr) QStr {
+		return s.Quantize(palette)
+	}
+	return Stage{Name: "quantize", Apply: apply, Lossless: true, segments: func(segments []Segment) ([]Segment, bool) {
+		if nearest == nil {
+			return segments, false
+		}
+		return mapSegmentColors(segments, nearest)
+	}}
+}
+
+// TruncateStage returns a stage named "truncate" that shortens values to at
+// most n visible characters, as QStr.Truncate does.
+func TruncateStage(n int, ellipsis string) Stage {
+	apply := func(s QStr) QStr {
+		return s.Truncate(n, ellipsis)
+	}
+	return Stage{Name: "truncate", Apply: apply, segments: func(segments []Segment) ([]Segment, bool) {
+		return truncateSegments(segments, n, ellipsis)
+	}}
+}
+
+// decodeSegments translates the glyphs of the text of segments using key.
+func decodeSegments(segments []Segment, key map[rune]rune) ([]Segment, bool) {
+	changed := false
+	for i, seg := range segments {
+		found := false
+		for _, c := range seg.Text {
+			if _, ok := key[c]; ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+
+		var b strings.Builder
+		b.Grow(len(seg.Text))
+		for _, c := range seg.Text {
+			if v, ok := key[c]; ok {
+				c = v
+			}
+			b.WriteRune(c)
+		}
+		segments[i].Text = b.String()
+		changed = true
+	}
+	return segments, changed
+}
+
+// mapSegmentColors rewrites the code of every colored segment whose color f
+// changes to the ^xNNN code nearest to the new color.
+func mapSegmentColors(segments []Segment, f func(RGBColor) RGBColor) ([]Segment, bool) {
+	changed := false
+	for i, seg := range segments {
+		if seg.Code == "" {
+			continue
+		}
+		if c := f(seg.Color); c != seg.Color {
+			// later stages see the color the code stands for
+			segments[i].Code = HexCode(c)
+			segments[i].Color = segments[i].Code.Color(nil)
+			changed = true
+		}
+	}
+	return segments, changed
+}
+
+// censor replaces every rune of the visible text matched by pattern with
+// mask, keeping the color codes.
+func censor(s QStr, pattern *regexp.Regexp, mask rune) QStr {
+	segments, changed := censorSegments(DarkPlaces.Tokenize(s), pattern, mask)
+	if !changed {
+		return s
+	}
+	return joinSegments(segments)
+}
+
+// censorSegments replaces every rune of the text of segments matched by
+// pattern with mask.
+func censorSegments(segments []Segment, pattern *regexp.Regexp, mask rune) ([]Segment, bool) {
+	matches := pattern.FindAllStringIndex(segmentsText(segments), -1)
+	if len(matches) == 0 {
+		return segments, false
+	}
+
+	offsets := make([]int, 0, 2*len(matches))
+	for _, m := range matches {
+		offsets = append(offsets, m[0], m[1])
+	}
+	segments = splitSegments(segments, offsets)
+
+	pos := 0
+	for i := range segments {
+		for len(matches) > 0 && matches[0][1] <= pos {
+			matches = matches[1:]
+		}
+		n := len(segments[i].Text)
+		if len(matches) > 0 && matches[0][0] <= pos {
+			segments[i].Text = strings.Repeat(string(mask), graphemeCount(segments[i].Text))
+		}
+		pos += n
+	}
+	return segments, true
+}
+
+// capColors caps the lightness of every color in s between floor and
+// ceiling. Codes whose color changes are rewritten as ^xNNN codes.
+func capColors(s QStr, floor, ceiling float64) QStr {
+	segments, changed := capColorSegments(DarkPlaces.Tokenize(s), floor, ceiling)
+	if !changed {
+		return s
+	}
+	return joinSegments(segments)
+}
+
+// capColorSegments caps the lightness of the color of every segment between
+// floor and ceiling. Segments whose color changes get the ^xNNN code nearest
+// to the new color, and the color that code stands for.
+func capColorSegments(segments []Segment, floor, ceiling float64) ([]Segment, bool) {
+	changed := false
+	for i, seg := range segments {
+		if seg.Code == "" {
+			continue
+		}
+		c := seg.Color.CapLightness(floor, ceiling)
+		if code := HexCode(c); c != seg.Color && code != seg.Code {
+			segments[i].Code = code
+			segments[i].Color = code.Color(nil)
+			changed = true
+		}
+	}
+	return segments, changed
+}
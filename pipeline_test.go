@@ -0,0 +1,127 @@
+package qstr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPipeline(t *testing.T) {
+	p := NewPipeline(
+		DecodeStage(XonoticDecodeKey),
+		CensorStage("noob"),
+	)
+	p.Register(CapColorsStage(0.5, 1.0))
+
+	var pipelineList = []struct {
+		Input    QStr
+		Expected QStr
+	}{
+		{"^1you no^2ob", "^1you **^2**"},
+		{"^x000\ue04eOOB", "^x888****"},
+		{"^7gg", "^7gg"},
+	}
+
+	for _, v := range pipelineList {
+		received := p.Process(v.Input)
+		if received != v.Expected {
+			t.Errorf("Incorrect pipeline result for %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+
+	expected := []StageMetrics{
+		{Name: "decode", Calls: 3, Changed: 1},
+		{Name: "censor", Calls: 3, Changed: 2},
+		{Name: "cap colors", Calls: 3, Changed: 1},
+	}
+	for i, m := range p.Metrics() {
+		m.Duration = 0
+		if m != expected[i] {
+			t.Errorf("Incorrect metrics for stage %v. Expected: %+v, Got: %+v.", m.Name, expected[i], m)
+		}
+	}
+}
+
+func TestPipelineLossless(t *testing.T) {
+	p := NewPipeline(Stage{Name: "upper", Apply: func(s QStr) QStr {
+		return QStr(strings.ToUpper(string(s)))
+	}, Lossless: true})
+
+	nick := QStr("^1Anti^2body")
+	if received := p.Process(nick); received != nick {
+		t.Errorf("Incorrect pipeline result for %v. Expected: %v, Got: %v.", nick, nick, received)
+	}
+
+	expected := StageMetrics{Name: "upper", Calls: 1, Violations: 1}
+	m := p.Metrics()[0]
+	m.Duration = 0
+	if m != expected {
+		t.Errorf("Incorrect metrics for stage %v. Expected: %+v, Got: %+v.", m.Name, expected, m)
+	}
+}
+
+func TestPipelineFused(t *testing.T) {
+	palette := []RGBColor{{1, 0, 0}, {0, 1, 0}, {1, 1, 1}}
+	stages := []Stage{
+		DecodeStage(XonoticDecodeKey),
+		SanitizeStage(StripForbidden, ForbidZeroWidth()),
+		NormalizeStage(),
+		CapColorsStage(0.5, 1.0),
+		QuantizeStage(palette),
+		TruncateStage(8, "@"),
+	}
+	p := NewPipeline(stages...)
+
+	var fusedList = []struct {
+		Input    QStr
+		Expected QStr
+	}{
+		{"^1An\u200bti^1body", "^1Antibody"},
+		{"^x000\ue04eOOB^2^3 rules all", "^xFFFNOOB^x0F0 ru@"},
+		{"^^1plain\x00", "^^1plain"},
+		{"", ""},
+	}
+
+	for _, v := range fusedList {
+		received := p.Process(v.Input)
+		if received != v.Expected {
+			t.Errorf("Incorrect pipeline result for %q. Expected: %q, Got: %q.", v.Input, v.Expected, received)
+		}
+
+		// the fused stages give the same result as the stages applied one
+		// after the other
+		chained := v.Input
+		for _, stage := range stages {
+			chained = stage.Apply(chained)
+		}
+		if received != chained {
+			t.Errorf("Incorrect fused result for %q. Expected: %q, Got: %q.", v.Input, chained, received)
+		}
+	}
+
+	m := p.Metrics()[1]
+	m.Duration = 0
+	if expected := (StageMetrics{Name: "sanitize", Calls: 4, Changed: 2}); m != expected {
+		t.Errorf("Incorrect metrics for stage %v. Expected: %+v, Got: %+v.", m.Name, expected, m)
+	}
+}
+
+func TestPipelineNormalize(t *testing.T) {
+	p := NewPipeline(DecodeStage(XonoticDecodeKey), NormalizeStage(), CapColorsStage(0.0, 1.0))
+
+	var normalizeList = []QStr{"^7Anti^7body", "^1a^7", "^7^1a", "^1^2^3", "^x4AFa^x4afb"}
+	for _, v := range normalizeList {
+		if received, expected := p.Process(v), v.Normalize(); received != expected {
+			t.Errorf("Incorrect pipeline result for %q. Expected: %q, Got: %q.", v, expected, received)
+		}
+	}
+}
+
+func TestSanitizeStageReject(t *testing.T) {
+	p := NewPipeline(SanitizeStage(RejectForbidden), TruncateStage(16, ""))
+	if received := p.Process("^1bad\x00"); received != "" {
+		t.Errorf("Incorrect pipeline result for a rejected value. Expected: %q, Got: %q.", "", received)
+	}
+	if received := p.Process("^1good"); received != "^1good" {
+		t.Errorf("Incorrect pipeline result for a clean value. Expected: %q, Got: %q.", "^1good", received)
+	}
+}
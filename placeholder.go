@@ -0,0 +1,107 @@
+package qstr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrBadPlaceholder is returned by FromPlaceholders when the text holds a
+// malformed placeholder.
+var ErrBadPlaceholder = errors.New("qstr: malformed placeholder")
+
+// Placeholders returns s in an editable plain-text form in which each basic
+// color code is written as a placeholder holding its color, such as
+// {c:ff0000}, each hex code as a placeholder holding its digits, such as
+// {x:4af}, and a return to the default color as {/c}. Escaped carets are
+// written as plain carets and literal opening braces are doubled. The result
+// can be turned back into a QStr with FromPlaceholders. Options may be given
+// to take the colors of the basic codes from another palette; see
+// WithPalette.
+func (s *QStr) Placeholders(opts ...Option) string {
+	palette := NewRenderer(opts...).theme.Palette
+	var b strings.Builder
+	var code Code
+	for _, seg := range DarkPlaces.Tokenize(*s) {
+		if seg.Code != code {
+			switch {
+			case seg.Code == "":
+				b.WriteString("{/c}")
+			case seg.Code.IsHex():
+				b.WriteString("{x:" + string(seg.Code[2:]) + "}")
+			default:
+				c := palette.color(seg)
+				b.WriteString("{c:" + c.Hex()[1:] + "}")
+			}
+			code = seg.Code
+		}
+		b.WriteString(strings.ReplaceAll(seg.Text, "{", "{{"))
+	}
+	return b.String()
+}
+
+// FromPlaceholders parses text in the form produced by Placeholders. Carets
+// in the text are escaped where they would otherwise start a code. A {x:NNN}
+// placeholder becomes the ^xNNN code, while a {c:rrggbb} color of the
+// palette becomes its ^N code and any other color the nearest ^xNNN code.
+// Values produced by Placeholders thus come back with their colors and hex
+// codes, but a color shared by several basic codes, such as the gray of ^0
+// and ^9, comes back as the first of them. Options may be given to match
+// colors against another palette; see WithPalette.
+func FromPlaceholders(text string, opts ...Option) (QStr, error) {
+	palette := NewRenderer(opts...).theme.Palette
+	var b strings.Builder
+	// literal text is escaped as a whole once it is known whether a code
+	// follows, so that its carets can't form codes
+	var literal strings.Builder
+	code := func(code string) {
+		b.WriteString(DarkPlaces.escape(literal.String(), true))
+		literal.Reset()
+		b.WriteString(code)
+	}
+	for i := 0; i < len(text); {
+		if text[i] != '{' {
+			literal.WriteByte(text[i])
+			i++
+			continue
+		}
+
+		if strings.HasPrefix(text[i:], "{{") {
+			literal.WriteByte('{')
+			i += 2
+			continue
+		}
+
+		end := strings.IndexByte(text[i:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("%w at offset %d", ErrBadPlaceholder, i)
+		}
+		placeholder := text[i : i+end+1]
+		i += end + 1
+
+		if placeholder == "{/c}" {
+			code(resetCode)
+			continue
+		}
+
+		if strings.HasPrefix(placeholder, "{x:") {
+			c, err := ParseCode("^x" + placeholder[len("{x:"):len(placeholder)-1])
+			if err != nil {
+				return "", fmt.Errorf("%w: %s", ErrBadPlaceholder, placeholder)
+			}
+			code(string(c))
+			continue
+		}
+
+		var r, g, bl int
+		if len(placeholder) != len("{c:rrggbb}") || !strings.HasPrefix(placeholder, "{c:") {
+			return "", fmt.Errorf("%w: %s", ErrBadPlaceholder, placeholder)
+		}
+		if _, err := fmt.Sscanf(placeholder, "{c:%02x%02x%02x}", &r, &g, &bl); err != nil {
+			return "", fmt.Errorf("%w: %s", ErrBadPlaceholder, placeholder)
+		}
+		code(string(palette.code(NewRGBColorFrom255(float64(r), float64(g), float64(bl)))))
+	}
+	b.WriteString(DarkPlaces.escape(literal.String(), false))
+	return QStr(b.String()), nil
+}
@@ -0,0 +1,74 @@
+package qstr
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPlaceholders(t *testing.T) {
+	var placeholderList = []struct {
+		Input    QStr
+		Expected string
+	}{
+		{"^1Anti^7body", "{c:ff0000}Anti{c:ffffff}body"},
+		{"plain^x444{x}", "plain{x:444}{{x}"},
+		{"^x4AFAnti^2body", "{x:4AF}Anti{c:33ff00}body"},
+		{"^xF00Anti^1body", "{x:F00}Anti{c:ff0000}body"},
+		{"^^1Anti^1^", "^1Anti{c:ff0000}^"},
+	}
+
+	for _, v := range placeholderList {
+		received := v.Input.Placeholders()
+		if received != v.Expected {
+			t.Errorf("Incorrect placeholders for %q. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+
+		back, err := FromPlaceholders(received)
+		if err != nil || back != v.Input {
+			t.Errorf("Incorrect round trip of %q. Expected: %q, Got: %q (%v).", v.Input, v.Input, back, err)
+		}
+	}
+
+	// codes sharing a color come back as the first of them
+	gray := QStr("^9gray^0x")
+	received := gray.Placeholders()
+	if expected := "{c:808080}gray{c:808080}x"; received != expected {
+		t.Errorf("Incorrect placeholders for %q. Expected: %v, Got: %v.", gray, expected, received)
+	}
+	if back, err := FromPlaceholders(received); err != nil || back != "^0gray^0x" {
+		t.Errorf("Incorrect round trip of %q. Expected: %q, Got: %q (%v).", gray, "^0gray^0x", back, err)
+	}
+
+	nick := QStr("^2Anti^7body")
+	received = nick.Placeholders(WithPalette(Quake3Palette))
+	if expected := "{c:00ff00}Anti{c:ffffff}body"; received != expected {
+		t.Errorf("Incorrect placeholders for %q with the Quake 3 palette. Expected: %v, Got: %v.", nick, expected, received)
+	}
+	if back, err := FromPlaceholders(received, WithPalette(Quake3Palette)); err != nil || back != nick {
+		t.Errorf("Incorrect round trip of %q with the Quake 3 palette. Expected: %q, Got: %q (%v).", nick, nick, back, err)
+	}
+}
+
+func TestFromPlaceholders(t *testing.T) {
+	var placeholderList = []struct {
+		Input    string
+		Expected QStr
+		Err      error
+	}{
+		{"{c:ff0000}Anti{/c}body", "^1Anti^7body", nil},
+		{"{c:4a4a4a}Anti", "^x444Anti", nil},
+		{"{x:4af}Anti{x:F00}body", "^x4afAnti^xF00body", nil},
+		{"{x:4ag}Anti", "", ErrBadPlaceholder},
+		{"{x:}Anti", "", ErrBadPlaceholder},
+		{"{c:red}Anti", "", ErrBadPlaceholder},
+		{"{c:ff0000", "", ErrBadPlaceholder},
+		{"{{c:ff0000}}", "{c:ff0000}}", nil},
+	}
+
+	for _, v := range placeholderList {
+		received, err := FromPlaceholders(v.Input)
+		if received != v.Expected || !errors.Is(err, v.Err) {
+			t.Errorf("Incorrect parse of %v. Expected: %v (%v), Got: %v (%v).", v.Input, v.Expected, v.Err, received, err)
+		}
+	}
+}
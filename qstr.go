@@ -2,11 +2,10 @@ package qstr
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
-	"html"
 	"html/template"
 	"math"
-	"regexp"
 	"strconv"
 	"strings"
 )
@@ -25,7 +24,32 @@ func NewRGBColorFrom255(r, g, b float64) RGBColor {
 	return RGBColor{r, g, b}
 }
 
-// HexToRGB converts a sequence of three hexadecimal characters into an RGBColor
+// ErrInvalidHex is returned by ParseHex for text that is not a hexadecimal
+// color.
+var ErrInvalidHex = errors.New("qstr: invalid hex color")
+
+// ParseHex parses a hexadecimal color of the form RGB or RRGGBB, optionally
+// preceded by a #.
+func ParseHex(s string) (RGBColor, error) {
+	digits := strings.TrimPrefix(s, "#")
+	if len(digits) == 3 {
+		digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+	}
+	if len(digits) != 6 {
+		return RGBColor{}, fmt.Errorf("%w: %q", ErrInvalidHex, s)
+	}
+	v, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return RGBColor{}, fmt.Errorf("%w: %q", ErrInvalidHex, s)
+	}
+	return NewRGBColorFrom255(float64(v>>16), float64(v>>8&0xff), float64(v&0xff)), nil
+}
+
+// HexToRGB converts a sequence of three hexadecimal characters into an RGBColor.
+// Invalid characters are silently read as zero.
+//
+// Deprecated: Use ParseHex, which reports invalid input and also accepts
+// six-digit colors.
 func HexToRGB(r string, g string, b string) (c RGBColor) {
 
 	red, _ := strconv.ParseInt(fmt.Sprintf("%s%s", r, r), 16, 0)
@@ -154,14 +178,70 @@ func (c *HSLColor) RGB() RGBColor {
 	}
 }
 
-// color codes of the form ^N
-var decColors = regexp.MustCompile(`\^(\d)`)
+// findCodes returns the locations of the color codes in raw, skipping ^^
+// escapes.
+func findCodes(raw string) [][]int {
+	return locateCodes(raw, false)
+}
 
-// color codes of the form ^xNNN
-var hexColors = regexp.MustCompile(`\^x([\dA-Fa-f])([\dA-Fa-f])([\dA-Fa-f])`)
+// findCodesAndEscapes returns the locations of the color codes and of the ^^
+// escapes in raw.
+func findCodesAndEscapes(raw string) [][]int {
+	return locateCodes(raw, true)
+}
+
+// locateCodes returns the locations of the color codes in raw, along with
+// those of the ^^ escapes if escapes is true. An escape is always taken as a
+// unit, so the caret it stands for never starts a code.
+func locateCodes(raw string, escapes bool) [][]int {
+	var locs [][]int
+	for i := 0; i < len(raw); {
+		j := strings.IndexByte(raw[i:], '^')
+		if j < 0 {
+			break
+		}
+		i += j
+		if i+1 < len(raw) && raw[i+1] == '^' {
+			if escapes {
+				locs = append(locs, []int{i, i + 2})
+			}
+			i += 2
+			continue
+		}
+		if n := basicCodeLen(raw[i:]); n > 0 {
+			locs = append(locs, []int{i, i + n})
+			i += n
+			continue
+		}
+		i++
+	}
+	return locs
+}
+
+// replaceCodes returns raw with each color code replaced by the result of f.
+// Escapes are left as they are.
+func replaceCodes(raw string, f func(code string) string) string {
+	locs := findCodes(raw)
+	if len(locs) == 0 {
+		return raw
+	}
+	var b strings.Builder
+	b.Grow(len(raw))
+	prev := 0
+	for _, loc := range locs {
+		b.WriteString(raw[prev:loc[0]])
+		b.WriteString(f(raw[loc[0]:loc[1]]))
+		prev = loc[1]
+	}
+	b.WriteString(raw[prev:])
+	return b.String()
+}
 
-// either of the above forms of color codes
-var allColors = regexp.MustCompile(`\^(\d|x[\dA-Fa-f]{3})`)
+// unescape returns text, which holds no color codes, with each ^^ escape
+// replaced by the caret it stands for.
+func unescape(text string) string {
+	return strings.ReplaceAll(text, "^^", "^")
+}
 
 // Type QStr is a Quake-style string with optional embedded color codes within
 // it. The color codes can take a basic form of ^N, where N is in 0..9. These
@@ -170,57 +250,75 @@ var allColors = regexp.MustCompile(`\^(\d|x[\dA-Fa-f]{3})`)
 // colors with greater precision.
 type QStr string
 
-// Stripped removes all of the color codes from string
-func (s *QStr) Stripped() string {
-	return allColors.ReplaceAllString(string(*s), "")
+// Raw returns the QStr exactly as it was received, color codes and all.
+func (s *QStr) Raw() string {
+	return string(*s)
 }
 
-// HTML returns the HTML representation of the QStr. Color codes are converted
-// into nested <span> elements with the appropriate color attached as inline
-// CSS.
-func (s *QStr) HTML() template.HTML {
-	// color representation by key for the "^n" format, where n is 0-9
-	var decimalSpans = map[string]string{
-		"^0": "<span style='color:rgb(128,128,128)'>",
-		"^1": "<span style='color:rgb(255,0,0)'>",
-		"^2": "<span style='color:rgb(51,255,0)'>",
-		"^3": "<span style='color:rgb(255,255,0)'>",
-		"^4": "<span style='color:rgb(51,102,255)'>",
-		"^5": "<span style='color:rgb(51,255,255)'>",
-		"^6": "<span style='color:rgb(255,51,102)'>",
-		"^7": "<span style='color:rgb(255,255,255)'>",
-		"^8": "<span style='color:rgb(153,153,153)'>",
-		"^9": "<span style='color:rgb(128,128,128)'>",
+// Stripped removes all of the color codes from string. Each ^^ escape is
+// replaced by the literal caret it stands for. A string without any carets
+// is returned as it is, without allocating.
+func (s *QStr) Stripped() string {
+	raw := string(*s)
+	if strings.IndexByte(raw, '^') < 0 {
+		return raw
 	}
+	var b strings.Builder
+	b.Grow(len(raw))
+	stripRuns(raw, func(text string) {
+		b.WriteString(text)
+	})
+	return b.String()
+}
 
-	// cast once to the string representation 'r'
-	r := string(*s)
-
-	// remove HTMl special characters
-	r = html.EscapeString(r)
-
-	// substitute matches of the form ^n, with n in 0..9
-	matchedDecStrings := decColors.FindAllStringSubmatch(r, -1)
-	for _, v := range matchedDecStrings {
-		r = strings.Replace(r, v[0], decimalSpans[v[0]], 1)
-	}
+// AppendStripped appends the text of s without its color codes, as returned
+// by Stripped, to dst and returns the extended buffer. It lets callers
+// stripping many strings reuse a single buffer.
+func (s *QStr) AppendStripped(dst []byte) []byte {
+	stripRuns(string(*s), func(text string) {
+		dst = append(dst, text...)
+	})
+	return dst
+}
 
-	// substitute matches of the form ^xrgb
-	// with r, g, and b being hexadecimal digits
-	// also cap the lightness to be in the given range
-	matchedHexStrings := hexColors.FindAllStringSubmatch(r, -1)
-	for _, v := range matchedHexStrings {
-		c := HexToRGB(v[1], v[2], v[3])
-		c = c.CapLightness(0.5, 1.0)
-		r = strings.Replace(r, v[0], c.SpanStr(), 1)
+// stripRuns calls emit with each run of visible text in raw, in order. The
+// run before a ^^ escape includes the caret it stands for. It works on
+// strings and byte slices alike, so that neither has to be converted.
+func stripRuns[T string | []byte](raw T, emit func(text T)) {
+	start := 0
+	for i := 0; i < len(raw); {
+		if raw[i] != '^' {
+			i++
+			continue
+		}
+		if i+1 < len(raw) && raw[i+1] == '^' {
+			emit(raw[start : i+1])
+			i += 2
+			start = i
+			continue
+		}
+		if n := colorCodeLen(raw[i+1:]); n > 0 {
+			emit(raw[start:i])
+			i += 1 + n
+			start = i
+			continue
+		}
+		i++
 	}
+	emit(raw[start:])
+}
 
-	// add the appropriate amount of closing spans
-	for i := 0; i < (len(matchedDecStrings) + len(matchedHexStrings)); i++ {
-		r = fmt.Sprintf("%s%s", r, "</span>")
-	}
+// Escape returns text as a QStr showing exactly that text, with every caret
+// doubled into the ^^ escape so that none of them starts a color code.
+func Escape(text string) QStr {
+	return QStr(strings.ReplaceAll(text, "^", "^^"))
+}
 
-	return template.HTML(r)
+// HTML returns the HTML representation of the QStr. Color codes are converted
+// into nested <span> elements with the appropriate color attached as inline
+// CSS. Options may be given to alter the output; see Renderer.
+func (s *QStr) HTML(opts ...Option) template.HTML {
+	return NewRenderer(opts...).HTML(*s)
 }
 
 // Type ColorPart is a piece of a QStr with a contiguous color.
@@ -231,31 +329,8 @@ type ColorPart struct {
 
 // ColorCodeToColorRGB converts a raw color code string into its RGBColor representation
 func ColorCodeToColorRGB(rawColorCode string) RGBColor {
-	if decColors.MatchString(rawColorCode) {
-		switch index := rawColorCode[1:]; index {
-		case "0":
-			return NewRGBColorFrom255(128, 128, 128)
-		case "1":
-			return NewRGBColorFrom255(255, 0, 0)
-		case "2":
-			return NewRGBColorFrom255(51, 255, 0)
-		case "3":
-			return NewRGBColorFrom255(255, 255, 0)
-		case "4":
-			return NewRGBColorFrom255(51, 102, 255)
-		case "5":
-			return NewRGBColorFrom255(51, 255, 255)
-		case "6":
-			return NewRGBColorFrom255(255, 51, 102)
-		case "7":
-			return NewRGBColorFrom255(255, 255, 255)
-		case "8":
-			return NewRGBColorFrom255(153, 153, 153)
-		case "9":
-			return NewRGBColorFrom255(128, 128, 128)
-		}
-	} else if hexColors.MatchString(rawColorCode) {
-		return HexToRGB(string(rawColorCode[2]), string(rawColorCode[3]), string(rawColorCode[4]))
+	if n := basicCodeLen(rawColorCode); n > 0 && n == len(rawColorCode) {
+		return Code(rawColorCode).Color(&XonoticPalette)
 	}
 
 	return RGBColor{128, 128, 128}
@@ -264,7 +339,7 @@ func ColorCodeToColorRGB(rawColorCode string) RGBColor {
 // ColorParts breaks up a QStr into its color-delineated parts
 func (s *QStr) ColorParts() []ColorPart {
 	// find the location of all color codes
-	colorLocs := allColors.FindAllStringIndex(string(*s), -1)
+	colorLocs := findCodes(string(*s))
 
 	// all of the colors included within the QStr
 	colors := make([]RGBColor, 0, len(colorLocs))
@@ -291,7 +366,7 @@ func (s *QStr) ColorParts() []ColorPart {
 		if colorIndex, ok := colorCodeIndices[i]; ok {
 			// did we add characters and need to push a new part to the running list?
 			if addedChars {
-				parts = append(parts, ColorPart{color, nickPart})
+				parts = append(parts, ColorPart{color, unescape(nickPart)})
 				addedChars = false
 				nickPart = ""
 			}
@@ -302,14 +377,15 @@ func (s *QStr) ColorParts() []ColorPart {
 		}
 	}
 	if addedChars {
-		parts = append(parts, ColorPart{color, nickPart})
+		parts = append(parts, ColorPart{color, unescape(nickPart)})
 	}
 
 	return parts
 }
 
 // Decode converts unicode characters within a QStr
-func (s *QStr) Decode(key map[rune]rune) QStr {
+func (s *QStr) Decode(key map[rune]rune, opts ...DecodeOption) QStr {
+	key = withDecodeOptions(key, opts)
 	rs := []rune(string(*s))
 
 	var buffer bytes.Buffer
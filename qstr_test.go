@@ -1,8 +1,11 @@
 package qstr
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"reflect"
+	"regexp"
 	"testing"
 )
 
@@ -108,6 +111,109 @@ func TestStrippedQStr(t *testing.T) {
 	}
 }
 
+func TestCaretEscape(t *testing.T) {
+	var escapeList = []struct {
+		Input    QStr
+		Stripped string
+	}{
+		{"^^1Anti", "^1Anti"},
+		{"^^^1Anti", "^Anti"},
+		{"A^^^^nti^", "A^^nti^"},
+		{"^1^^x444body", "^x444body"},
+	}
+
+	for _, v := range escapeList {
+		if received := v.Input.Stripped(); received != v.Stripped {
+			t.Errorf("Incorrect stripping applied to %v. Expected: %v, Got: %v.", v.Input, v.Stripped, received)
+		}
+	}
+
+	text := "^1 ^^ ^x4af^"
+	escaped := Escape(text)
+	if escaped != "^^1 ^^^^ ^^x4af^^" {
+		t.Errorf("Incorrect escaping of %v. Expected: %v, Got: %v.", text, "^^1 ^^^^ ^^x4af^^", escaped)
+	}
+	if received := escaped.Stripped(); received != text {
+		t.Errorf("Incorrect stripping applied to %v. Expected: %v, Got: %v.", escaped, text, received)
+	}
+}
+
+func TestFindCodes(t *testing.T) {
+	var codesList = []struct {
+		Input    string
+		Expected [][]int
+	}{
+		{"^1Anti^x4afbody", [][]int{{0, 2}, {6, 11}}},
+		{"^^1Anti^^^2", [][]int{{9, 11}}},
+		{"^x4agAnti^", nil},
+		{"^^", nil},
+	}
+
+	for _, v := range codesList {
+		if received := findCodes(v.Input); !reflect.DeepEqual(received, v.Expected) {
+			t.Errorf("Incorrect codes found in %q. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+
+	text := "^1Anti^^2^x4afbody^"
+	expected := "<^1>Anti^^2<^x4af>body^"
+	received := replaceCodes(text, func(code string) string { return "<" + code + ">" })
+	if received != expected {
+		t.Errorf("Incorrect replacement of the codes in %q. Expected: %q, Got: %q.", text, expected, received)
+	}
+}
+
+func TestAppendStripped(t *testing.T) {
+	nicks := []QStr{"^1Anti^x444body", "^^1A^", "", "plain"}
+	buf := []byte("> ")
+	for _, nick := range nicks {
+		received := string(nick.AppendStripped(buf))
+		if expected := "> " + nick.Stripped(); received != expected {
+			t.Errorf("Incorrect stripping appended for %v. Expected: %v, Got: %v.", nick, expected, received)
+		}
+	}
+}
+
+func BenchmarkStripped(b *testing.B) {
+	nicks := []QStr{"^1Anti^7body", "^x444Some^5one", "^2p^3l^4a^5y^6e^7r", "plain"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, nick := range nicks {
+			nick.Stripped()
+		}
+	}
+}
+
+// codesAndEscapes matches color codes and ^^ escapes the way the package
+// once did, as a baseline for the benchmarks
+var codesAndEscapes = regexp.MustCompile(`\^(\^|\d|x[\dA-Fa-f]{3})`)
+
+func BenchmarkStrippedRegexp(b *testing.B) {
+	nicks := []QStr{"^1Anti^7body", "^x444Some^5one", "^2p^3l^4a^5y^6e^7r", "plain"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, nick := range nicks {
+			codesAndEscapes.ReplaceAllStringFunc(string(nick), func(m string) string {
+				if m == "^^" {
+					return "^"
+				}
+				return ""
+			})
+		}
+	}
+}
+
+func BenchmarkAppendStripped(b *testing.B) {
+	nicks := []QStr{"^1Anti^7body", "^x444Some^5one", "^2p^3l^4a^5y^6e^7r", "plain"}
+	var buf []byte
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, nick := range nicks {
+			buf = nick.AppendStripped(buf[:0])
+		}
+	}
+}
+
 func TestHexToRGB(t *testing.T) {
 	var hexRGBList = []struct {
 		R string
@@ -162,3 +268,28 @@ func TestDecode(t *testing.T) {
 		t.Errorf("Incorrect decoding. Expected: %v, Got: %v.", expected, decoded)
 	}
 }
+
+func TestParseHex(t *testing.T) {
+	var hexList = []struct {
+		Input    string
+		Expected RGBColor
+	}{
+		{"4af", NewRGBColorFrom255(0x44, 0xaa, 0xff)},
+		{"#4AF", NewRGBColorFrom255(0x44, 0xaa, 0xff)},
+		{"ff6347", NewRGBColorFrom255(255, 99, 71)},
+		{"#FF6347", NewRGBColorFrom255(255, 99, 71)},
+	}
+
+	for _, v := range hexList {
+		received, err := ParseHex(v.Input)
+		if err != nil || received != v.Expected {
+			t.Errorf("Incorrect color from %q. Expected: %v, Got: %v (%v).", v.Input, v.Expected, received, err)
+		}
+	}
+
+	for _, bad := range []string{"", "4a", "4afz", "ggg", "#ff63", "+ff634", "##4af"} {
+		if _, err := ParseHex(bad); !errors.Is(err, ErrInvalidHex) {
+			t.Errorf("Incorrect error for %q. Expected: %v, Got: %v.", bad, ErrInvalidHex, err)
+		}
+	}
+}
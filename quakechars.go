@@ -0,0 +1,63 @@
+package qstr
+
+import (
+	"strings"
+)
+
+// quakeChars maps the low half of the classic Quake character set to the
+// readable characters closest to its glyphs. Bytes 0x20 to 0x7f are ASCII,
+// while the first 32 hold brackets, gold digits, and decorations. The high
+// half repeats the low half in an alternate, brown color.
+var quakeChars = [32]byte{
+	'.', '_', '_', '_', '_', '.', '_', '_', '_', '_', '\n', '_', '\n', '>', '.', '.',
+	'[', ']', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', '.', '<', '=', '>',
+}
+
+// quakeChar returns the readable character for a byte of the Quake
+// character set, and whether it is drawn in the alternate color.
+func quakeChar(c byte) (byte, bool) {
+	alt := c >= 0x80
+	c &= 0x7f
+	if c < 0x20 {
+		return quakeChars[c], alt
+	}
+	return c, alt
+}
+
+// QuakeCharsetToUTF8 converts text in the character set of Quake and
+// QuakeWorld, as found in server responses, into plain readable text. The
+// alternate-colored characters become their normal counterparts and the
+// decorative glyphs their nearest ASCII characters.
+func QuakeCharsetToUTF8(b []byte) string {
+	out := make([]byte, len(b))
+	for i, c := range b {
+		out[i], _ = quakeChar(c)
+	}
+	return string(out)
+}
+
+// FromQuakeCharset converts text in the character set of Quake and
+// QuakeWorld into a QStr, as QuakeCharsetToUTF8 does, coloring the runs of
+// alternate-colored characters with alt and the text between them with ^7.
+// Carets in the text are escaped. An empty alt leaves the text uncolored.
+func FromQuakeCharset(b []byte, alt Code) QStr {
+	var out strings.Builder
+	colored := false
+	for _, c := range b {
+		r, isAlt := quakeChar(c)
+		if alt != "" && isAlt != colored {
+			if isAlt {
+				out.WriteString(string(alt))
+			} else {
+				out.WriteString(string(resetCode))
+			}
+			colored = isAlt
+		}
+		if r == '^' {
+			out.WriteString("^^")
+		} else {
+			out.WriteByte(r)
+		}
+	}
+	return QStr(out.String())
+}
@@ -0,0 +1,43 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestQuakeCharsetToUTF8(t *testing.T) {
+	var charsetList = []struct {
+		Input    []byte
+		Expected string
+	}{
+		{[]byte("player"), "player"},
+		{[]byte{0xf0, 0xec, 0xe1, 0xf9, 0xe5, 0xf2}, "player"},
+		{[]byte{0x10, 'a', 0x11, 0x12, 0x9b}, "[a]09"},
+		{[]byte{0x9d, 0x9e, 0x9f}, "<=>"},
+		{nil, ""},
+	}
+
+	for _, v := range charsetList {
+		if received := QuakeCharsetToUTF8(v.Input); received != v.Expected {
+			t.Errorf("Incorrect conversion of %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestFromQuakeCharset(t *testing.T) {
+	var charsetList = []struct {
+		Input    []byte
+		Alt      Code
+		Expected QStr
+	}{
+		{[]byte{0xdb, 0xc1, 0xdd, 'p', 'l', 'a', 'y', 'e', 'r'}, "^3", "^3[A]^7player"},
+		{[]byte{'a', 0xe2, 0xe3, 'd'}, "^xC84", "a^xC84bc^7d"},
+		{[]byte{0xdb, 0xc1, 0xdd, 'p'}, "", "[A]p"},
+		{[]byte("^1x"), "^3", "^^1x"},
+	}
+
+	for _, v := range charsetList {
+		if received := FromQuakeCharset(v.Input, v.Alt); received != v.Expected {
+			t.Errorf("Incorrect conversion of %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
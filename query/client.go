@@ -0,0 +1,101 @@
+package query
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net"
+	"os"
+
+	"github.com/antzucaro/qstr/rcon"
+)
+
+// maxPacketSize is the largest UDP payload
+const maxPacketSize = 65535
+
+// StatusRequest returns the packet of a getstatus query. The server echoes
+// challenge in the info string of its response, under the key "challenge".
+func StatusRequest(challenge string) []byte {
+	return rcon.Frame([]byte("getstatus " + challenge))
+}
+
+// InfoRequest returns the packet of a getinfo query. The server echoes
+// challenge in the info string of its response, under the key "challenge".
+func InfoRequest(challenge string) []byte {
+	return rcon.Frame([]byte("getinfo " + challenge))
+}
+
+// Status sends a getstatus query to the server at address, a host and UDP
+// port, and parses its response. The query is abandoned when ctx is done.
+func Status(ctx context.Context, address string) (*ServerStatus, error) {
+	return exchange(ctx, address, StatusRequest, ParseStatus)
+}
+
+// Info sends a getinfo query to the server at address, a host and UDP port,
+// and parses its response. The query is abandoned when ctx is done.
+func Info(ctx context.Context, address string) (*ServerStatus, error) {
+	return exchange(ctx, address, InfoRequest, ParseInfo)
+}
+
+// exchange sends a query with a fresh challenge and waits for the response
+// that echoes it. Packets of other kinds, and responses that don't echo the
+// challenge and so answer some other query or were spoofed, are skipped.
+func exchange(ctx context.Context, address string, request func(string) []byte, parse func([]byte) (*ServerStatus, error)) (*ServerStatus, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	// unblock the read if ctx is canceled before its deadline
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	challenge, err := newChallenge()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(request(challenge)); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// the read deadline is ctx's, and may pass just before ctx
+			// reports it
+			if _, ok := ctx.Deadline(); ok && errors.Is(err, os.ErrDeadlineExceeded) {
+				return nil, context.DeadlineExceeded
+			}
+			return nil, err
+		}
+		status, err := parse(buf[:n])
+		if errors.Is(err, ErrUnexpectedResponse) || errors.Is(err, rcon.ErrNoHeader) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if status.Info["challenge"] != challenge {
+			continue
+		}
+		return status, nil
+	}
+}
+
+// newChallenge returns a random challenge to send with a query
+func newChallenge() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,77 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serve answers one query on conn with a status response echoing its
+// challenge, preceded by a stray response with another challenge
+func serve(t *testing.T, conn net.PacketConn) {
+	buf := make([]byte, 1024)
+	n, addr, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Errorf("Unexpected error reading query: %v.", err)
+		return
+	}
+	query := string(buf[:n])
+	if !strings.HasPrefix(query, "\xff\xff\xff\xffgetstatus ") {
+		t.Errorf("Incorrect query. Got: %q.", query)
+		return
+	}
+	challenge := strings.TrimPrefix(query, "\xff\xff\xff\xffgetstatus ")
+
+	response := func(challenge string) []byte {
+		return []byte("\xff\xff\xff\xffstatusResponse\n\\challenge\\" + challenge +
+			"\\hostname\\^1My ^7Server\\mapname\\afterslime\n" +
+			"25 48 \"^x444Anti^5body\"\n")
+	}
+	conn.WriteTo(response("stale"), addr)
+	conn.WriteTo(response(challenge), addr)
+}
+
+func TestStatusQuery(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("Cannot listen on UDP: %v.", err)
+	}
+	defer conn.Close()
+	go serve(t, conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	status, err := Status(ctx, conn.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("Unexpected error querying status: %v.", err)
+	}
+	if status.Hostname != "^1My ^7Server" || len(status.Players) != 1 || status.Players[0].Name != "^x444Anti^5body" {
+		t.Errorf("Incorrect status. Got: %+v.", status)
+	}
+}
+
+func TestQueryCanceled(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("Cannot listen on UDP: %v.", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := Info(ctx, conn.LocalAddr().String()); err != context.DeadlineExceeded {
+		t.Errorf("Incorrect error for an unanswered query. Expected: %v, Got: %v.", context.DeadlineExceeded, err)
+	}
+}
+
+func TestRequests(t *testing.T) {
+	if received := StatusRequest("abc"); !bytes.Equal(received, []byte("\xff\xff\xff\xffgetstatus abc")) {
+		t.Errorf("Incorrect status request. Got: %q.", received)
+	}
+	if received := InfoRequest("abc"); !bytes.Equal(received, []byte("\xff\xff\xff\xffgetinfo abc")) {
+		t.Errorf("Incorrect info request. Got: %q.", received)
+	}
+}
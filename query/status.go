@@ -0,0 +1,200 @@
+// Package query sends getstatus and getinfo queries to DarkPlaces game
+// servers, such as Xonotic's, and parses their responses. Hostnames and
+// player names are kept as qstr.QStr values so they can be rendered with
+// their colors.
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"html/template"
+	"strconv"
+	"strings"
+
+	"github.com/antzucaro/qstr"
+	"github.com/antzucaro/qstr/rcon"
+)
+
+// ErrUnexpectedResponse is returned when a packet is not the kind of
+// response being parsed.
+var ErrUnexpectedResponse = errors.New("query: unexpected response type")
+
+// Player is an entry of the player list in a status response.
+type Player struct {
+	Name  qstr.QStr
+	Score int
+	Ping  int
+}
+
+// ServerStatus describes a game server as reported in response to a query.
+type ServerStatus struct {
+	Hostname   qstr.QStr
+	Map        string
+	Players    []Player
+	MaxPlayers int
+
+	// Info holds every key of the server's info string, including those
+	// reflected in the fields above
+	Info map[string]string
+}
+
+// ParseStatus parses the response to a getstatus query, which includes the
+// list of players.
+func ParseStatus(packet []byte) (*ServerStatus, error) {
+	lines, err := responseLines(packet, "statusResponse")
+	if err != nil {
+		return nil, err
+	}
+
+	status := newServerStatus(lines[0])
+	for _, l := range lines[1:] {
+		if l == "" {
+			continue
+		}
+		p, err := parsePlayer(l)
+		if err != nil {
+			return nil, err
+		}
+		status.Players = append(status.Players, p)
+	}
+	return status, nil
+}
+
+// ParseInfo parses the response to a getinfo query, which only carries the
+// info string.
+func ParseInfo(packet []byte) (*ServerStatus, error) {
+	lines, err := responseLines(packet, "infoResponse")
+	if err != nil {
+		return nil, err
+	}
+	return newServerStatus(lines[0]), nil
+}
+
+// responseLines unframes packet, checks that its payload is the expected
+// kind of response, and returns the lines following the response type. The
+// first of them is the info string.
+func responseLines(packet []byte, kind string) ([]string, error) {
+	payload, err := rcon.Unframe(packet)
+	if err != nil {
+		return nil, err
+	}
+
+	payload = bytes.TrimRight(payload, "\x00")
+	lines := strings.Split(string(payload), "\n")
+	if lines[0] != kind || len(lines) < 2 {
+		return nil, ErrUnexpectedResponse
+	}
+	return lines[1:], nil
+}
+
+// newServerStatus returns a ServerStatus filled in from an info string of
+// the form \key\value\key\value.
+func newServerStatus(infostring string) *ServerStatus {
+	info := make(map[string]string)
+	fields := strings.Split(strings.TrimPrefix(infostring, "\\"), "\\")
+	for i := 0; i+1 < len(fields); i += 2 {
+		info[fields[i]] = fields[i+1]
+	}
+
+	maxPlayers, _ := strconv.Atoi(info["sv_maxclients"])
+	return &ServerStatus{
+		Hostname:   qstr.QStr(info["hostname"]),
+		Map:        info["mapname"],
+		Players:    []Player{},
+		MaxPlayers: maxPlayers,
+		Info:       info,
+	}
+}
+
+// parsePlayer parses a player line of the form <score> <ping> "<name>". Some
+// games add more numeric fields, such as the team, before the name; they are
+// ignored.
+func parsePlayer(line string) (Player, error) {
+	quote := strings.IndexByte(line, '"')
+	if quote < 0 || !strings.HasSuffix(line, "\"") || quote == len(line)-1 {
+		return Player{}, fmt.Errorf("query: malformed player line %q", line)
+	}
+
+	fields := strings.Fields(line[:quote])
+	if len(fields) < 2 {
+		return Player{}, fmt.Errorf("query: malformed player line %q", line)
+	}
+	score, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return Player{}, fmt.Errorf("query: malformed score in %q", line)
+	}
+	ping, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return Player{}, fmt.Errorf("query: malformed ping in %q", line)
+	}
+
+	return Player{
+		Name:  qstr.QStr(line[quote+1 : len(line)-1]),
+		Score: score,
+		Ping:  ping,
+	}, nil
+}
+
+// jsonPlayer is the JSON representation of a Player
+type jsonPlayer struct {
+	Name         string `json:"name"`
+	NameStripped string `json:"name_stripped"`
+	Score        int    `json:"score"`
+	Ping         int    `json:"ping"`
+}
+
+// jsonStatus is the JSON representation of a ServerStatus
+type jsonStatus struct {
+	Hostname         string            `json:"hostname"`
+	HostnameStripped string            `json:"hostname_stripped"`
+	Map              string            `json:"map"`
+	Players          []jsonPlayer      `json:"players"`
+	MaxPlayers       int               `json:"max_players"`
+	Info             map[string]string `json:"info"`
+}
+
+// MarshalJSON encodes the status with both the raw and the stripped forms of
+// each name.
+func (s *ServerStatus) MarshalJSON() ([]byte, error) {
+	j := jsonStatus{
+		Hostname:         string(s.Hostname),
+		HostnameStripped: s.Hostname.Stripped(),
+		Map:              s.Map,
+		Players:          make([]jsonPlayer, 0, len(s.Players)),
+		MaxPlayers:       s.MaxPlayers,
+		Info:             s.Info,
+	}
+	for _, p := range s.Players {
+		j.Players = append(j.Players, jsonPlayer{
+			Name:         string(p.Name),
+			NameStripped: p.Name.Stripped(),
+			Score:        p.Score,
+			Ping:         p.Ping,
+		})
+	}
+	return json.Marshal(j)
+}
+
+// HTML renders the status as an HTML fragment: the colored hostname and map
+// followed by a table of players. If r is nil, a Renderer with the default
+// options is used.
+func (s *ServerStatus) HTML(r *qstr.Renderer) template.HTML {
+	if r == nil {
+		r = qstr.NewRenderer()
+	}
+
+	var b strings.Builder
+	b.WriteString("<div class=\"qstr-server\">")
+	fmt.Fprintf(&b, "<h3 class=\"qstr-server-name\">%s</h3>", r.HTML(s.Hostname))
+	fmt.Fprintf(&b, "<p class=\"qstr-server-map\">%s (%d/%d)</p>", html.EscapeString(s.Map), len(s.Players), s.MaxPlayers)
+	b.WriteString("<table class=\"qstr-players\"><tr><th>Name</th><th>Score</th><th>Ping</th></tr>")
+	for _, p := range s.Players {
+		fmt.Fprintf(&b, "<tr><td>%s</td><td>%d</td><td>%d</td></tr>", r.HTML(p.Name), p.Score, p.Ping)
+	}
+	b.WriteString("</table></div>")
+
+	return template.HTML(b.String())
+}
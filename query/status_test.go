@@ -0,0 +1,72 @@
+package query
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/antzucaro/qstr"
+)
+
+var statusPacket = []byte("\xff\xff\xff\xffstatusResponse\n" +
+	"\\gamename\\Xonotic\\sv_maxclients\\16\\clients\\2\\mapname\\afterslime\\hostname\\^1My ^7Server\n" +
+	"25 48 \"^x444Anti^5body\"\n" +
+	"-3 120 1 \"player \\\"two\\\"\"\n")
+
+func TestParseStatus(t *testing.T) {
+	status, err := ParseStatus(statusPacket)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing status: %v.", err)
+	}
+
+	if status.Hostname != "^1My ^7Server" || status.Map != "afterslime" || status.MaxPlayers != 16 {
+		t.Errorf("Incorrect server details. Got: %+v.", status)
+	}
+
+	expected := []Player{
+		{Name: "^x444Anti^5body", Score: 25, Ping: 48},
+		{Name: "player \\\"two\\\"", Score: -3, Ping: 120},
+	}
+	if !reflect.DeepEqual(status.Players, expected) {
+		t.Errorf("Incorrect players. Expected: %+v, Got: %+v.", expected, status.Players)
+	}
+}
+
+func TestParseInfo(t *testing.T) {
+	status, err := ParseInfo([]byte("\xff\xff\xff\xffinfoResponse\n\\hostname\\^2Green\\mapname\\solarium"))
+	if err != nil {
+		t.Fatalf("Unexpected error parsing info: %v.", err)
+	}
+	if status.Hostname != "^2Green" || status.Map != "solarium" || len(status.Players) != 0 {
+		t.Errorf("Incorrect server details. Got: %+v.", status)
+	}
+
+	if _, err := ParseInfo(statusPacket); err != ErrUnexpectedResponse {
+		t.Errorf("Incorrect error for a status response. Expected: %v, Got: %v.", ErrUnexpectedResponse, err)
+	}
+}
+
+func TestStatusJSON(t *testing.T) {
+	status, _ := ParseStatus(statusPacket)
+	data, err := json.Marshal(status)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling status: %v.", err)
+	}
+
+	expected := `"players":[{"name":"^x444Anti^5body","name_stripped":"Antibody","score":25,"ping":48}`
+	if !strings.Contains(string(data), expected) {
+		t.Errorf("JSON is missing %v. Got: %s.", expected, data)
+	}
+}
+
+func TestStatusHTML(t *testing.T) {
+	status, _ := ParseStatus(statusPacket)
+	received := string(status.HTML(nil))
+
+	name := qstr.QStr("^x444Anti^5body")
+	expected := "<tr><td>" + string(name.HTML()) + "</td><td>25</td><td>48</td></tr>"
+	if !strings.Contains(received, expected) {
+		t.Errorf("HTML is missing %v. Got: %v.", expected, received)
+	}
+}
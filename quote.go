@@ -0,0 +1,28 @@
+package qstr
+
+import (
+	"errors"
+)
+
+// ErrColored is returned by Unquote for values that hold color codes and so
+// were not produced by Quote.
+var ErrColored = errors.New("qstr: quoted text contains color codes")
+
+// Quote returns plain as a QStr that shows exactly that text, for embedding
+// untrusted text such as chat messages in colored output. Only the carets
+// that would start a color code or an escape are doubled, as is a trailing
+// caret, so that the quoted text can't combine with whatever follows it into
+// a code. Unlike Escape, carets that are already shown literally are left
+// alone.
+func Quote(plain string) QStr {
+	return QStr(DarkPlaces.escape(plain, true))
+}
+
+// Unquote returns the plain text quoted in s, replacing each ^^ escape by
+// the caret it stands for. It returns ErrColored if s holds color codes.
+func Unquote(s QStr) (string, error) {
+	if len(findCodes(string(s))) > 0 {
+		return "", ErrColored
+	}
+	return unescape(string(s)), nil
+}
@@ -0,0 +1,47 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestQuote(t *testing.T) {
+	var quoteList = []struct {
+		Plain    string
+		Expected QStr
+	}{
+		{"hello", "hello"},
+		{"^1red", "^^1red"},
+		{"^x4afblue", "^^x4afblue"},
+		{"^xZZ ^ a^b", "^xZZ ^ a^b"},
+		{"^^", "^^^^"},
+		{"trailing^", "trailing^^"},
+		{"", ""},
+	}
+
+	for _, v := range quoteList {
+		received := Quote(v.Plain)
+		if received != v.Expected {
+			t.Errorf("Incorrect quoting of %v. Expected: %v, Got: %v.", v.Plain, v.Expected, received)
+		}
+		if stripped := received.Stripped(); stripped != v.Plain {
+			t.Errorf("Incorrect stripping applied to %v. Expected: %v, Got: %v.", received, v.Plain, stripped)
+		}
+		if unquoted, err := Unquote(received); err != nil || unquoted != v.Plain {
+			t.Errorf("Incorrect unquoting of %v. Expected: %v, Got: %v (%v).", received, v.Plain, unquoted, err)
+		}
+	}
+}
+
+func TestQuoteInterpolated(t *testing.T) {
+	msg := QStr("^3<") + Quote("^1nick^") + QStr("2> ^7said")
+	expected := "<^1nick^2> said"
+	if received := msg.Stripped(); received != expected {
+		t.Errorf("Incorrect stripping applied to %v. Expected: %v, Got: %v.", msg, expected, received)
+	}
+}
+
+func TestUnquoteColored(t *testing.T) {
+	if _, err := Unquote("^^1a^2b"); err != ErrColored {
+		t.Errorf("Incorrect error. Expected: %v, Got: %v.", ErrColored, err)
+	}
+}
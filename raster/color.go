@@ -0,0 +1,67 @@
+package raster
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"strings"
+
+	"github.com/antzucaro/qstr"
+	"golang.org/x/image/colornames"
+)
+
+// RGBA converts c into an opaque color.RGBA, for use with the image
+// packages.
+func RGBA(c qstr.RGBColor) color.RGBA {
+	return color.RGBA{to255(c.R), to255(c.G), to255(c.B), 0xff}
+}
+
+// FromColor converts any color.Color into a qstr.RGBColor. Translucent
+// colors are converted as if drawn over black.
+func FromColor(c color.Color) qstr.RGBColor {
+	r, g, b, _ := c.RGBA()
+	return qstr.RGBColor{R: float64(r) / 0xffff, G: float64(g) / 0xffff, B: float64(b) / 0xffff}
+}
+
+// Named returns the color with the given SVG 1.1 name, such as
+// "cornflowerblue", as listed in golang.org/x/image/colornames. Names are
+// matched regardless of case.
+func Named(name string) (qstr.RGBColor, bool) {
+	c, ok := colornames.Map[strings.ToLower(name)]
+	if !ok {
+		return qstr.RGBColor{}, false
+	}
+	return FromColor(c), true
+}
+
+// Name returns the SVG 1.1 name of the color perceptually closest to c.
+// Where several names share a color, the first in alphabetical order is
+// returned.
+func Name(c qstr.RGBColor) string {
+	lab := c.Lab()
+
+	best, bestDist := "", math.Inf(1)
+	for _, name := range colornames.Names {
+		named := FromColor(colornames.Map[name])
+		o := named.Lab()
+		d := math.Hypot(math.Hypot(lab.L-o.L, lab.A-o.A), lab.B-o.B)
+		if d < bestDist {
+			best, bestDist = name, d
+		}
+	}
+	return best
+}
+
+// Uniform returns an infinite image of c, for use as a drawing source.
+func Uniform(c qstr.RGBColor) *image.Uniform {
+	return image.NewUniform(RGBA(c))
+}
+
+// Swatch returns a width by height image filled with c, for legends and
+// color pickers.
+func Swatch(c qstr.RGBColor, width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), Uniform(c), image.Point{}, draw.Src)
+	return img
+}
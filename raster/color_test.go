@@ -0,0 +1,63 @@
+package raster
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/antzucaro/qstr"
+)
+
+func TestNamed(t *testing.T) {
+	c, ok := Named("CornflowerBlue")
+	expected := qstr.NewRGBColorFrom255(100, 149, 237)
+	if !ok || RGBA(c) != RGBA(expected) {
+		t.Errorf("Incorrect color for cornflowerblue. Expected: %v, Got: %v (%v).", expected, c, ok)
+	}
+
+	if _, ok := Named("notacolor"); ok {
+		t.Errorf("Incorrect lookup of an unknown name. Expected: false, Got: true.")
+	}
+}
+
+func TestName(t *testing.T) {
+	var nameList = []struct {
+		Input    qstr.RGBColor
+		Expected string
+	}{
+		{qstr.XonoticPalette[1], "red"},
+		{qstr.XonoticPalette[7], "white"},
+		{qstr.RGBColor{R: 0, G: 1, B: 1}, "aqua"},
+		{qstr.NewRGBColorFrom255(101, 148, 236), "cornflowerblue"},
+	}
+
+	for _, v := range nameList {
+		if received := Name(v.Input); received != v.Expected {
+			t.Errorf("Incorrect name of %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestColorConversion(t *testing.T) {
+	c := qstr.NewRGBColorFrom255(68, 170, 255)
+	expected := color.RGBA{68, 170, 255, 0xff}
+	if received := RGBA(c); received != expected {
+		t.Errorf("Incorrect conversion of %v. Expected: %v, Got: %v.", c, expected, received)
+	}
+	if received := RGBA(FromColor(expected)); received != expected {
+		t.Errorf("Incorrect round trip of %v. Expected: %v, Got: %v.", expected, expected, received)
+	}
+}
+
+func TestSwatch(t *testing.T) {
+	img := Swatch(qstr.XonoticPalette[1], 4, 3)
+	if img.Bounds().Dx() != 4 || img.Bounds().Dy() != 3 {
+		t.Errorf("Incorrect swatch size. Expected: 4x3, Got: %v.", img.Bounds().Size())
+	}
+	expected := color.RGBA{0xff, 0, 0, 0xff}
+	if received := img.RGBAAt(3, 2); received != expected {
+		t.Errorf("Incorrect swatch color. Expected: %v, Got: %v.", expected, received)
+	}
+	if received := Uniform(qstr.XonoticPalette[1]).At(100, -5); received != expected {
+		t.Errorf("Incorrect uniform color. Expected: %v, Got: %v.", expected, received)
+	}
+}
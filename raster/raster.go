@@ -0,0 +1,111 @@
+// Package raster draws QStr values into images using a font face, coloring
+// each segment, for generated graphics such as player signature images, and
+// converts colors to and from the image packages and the SVG color names of
+// golang.org/x/image/colornames. It depends on golang.org/x/image.
+package raster
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+
+	"github.com/antzucaro/qstr"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// Option configures the images produced by Render and EncodePNG.
+type Option func(*config)
+
+type config struct {
+	theme      qstr.Theme
+	background color.Color
+	padding    int
+}
+
+// WithTheme sets the theme the colors are adapted to. The default is
+// qstr.DarkTheme.
+func WithTheme(theme qstr.Theme) Option {
+	return func(c *config) {
+		c.theme = theme
+	}
+}
+
+// WithBackground sets the color the image is filled with before drawing.
+// The default is transparent.
+func WithBackground(bg color.Color) Option {
+	return func(c *config) {
+		c.background = bg
+	}
+}
+
+// WithPadding sets the number of pixels left empty around the text.
+func WithPadding(padding int) Option {
+	return func(c *config) {
+		c.padding = padding
+	}
+}
+
+func newConfig(opts []Option) config {
+	c := config{theme: qstr.DarkTheme, background: color.Transparent}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// Draw draws s onto dst using face, starting with the baseline origin at
+// dot, and returns the position following the text. Text without a color is
+// drawn in ^7's color and hex colors are capped to the theme's lightness
+// bounds, as in HTML output.
+func Draw(dst draw.Image, s qstr.QStr, face font.Face, dot fixed.Point26_6, opts ...Option) fixed.Point26_6 {
+	c := newConfig(opts)
+
+	d := &font.Drawer{Dst: dst, Face: face, Dot: dot}
+	for _, seg := range s.Tokenize() {
+		d.Src = image.NewUniform(c.color(seg))
+		d.DrawString(seg.Text)
+	}
+	return d.Dot
+}
+
+// Render returns a new image just large enough to hold s drawn with face,
+// plus any padding.
+func Render(s qstr.QStr, face font.Face, opts ...Option) *image.RGBA {
+	c := newConfig(opts)
+
+	width := font.MeasureString(face, s.Stripped()).Ceil()
+	metrics := face.Metrics()
+	ascent := metrics.Ascent.Ceil()
+	height := ascent + metrics.Descent.Ceil()
+
+	img := image.NewRGBA(image.Rect(0, 0, width+2*c.padding, height+2*c.padding))
+	draw.Draw(img, img.Bounds(), image.NewUniform(c.background), image.Point{}, draw.Src)
+
+	Draw(img, s, face, fixed.P(c.padding, c.padding+ascent), opts...)
+	return img
+}
+
+// EncodePNG renders s as Render does and writes it to w as a PNG.
+func EncodePNG(w io.Writer, s qstr.QStr, face font.Face, opts ...Option) error {
+	return png.Encode(w, Render(s, face, opts...))
+}
+
+// color returns the color to draw seg in
+func (c *config) color(seg qstr.Segment) color.Color {
+	rgb := c.theme.Palette[7]
+	switch {
+	case len(seg.Code) == 2:
+		rgb = c.theme.Palette[seg.Code[1]-'0']
+	case seg.Code != "":
+		rgb = seg.Color.CapLightness(c.theme.MinLightness, c.theme.MaxLightness)
+	}
+	return RGBA(rgb)
+}
+
+// to255 converts a channel in the range [0, 1] to the range [0, 255]
+func to255(v float64) uint8 {
+	return uint8(min(max(v, 0), 1)*255 + 0.5)
+}
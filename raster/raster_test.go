@@ -0,0 +1,52 @@
+package raster
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"golang.org/x/image/font/basicfont"
+)
+
+func TestRender(t *testing.T) {
+	img := Render("^1A^x444b", basicfont.Face7x13, WithPadding(2), WithBackground(color.Black))
+
+	if received := img.Bounds().Dx(); received != 2*7+4 {
+		t.Errorf("Incorrect width. Expected: %v, Got: %v.", 2*7+4, received)
+	}
+	if received := img.Bounds().Dy(); received != 13+4 {
+		t.Errorf("Incorrect height. Expected: %v, Got: %v.", 13+4, received)
+	}
+
+	// every pixel is either the background or one of the two text colors
+	seen := make(map[color.RGBA]bool)
+	for y := 0; y < img.Bounds().Dy(); y++ {
+		for x := 0; x < img.Bounds().Dx(); x++ {
+			seen[img.RGBAAt(x, y)] = true
+		}
+	}
+	for _, c := range []color.RGBA{{0, 0, 0, 0xff}, {0xff, 0, 0, 0xff}, {0x80, 0x80, 0x80, 0xff}} {
+		if !seen[c] {
+			t.Errorf("Incorrect image. Expected color %v to be drawn.", c)
+		}
+	}
+	if len(seen) != 3 {
+		t.Errorf("Incorrect number of colors. Expected: %v, Got: %v.", 3, len(seen))
+	}
+}
+
+func TestEncodePNG(t *testing.T) {
+	var b bytes.Buffer
+	if err := EncodePNG(&b, "^1Antibody", basicfont.Face7x13); err != nil {
+		t.Fatalf("Unexpected error: %v.", err)
+	}
+
+	img, err := png.Decode(&b)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v.", err)
+	}
+	if received := img.Bounds().Dx(); received != 8*7 {
+		t.Errorf("Incorrect width. Expected: %v, Got: %v.", 8*7, received)
+	}
+}
@@ -0,0 +1,109 @@
+package rcon
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// md4 implements the MD4 hash algorithm as described in RFC 1320. DarkPlaces
+// signs secure rcon commands with HMAC-MD4, and MD4 is not part of the
+// standard library.
+type md4 struct {
+	s   [4]uint32
+	x   [64]byte
+	nx  int
+	len uint64
+}
+
+const md4BlockSize = 64
+
+func newMD4() hash.Hash {
+	d := new(md4)
+	d.Reset()
+	return d
+}
+
+func (d *md4) Reset() {
+	d.s = [4]uint32{0x67452301, 0xefcdab89, 0x98badcfe, 0x10325476}
+	d.nx = 0
+	d.len = 0
+}
+
+func (d *md4) Size() int { return 16 }
+
+func (d *md4) BlockSize() int { return md4BlockSize }
+
+func (d *md4) Write(p []byte) (int, error) {
+	n := len(p)
+	d.len += uint64(n)
+	if d.nx > 0 {
+		c := copy(d.x[d.nx:], p)
+		d.nx += c
+		p = p[c:]
+		if d.nx == md4BlockSize {
+			d.block(d.x[:])
+			d.nx = 0
+		}
+	}
+	for len(p) >= md4BlockSize {
+		d.block(p[:md4BlockSize])
+		p = p[md4BlockSize:]
+	}
+	d.nx = copy(d.x[:], p)
+	return n, nil
+}
+
+func (d *md4) Sum(in []byte) []byte {
+	// work on a copy so the caller can keep writing
+	c := *d
+
+	var pad [md4BlockSize + 8]byte
+	pad[0] = 0x80
+	n := 56 - int(c.len%md4BlockSize)
+	if n <= 0 {
+		n += md4BlockSize
+	}
+	binary.LittleEndian.PutUint64(pad[n:], c.len<<3)
+	c.Write(pad[:n+8])
+
+	var out [16]byte
+	for i, v := range c.s {
+		binary.LittleEndian.PutUint32(out[i*4:], v)
+	}
+	return append(in, out[:]...)
+}
+
+var md4Shifts = [3][4]int{{3, 7, 11, 19}, {3, 5, 9, 13}, {3, 9, 11, 15}}
+var md4Round2Order = [16]int{0, 4, 8, 12, 1, 5, 9, 13, 2, 6, 10, 14, 3, 7, 11, 15}
+var md4Round3Order = [16]int{0, 8, 4, 12, 2, 10, 6, 14, 1, 9, 5, 13, 3, 11, 7, 15}
+
+func (d *md4) block(p []byte) {
+	var x [16]uint32
+	for i := range x {
+		x[i] = binary.LittleEndian.Uint32(p[i*4:])
+	}
+
+	a, b, c, e := d.s[0], d.s[1], d.s[2], d.s[3]
+
+	for i := 0; i < 16; i++ {
+		f := (b & c) | (^b & e)
+		a = bits.RotateLeft32(a+f+x[i], md4Shifts[0][i%4])
+		a, b, c, e = e, a, b, c
+	}
+	for i := 0; i < 16; i++ {
+		g := (b & c) | (b & e) | (c & e)
+		a = bits.RotateLeft32(a+g+x[md4Round2Order[i]]+0x5a827999, md4Shifts[1][i%4])
+		a, b, c, e = e, a, b, c
+	}
+	for i := 0; i < 16; i++ {
+		h := b ^ c ^ e
+		a = bits.RotateLeft32(a+h+x[md4Round3Order[i]]+0x6ed9eba1, md4Shifts[2][i%4])
+		a, b, c, e = e, a, b, c
+	}
+
+	d.s[0] += a
+	d.s[1] += b
+	d.s[2] += c
+	d.s[3] += e
+}
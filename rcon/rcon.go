@@ -0,0 +1,130 @@
+// Package rcon frames and unframes the connectionless packets spoken by
+// DarkPlaces game servers, such as Xonotic's, and builds the payloads for
+// plain and secure remote console (rcon) commands. Command output is
+// returned as qstr.QStr lines, color codes intact.
+package rcon
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/antzucaro/qstr"
+)
+
+// Header prefixes every connectionless packet.
+const Header = "\xff\xff\xff\xff"
+
+// ErrNoHeader is returned when a packet does not start with Header.
+var ErrNoHeader = errors.New("rcon: packet is missing the connectionless header")
+
+// ErrNotChallenge is returned when a packet is not a challenge response.
+var ErrNotChallenge = errors.New("rcon: packet is not a challenge response")
+
+// Frame prefixes payload with the connectionless header.
+func Frame(payload []byte) []byte {
+	packet := make([]byte, 0, len(Header)+len(payload))
+	packet = append(packet, Header...)
+	return append(packet, payload...)
+}
+
+// Unframe strips the connectionless header from packet, returning the
+// payload.
+func Unframe(packet []byte) ([]byte, error) {
+	if !bytes.HasPrefix(packet, []byte(Header)) {
+		return nil, ErrNoHeader
+	}
+	return packet[len(Header):], nil
+}
+
+// Command returns the packet for a plain text rcon command, as accepted by
+// servers with rcon_secure set to 0. The password is sent in the clear.
+func Command(password, command string) []byte {
+	return Frame([]byte(fmt.Sprintf("rcon %s %s", password, command)))
+}
+
+// TimeCommand returns the packet for an rcon command signed with the current
+// time, as accepted by servers with rcon_secure set to 1.
+func TimeCommand(password, command string, now time.Time) []byte {
+	t := fmt.Sprintf("%d.%06d", now.Unix(), now.Nanosecond()/1000)
+	return signed(password, "TIME", t, command)
+}
+
+// ChallengeRequest returns the packet asking the server for a challenge,
+// the first step of sending a command to a server with rcon_secure set to 2.
+func ChallengeRequest() []byte {
+	return Frame([]byte("getchallenge"))
+}
+
+// ParseChallenge returns the challenge from the server's response to a
+// ChallengeRequest.
+func ParseChallenge(packet []byte) (string, error) {
+	payload, err := Unframe(packet)
+	if err != nil {
+		return "", err
+	}
+
+	const prefix = "challenge "
+	if !bytes.HasPrefix(payload, []byte(prefix)) {
+		return "", ErrNotChallenge
+	}
+	challenge := payload[len(prefix):]
+
+	// the challenge may be followed by a NUL and extra data
+	if i := bytes.IndexByte(challenge, 0); i >= 0 {
+		challenge = challenge[:i]
+	}
+	return string(challenge), nil
+}
+
+// ChallengeCommand returns the packet for an rcon command signed with a
+// challenge obtained through ParseChallenge, as accepted by servers with
+// rcon_secure set to 2.
+func ChallengeCommand(password, challenge, command string) []byte {
+	return signed(password, "CHALLENGE", challenge, command)
+}
+
+// signed returns the packet for an srcon command, signed with HMAC-MD4
+// over the nonce and the command.
+func signed(password, kind, nonce, command string) []byte {
+	mac := hmac.New(newMD4, []byte(password))
+	mac.Write([]byte(nonce + " " + command))
+
+	var b bytes.Buffer
+	b.WriteString("srcon HMAC-MD4 " + kind + " ")
+	b.Write(mac.Sum(nil))
+	b.WriteString(" " + nonce + " " + command)
+	return Frame(b.Bytes())
+}
+
+// ParseOutput returns the console output carried by the given response
+// packets as lines of text. Responses to rcon commands are print packets,
+// whose payload starts with "n"; other packets are ignored. Output spanning
+// several packets is joined before being split into lines.
+func ParseOutput(packets ...[]byte) ([]qstr.QStr, error) {
+	var out strings.Builder
+	for _, packet := range packets {
+		payload, err := Unframe(packet)
+		if err != nil {
+			return nil, err
+		}
+		if len(payload) > 0 && payload[0] == 'n' {
+			out.Write(payload[1:])
+		}
+	}
+
+	text := strings.TrimSuffix(out.String(), "\n")
+	if text == "" {
+		return []qstr.QStr{}, nil
+	}
+
+	raw := strings.Split(text, "\n")
+	lines := make([]qstr.QStr, 0, len(raw))
+	for _, l := range raw {
+		lines = append(lines, qstr.QStr(l))
+	}
+	return lines, nil
+}
@@ -0,0 +1,100 @@
+package rcon
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"encoding/hex"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/antzucaro/qstr"
+)
+
+func TestMD4(t *testing.T) {
+	var md4List = []struct {
+		Input    string
+		Expected string
+	}{
+		{"", "31d6cfe0d16ae931b73c59d7e0c089c0"},
+		{"abc", "a448017aaf21d8525fc10ae87aa6729d"},
+		{"12345678901234567890123456789012345678901234567890123456789012345678901234567890", "e33b4ddc9c38f2199c3e7b164fcc0536"},
+	}
+
+	for _, v := range md4List {
+		d := newMD4()
+		d.Write([]byte(v.Input))
+		received := hex.EncodeToString(d.Sum(nil))
+		if received != v.Expected {
+			t.Errorf("Incorrect MD4 of %q. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestFraming(t *testing.T) {
+	packet := Frame([]byte("getstatus"))
+	if string(packet) != "\xff\xff\xff\xffgetstatus" {
+		t.Errorf("Incorrect framing. Got: %q.", packet)
+	}
+
+	payload, err := Unframe(packet)
+	if err != nil || string(payload) != "getstatus" {
+		t.Errorf("Incorrect unframing. Expected: %q, Got: %q (%v).", "getstatus", payload, err)
+	}
+
+	if _, err := Unframe([]byte("getstatus")); err != ErrNoHeader {
+		t.Errorf("Incorrect error unframing a bare payload. Expected: %v, Got: %v.", ErrNoHeader, err)
+	}
+}
+
+func TestCommand(t *testing.T) {
+	expected := "\xff\xff\xff\xffrcon secret status"
+	if received := string(Command("secret", "status")); received != expected {
+		t.Errorf("Incorrect rcon packet. Expected: %q, Got: %q.", expected, received)
+	}
+}
+
+func TestChallengeCommand(t *testing.T) {
+	challenge, err := ParseChallenge([]byte("\xff\xff\xff\xffchallenge 1a2b3c\x00vlen"))
+	if err != nil || challenge != "1a2b3c" {
+		t.Fatalf("Incorrect challenge. Expected: %q, Got: %q (%v).", "1a2b3c", challenge, err)
+	}
+
+	packet := ChallengeCommand("secret", challenge, "status")
+	prefix := []byte("\xff\xff\xff\xffsrcon HMAC-MD4 CHALLENGE ")
+	suffix := []byte(" 1a2b3c status")
+	if !bytes.HasPrefix(packet, prefix) || !bytes.HasSuffix(packet, suffix) {
+		t.Fatalf("Incorrect srcon packet. Got: %q.", packet)
+	}
+
+	mac := hmac.New(newMD4, []byte("secret"))
+	mac.Write([]byte("1a2b3c status"))
+	if sum := packet[len(prefix) : len(packet)-len(suffix)]; !hmac.Equal(sum, mac.Sum(nil)) {
+		t.Errorf("Incorrect srcon signature. Expected: %x, Got: %x.", mac.Sum(nil), sum)
+	}
+}
+
+func TestTimeCommand(t *testing.T) {
+	now := time.Unix(1700000000, 123456000)
+	packet := TimeCommand("secret", "status", now)
+
+	suffix := []byte(" 1700000000.123456 status")
+	if !bytes.HasSuffix(packet, suffix) {
+		t.Errorf("Incorrect srcon packet. Got: %q.", packet)
+	}
+}
+
+func TestParseOutput(t *testing.T) {
+	lines, err := ParseOutput(
+		[]byte("\xff\xff\xff\xffnhost:     ^1My ^7Server\nmap:      "),
+		[]byte("\xff\xff\xff\xffnafterslime\n"),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing output: %v.", err)
+	}
+
+	expected := []qstr.QStr{"host:     ^1My ^7Server", "map:      afterslime"}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Incorrect output lines. Expected: %q, Got: %q.", expected, lines)
+	}
+}
@@ -0,0 +1,926 @@
+package qstr
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"html/template"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CopyMode selects the text a copy-friendly rendering offers for copying.
+type CopyMode int
+
+const (
+	// NoCopy leaves out copy data. This is the default.
+	NoCopy CopyMode = iota
+
+	// CopyStripped offers the stripped text, with game font glyphs decoded
+	// into the characters they represent.
+	CopyStripped
+
+	// CopyRaw offers the raw string, color codes included.
+	CopyRaw
+)
+
+// BackgroundMode controls how colors are applied to the spans emitted by the
+// HTML renderer.
+type BackgroundMode int
+
+const (
+	// ForegroundOnly colors the text itself. This is the default.
+	ForegroundOnly BackgroundMode = iota
+
+	// BackgroundOnly uses each color as the background of its span and picks
+	// a readable black or white foreground, giving a "badge" look suited to
+	// compact lists.
+	BackgroundOnly
+
+	// ForegroundAndBackground colors the text and places it on a black or
+	// white backdrop, whichever contrasts better with the text color.
+	ForegroundAndBackground
+)
+
+// color representation by key for the "^n" format, where n is 0-9
+var decimalSpans = map[string]string{
+	"^0": "<span style='color:rgb(128,128,128)'>",
+	"^1": "<span style='color:rgb(255,0,0)'>",
+	"^2": "<span style='color:rgb(51,255,0)'>",
+	"^3": "<span style='color:rgb(255,255,0)'>",
+	"^4": "<span style='color:rgb(51,102,255)'>",
+	"^5": "<span style='color:rgb(51,255,255)'>",
+	"^6": "<span style='color:rgb(255,51,102)'>",
+	"^7": "<span style='color:rgb(255,255,255)'>",
+	"^8": "<span style='color:rgb(153,153,153)'>",
+	"^9": "<span style='color:rgb(128,128,128)'>",
+}
+
+// Renderer converts a QStr into its displayable forms. The zero value is not
+// usable; create one with NewRenderer.
+type Renderer struct {
+	dialect    *Dialect
+	theme      Theme
+	background BackgroundMode
+	reveal     bool
+	isolate    bool
+	title      bool
+	copyMode   CopyMode
+	linkify    bool
+	canonical  bool
+	depth      ColorDepth
+	width      WidthFunc
+	prefix     string
+	escape     func(string) string
+	classFunc  ClassFunc
+	email      bool
+	oklch      bool
+	flat       bool
+	rawData    bool
+	tag        string
+
+	// whether every color is written as a class, not only the basic ones
+	classesOnly bool
+
+	// minimum contrast against contrastBg, if not zero
+	minContrast float64
+	contrastBg  RGBColor
+
+	// limits on the input, if not zero
+	codeLimit, sizeLimit int
+
+	decodeKey   map[rune]rune
+	replacement string
+	replace     bool
+
+	cache *renderCache
+
+	// collects warnings, on the copies made by withWarnings only
+	warn *warningList
+}
+
+// Option configures a Renderer.
+type Option func(*Renderer)
+
+// NewRenderer returns a Renderer configured with the given options.
+func NewRenderer(opts ...Option) *Renderer {
+	r := &Renderer{
+		dialect:    DarkPlaces,
+		theme:      DefaultTheme(),
+		background: ForegroundOnly,
+		width:      CellWidth,
+		escape:     html.EscapeString,
+		tag:        "span",
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// WithDialect sets the dialect used to interpret color codes.
+func WithDialect(d *Dialect) Option {
+	return func(r *Renderer) {
+		r.dialect = d
+	}
+}
+
+// WithPalette sets the colors of the basic color codes, ^0 through ^9.
+func WithPalette(p Palette) Option {
+	return func(r *Renderer) {
+		r.theme.Palette = p
+	}
+}
+
+// WithTheme sets the theme of the page the output is shown on, replacing the
+// palette and the lightness bounds. The default is DarkTheme, unless changed
+// with SetDefaultTheme; pages with a light background should use LightTheme,
+// which caps colors to the darker half of the lightness range instead of the
+// lighter one.
+func WithTheme(theme Theme) Option {
+	return func(r *Renderer) {
+		r.theme = theme
+	}
+}
+
+// WithLightnessBounds sets the bounds hex colors are capped to, replacing
+// those of the theme.
+func WithLightnessBounds(lower, upper float64) Option {
+	return func(r *Renderer) {
+		r.theme.MinLightness = lower
+		r.theme.MaxLightness = upper
+	}
+}
+
+// WithOKLCH caps colors to the lightness bounds in the OKLCH space instead
+// of HSL, so that capping keeps hues intact and treats colors by their
+// perceived lightness. The bounds keep their meaning of 0 for black and 1
+// for white. See CapLightnessOKLCH.
+func WithOKLCH() Option {
+	return func(r *Renderer) {
+		r.oklch = true
+	}
+}
+
+// WithMinContrast adjusts every color during rendering, basic colors
+// included, so that text meets a WCAG contrast ratio of at least ratio
+// against bg, the background of the page or terminal, using EnsureContrast.
+// Colors that already meet it are left alone, so unlike the lightness
+// bounds, which it replaces, it only changes the colors that need it and
+// only as much as needed. Text on a background set by a dialect code is
+// compared with that background instead. Compare ratio with ContrastAA.
+func WithMinContrast(bg RGBColor, ratio float64) Option {
+	return func(r *Renderer) {
+		r.contrastBg = bg
+		r.minContrast = ratio
+	}
+}
+
+// WithClassPrefix renders the basic color codes as CSS classes instead of
+// inline styles: ^1 becomes class="<prefix>1", and so on. Sites can then
+// restyle the palette from their own stylesheets. Hex colors are still
+// written inline unless WithClassesOnly is given too.
+func WithClassPrefix(prefix string) Option {
+	return func(r *Renderer) {
+		r.prefix = prefix
+	}
+}
+
+// WithClassesOnly extends WithClassPrefix to every color and attribute, for
+// pages whose Content Security Policy forbids inline styles: ^x4af becomes
+// class="<prefix>x4af", a background class="<prefix>bg-x4af", and bold text
+// class="<prefix>bold". Use Stylesheet to generate the matching rules. It
+// has no effect without a class prefix.
+func WithClassesOnly() Option {
+	return func(r *Renderer) {
+		r.classesOnly = true
+	}
+}
+
+// ClassFunc returns the CSS classes to attach to the span of a segment, given
+// the segment and its index among the segments of the value. An empty result
+// attaches none.
+type ClassFunc func(seg Segment, index int) string
+
+// WithClassFunc attaches the classes returned by f to the span of each
+// segment, giving stylesheets a hook to style segments such as clan tags.
+// Segments without a color get a span of their own when f returns classes
+// for them. Since nested spans would pass classes on to the segments that
+// follow, every span is closed before the next segment starts. With
+// WithEmail, the classes are attached to the <font> elements instead.
+func WithClassFunc(f ClassFunc) Option {
+	return func(r *Renderer) {
+		r.classFunc = f
+	}
+}
+
+// WithEmail switches to a profile for HTML email, which many clients render
+// with little CSS support. Each segment is written as a <font> element
+// carrying both a color attribute and an inline style, elements are never
+// nested, and colors are written as #rrggbb. Since most messages have a light
+// background, every color, including those of the basic codes, is capped to
+// the lightness bounds of LightTheme, while the palette is left as it is;
+// options given after WithEmail can change them. Background modes don't
+// apply. Use PlainText for the plain-text alternative part.
+func WithEmail() Option {
+	return func(r *Renderer) {
+		r.email = true
+		r.theme.MinLightness = LightTheme.MinLightness
+		r.theme.MaxLightness = LightTheme.MaxLightness
+	}
+}
+
+// WithEscaping sets the function used to escape the visible text before it is
+// written into the markup. The default is html.EscapeString. A nil function
+// writes the text unchanged, which is only safe for input known not to
+// contain markup.
+func WithEscaping(escape func(string) string) Option {
+	return func(r *Renderer) {
+		if escape == nil {
+			escape = func(s string) string { return s }
+		}
+		r.escape = escape
+	}
+}
+
+// WithBackground sets how colors are applied to the rendered spans.
+func WithBackground(mode BackgroundMode) Option {
+	return func(r *Renderer) {
+		r.background = mode
+	}
+}
+
+// WithReveal makes spaces, tabs, zero-width characters, control characters,
+// and unmapped glyphs visible in the output, so moderators can see exactly
+// what a string contains.
+func WithReveal() Option {
+	return func(r *Renderer) {
+		r.reveal = true
+	}
+}
+
+// WithBidiIsolation wraps the output in a <bdi> element and removes explicit
+// bidirectional override characters from the text, so right-to-left names
+// can't visually reorder the text surrounding them.
+func WithBidiIsolation() Option {
+	return func(r *Renderer) {
+		r.isolate = true
+	}
+}
+
+// WithTitle adds a title attribute holding the stripped text to a wrapper
+// element around the output, so hovering over a heavily stylized name shows
+// it plainly.
+func WithTitle() Option {
+	return func(r *Renderer) {
+		r.title = true
+	}
+}
+
+// WithRawData adds a data-qstr-raw attribute holding the raw string, color
+// codes included, to a wrapper element around the output, so scripts can
+// get at the original value without a second template call.
+func WithRawData() Option {
+	return func(r *Renderer) {
+		r.rawData = true
+	}
+}
+
+// phrasingElements holds the names accepted by WithTag: inline elements
+// whose content is parsed as ordinary text and markup. Elements such as
+// script, style, textarea, and title treat their content as raw text, which
+// would defeat the escaping of the visible text.
+var phrasingElements = map[string]bool{
+	"span": true, "font": true, "b": true, "i": true, "u": true, "s": true,
+	"em": true, "strong": true, "small": true, "mark": true, "code": true,
+	"kbd": true, "samp": true, "var": true, "abbr": true, "cite": true,
+	"dfn": true, "q": true, "sub": true, "sup": true, "bdi": true, "bdo": true,
+	"data": true, "time": true, "del": true, "ins": true,
+}
+
+// WithTag sets the name of the element used for colored runs and for the
+// wrapper element, in place of span, such as font for mail clients that
+// ignore the style of spans. Only inline phrasing elements, such as font, b,
+// or em, are accepted; other names are ignored. See also WithEmail.
+func WithTag(tag string) Option {
+	return func(r *Renderer) {
+		if tag = strings.ToLower(tag); phrasingElements[tag] {
+			r.tag = tag
+		}
+	}
+}
+
+// WithCopyData makes the output copy-friendly: the wrapper element carries
+// the text chosen by mode in a data-copy attribute, and every game font glyph
+// is wrapped in a span whose data-glyph attribute holds the plain character
+// it represents. Clipboard handlers can then offer the plain name without
+// parsing the markup.
+func WithCopyData(mode CopyMode) Option {
+	return func(r *Renderer) {
+		r.copyMode = mode
+	}
+}
+
+// WithLinks wraps URLs found in the visible text in <a> elements. URLs are
+// found even when color codes interrupt them.
+func WithLinks() Option {
+	return func(r *Renderer) {
+		r.linkify = true
+	}
+}
+
+// CanonicalVersion is the version of the canonical output format produced
+// with WithCanonical. It only changes if the format itself changes, which is
+// never done silently.
+const CanonicalVersion = 1
+
+// WithCanonical switches to the canonical output format, which is guaranteed
+// to be byte-for-byte stable across library versions for the same input and
+// options, so cached output and golden files don't churn on upgrades. In the
+// canonical format every span has a single double-quoted style attribute
+// whose declarations appear in a fixed order (color, background-color,
+// font-weight, font-style, text-decoration), separated by semicolons without
+// spaces, and every color is written as a lowercase #rrggbb hex triplet with
+// channels rounded to the nearest value.
+func WithCanonical() Option {
+	return func(r *Renderer) {
+		r.canonical = true
+	}
+}
+
+// WithFlatSpans closes each span before the next one opens, so that every
+// colored run gets a single span of its own rather than being nested inside
+// the spans of the runs before it. The output is longer, but flat and
+// balanced, which is what HTML sanitizers and feed readers expect.
+func WithFlatSpans() Option {
+	return func(r *Renderer) {
+		r.flat = true
+	}
+}
+
+// WithWidthFunc sets the function used to measure the display width of text.
+// Every width-based operation of the Renderer uses it, so alignment in
+// terminals and in monospaced HTML (measured in ch units) agrees.
+func WithWidthFunc(f WidthFunc) Option {
+	return func(r *Renderer) {
+		r.width = f
+	}
+}
+
+// WithDecodeKey translates the text being rendered using key, as Decode does,
+// so game font glyphs are shown as the characters they represent.
+func WithDecodeKey(key map[rune]rune) Option {
+	return func(r *Renderer) {
+		r.decodeKey = key
+	}
+}
+
+// WithReplacement sets the text shown in place of private-use glyphs that
+// have no mapping in the decode key, rather than passing the raw code point
+// through where it would render as an empty box.
+func WithReplacement(replacement string) Option {
+	return func(r *Renderer) {
+		r.replacement = replacement
+		r.replace = true
+	}
+}
+
+// Width returns the number of fixed-width cells the visible text of s takes
+// up when displayed.
+func (r *Renderer) Width(s QStr) int {
+	w := 0
+	for _, seg := range r.dialect.Tokenize(s) {
+		w += textWidth(seg.Text, r.width)
+	}
+	return w
+}
+
+// HTML returns the HTML representation of s. Color codes are converted into
+// nested <span> elements with the appropriate color attached as inline CSS:
+// the value is converted in a single pass, each colored segment opening a
+// span inside those of the segments before it, and every span is closed at
+// the end, so the markup is balanced however many codes repeat. Use
+// WithFlatSpans to close each span before the next one opens instead.
+func (r *Renderer) HTML(s QStr) template.HTML {
+	return template.HTML(r.cached(cacheHTML, s, func() string {
+		return string(r.annotatedHTML(s, nil))
+	}))
+}
+
+// annotatedHTML returns the HTML representation of s with the given
+// annotations placed around ranges of the visible text. Annotations must be
+// sorted and must not overlap.
+func (r *Renderer) annotatedHTML(s QStr, annotations []annotation) template.HTML {
+	h, _ := r.renderHTML(context.Background(), s, annotations)
+	return h
+}
+
+// renderHTML does the work of annotatedHTML, giving up on markup once ctx is
+// done: the rest of the text is then appended stripped and false is returned.
+func (r *Renderer) renderHTML(ctx context.Context, s QStr, annotations []annotation) (template.HTML, bool) {
+	var b strings.Builder
+	ok := r.writeHTML(ctx, &b, s, annotations)
+	return template.HTML(b.String()), ok
+}
+
+// writeHTML writes the HTML representation of s to b as renderHTML does,
+// returning false if ctx was done before the markup was complete.
+func (r *Renderer) writeHTML(ctx context.Context, b textWriter, s QStr, annotations []annotation) bool {
+	s, cut := r.limit(s)
+	closeWrapper := r.openWrapper(b, s)
+
+	w := &htmlWriter{r: r, b: b, depth: -1}
+	d := r.newDeadline(ctx)
+	rest := ""
+	if cut {
+		r.truncated(len(s))
+	}
+	if r.linkify {
+		// links may span segments, so they need to be found up front,
+		// along with the code of each segment if warnings are collected
+		var segments []Segment
+		var codes []warningList
+		r.dialect.scanWarnings(s, func(seg Segment) bool {
+			segments = append(segments, seg)
+			if r.warn != nil {
+				codes = append(codes, warningList{offset: r.warn.offset, code: r.warn.code})
+			}
+			return true
+		}, r.warn)
+		w.annotations = mergeAnnotations(annotations, findLinks(segments))
+		for i, seg := range segments {
+			if r.warn != nil {
+				r.warn.setCode(codes[i].offset, codes[i].code)
+			}
+			if !d.admit(seg) {
+				r.colorsDropped()
+				rest = segmentsText(segments[i:])
+				break
+			}
+			w.segment(seg)
+		}
+	} else {
+		w.annotations = annotations
+		off := r.dialect.scanWarnings(s, func(seg Segment) bool {
+			if !d.admit(seg) {
+				r.colorsDropped()
+				return false
+			}
+			w.segment(seg)
+			return true
+		}, r.warn)
+		rest = r.dialect.Strip(s[off:])
+	}
+	if w.depth >= 0 {
+		w.endAnnotation()
+	}
+	w.finish()
+	r.writeText(b, rest)
+
+	b.WriteString(closeWrapper)
+
+	return !d.hit && !cut
+}
+
+// mergeAnnotations merges two sorted lists of annotations. Where two
+// annotations overlap, the one starting first wins; ties go to a.
+func mergeAnnotations(a, b []annotation) []annotation {
+	merged := make([]annotation, 0, len(a)+len(b))
+	end := 0
+	for len(a) > 0 || len(b) > 0 {
+		var next annotation
+		if len(b) == 0 || (len(a) > 0 && a[0].start <= b[0].start) {
+			next, a = a[0], a[1:]
+		} else {
+			next, b = b[0], b[1:]
+		}
+		if next.start >= end {
+			merged = append(merged, next)
+			end = next.end
+		}
+	}
+	return merged
+}
+
+// htmlWriter keeps track of the elements left open while writing the HTML
+// for a sequence of segments. Spans are nested unless the options call for
+// each to be closed before the next one opens.
+type htmlWriter struct {
+	r *Renderer
+	b textWriter
+
+	// the closing tags of the elements currently open, innermost last
+	closers []string
+
+	// elements to place around ranges of the visible text, the offset of
+	// the text written so far, and the number of spans that were open when
+	// the current annotation started, or -1 if none is open
+	annotations []annotation
+	pos         int
+	depth       int
+
+	// the number of segments written so far
+	index int
+}
+
+// annotation is an element placed around a range of the visible text, such
+// as a link. Start and end are byte offsets within the visible text.
+type annotation struct {
+	start, end int
+	open       string
+	close      string
+}
+
+// segment writes one segment of text
+func (w *htmlWriter) segment(seg Segment) {
+	tag := ""
+	if w.r.classFunc != nil {
+		tag = w.classSpan(seg)
+	} else if w.r.email {
+		tag = w.r.emailTag(seg)
+	} else if seg.styled() {
+		tag = w.r.openSpan(seg)
+	}
+	tag = w.r.retag(tag)
+	w.index++
+
+	if len(w.closers) > 0 && (tag == "" || w.r.classFunc != nil || w.r.email || w.r.flat) {
+		// nothing to inherit, so close whatever is still open
+		if w.depth >= 0 {
+			w.endAnnotation()
+			w.closeSpans(len(w.closers))
+			w.startAnnotation()
+		} else {
+			w.closeSpans(len(w.closers))
+		}
+	}
+	if tag != "" {
+		w.openElement(tag)
+	}
+
+	text := seg.Text
+	for len(text) > 0 {
+		n := len(text)
+		if len(w.annotations) > 0 {
+			a := w.annotations[0]
+			if w.depth < 0 && w.pos == a.start {
+				w.startAnnotation()
+			}
+			if w.depth < 0 {
+				n = min(n, a.start-w.pos)
+			} else {
+				n = min(n, a.end-w.pos)
+			}
+		}
+
+		w.r.writeText(w.b, text[:n])
+		text = text[n:]
+		w.pos += n
+
+		if w.depth >= 0 && w.pos == w.annotations[0].end {
+			closed := w.endAnnotation()
+			w.annotations = w.annotations[1:]
+
+			// carry the segment's color on past the annotation
+			if closed > 0 && len(text) > 0 {
+				w.openElement(tag)
+			}
+		}
+	}
+}
+
+// classSpan returns the opening span for a segment with the classes given by
+// the renderer's ClassFunc, or an empty string if the segment needs none.
+func (w *htmlWriter) classSpan(seg Segment) string {
+	class := w.r.classFunc(seg, w.index)
+	name := "span"
+	if w.r.email {
+		name = "font"
+	}
+	if !seg.styled() {
+		if class == "" {
+			return ""
+		}
+		return fmt.Sprintf("<%s class=\"%s\">", name, html.EscapeString(class))
+	}
+
+	tag := w.r.openSpan(seg)
+	if w.r.email {
+		tag = w.r.emailTag(seg)
+	}
+	if class == "" {
+		return tag
+	}
+	n := len("<" + name)
+	if strings.HasPrefix(tag[n:], " class=\"") {
+		n += len(" class=\"")
+		return tag[:n] + html.EscapeString(class) + " " + tag[n:]
+	}
+	return tag[:n] + fmt.Sprintf(" class=\"%s\"", html.EscapeString(class)) + tag[n:]
+}
+
+// finish closes every element left open
+func (w *htmlWriter) finish() {
+	// add the appropriate amount of closing spans
+	w.closeSpans(len(w.closers))
+}
+
+// startAnnotation opens the next annotation
+func (w *htmlWriter) startAnnotation() {
+	w.b.WriteString(w.annotations[0].open)
+	w.depth = len(w.closers)
+}
+
+// endAnnotation closes the current annotation along with any spans opened
+// within it, returning the number of spans closed.
+func (w *htmlWriter) endAnnotation() int {
+	inner := len(w.closers) - w.depth
+	w.closeSpans(inner)
+	w.b.WriteString(w.annotations[0].close)
+	w.depth = -1
+	return inner
+}
+
+// openElement writes the opening tag of an element and remembers its closing
+// tag, which depends on the element: a span, a <font> element in the email
+// profile, or the element given to WithTag.
+func (w *htmlWriter) openElement(tag string) {
+	w.b.WriteString(tag)
+	name := tag[1:strings.IndexAny(tag, " >")]
+	w.closers = append(w.closers, "</"+name+">")
+}
+
+// closeSpans closes the n innermost open elements
+func (w *htmlWriter) closeSpans(n int) {
+	for i := 0; i < n; i++ {
+		last := len(w.closers) - 1
+		w.b.WriteString(w.closers[last])
+		w.closers = w.closers[:last]
+	}
+}
+
+// retag returns the opening span tag open with its element name replaced by
+// the renderer's tag.
+func (r *Renderer) retag(open string) string {
+	if r.tag == "span" || !strings.HasPrefix(open, "<span") {
+		return open
+	}
+	return "<" + r.tag + open[len("<span"):]
+}
+
+// openWrapper writes the opening tag of the element wrapping the whole
+// output, if the options call for one, and returns its closing tag.
+func (r *Renderer) openWrapper(b textWriter, s QStr) string {
+	tag := r.tag
+	if r.isolate {
+		tag = "bdi"
+	} else if !r.title && !r.rawData && r.copyMode == NoCopy {
+		return ""
+	}
+
+	b.WriteString("<" + tag)
+	if r.title {
+		fmt.Fprintf(b, " title=\"%s\"", html.EscapeString(r.dialect.Strip(s)))
+	}
+	if r.rawData {
+		fmt.Fprintf(b, " data-qstr-raw=\"%s\"", html.EscapeString(string(s)))
+	}
+	switch r.copyMode {
+	case CopyStripped:
+		fmt.Fprintf(b, " data-copy=\"%s\"", html.EscapeString(decodeString(r.dialect.Strip(s))))
+	case CopyRaw:
+		fmt.Fprintf(b, " data-copy=\"%s\"", html.EscapeString(string(s)))
+	}
+	b.WriteString(">")
+
+	return "</" + tag + ">"
+}
+
+// writeText writes the text of a segment, escaping HTML special characters.
+// In copy-friendly mode game font glyphs are marked up individually.
+func (r *Renderer) writeText(b textWriter, text string) {
+	if r.copyMode == NoCopy {
+		b.WriteString(r.escape(r.text(text)))
+		return
+	}
+
+	start := 0
+	for i, c := range text {
+		d, ok := XonoticDecodeKey[c]
+		if !ok {
+			continue
+		}
+		b.WriteString(r.escape(r.text(text[start:i])))
+		fmt.Fprintf(b, "<span data-glyph=\"%s\">%s</span>", html.EscapeString(string(d)), r.escape(r.text(string(c))))
+		start = i + utf8.RuneLen(c)
+	}
+	b.WriteString(r.escape(r.text(text[start:])))
+}
+
+// text applies the renderer's character translations to the text of a
+// segment.
+func (r *Renderer) text(text string) string {
+	if r.decodeKey != nil || r.replace {
+		text = r.decode(text)
+	}
+	if r.isolate {
+		text = removeBidiControls(text)
+	}
+	if r.reveal {
+		text = reveal(text)
+	}
+	return text
+}
+
+// decode translates text with the renderer's decode key, substituting the
+// replacement for unmapped private-use glyphs when one is set.
+func (r *Renderer) decode(text string) string {
+	var b strings.Builder
+	for _, c := range text {
+		if d, ok := r.decodeKey[c]; ok {
+			b.WriteRune(d)
+		} else if r.replace && unicode.Is(unicode.Co, c) {
+			b.WriteString(r.replacement)
+		} else {
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// openSpan returns the opening span for a styled segment
+func (r *Renderer) openSpan(seg Segment) string {
+	if r.prefix != "" && r.classesOnly {
+		return r.classSpan(seg)
+	}
+	if r.canonical {
+		return r.canonicalSpan(seg)
+	}
+
+	class := ""
+	if r.prefix != "" && r.background == ForegroundOnly && seg.Code != "" && !seg.Code.IsHex() {
+		class = fmt.Sprintf(" class=\"%s%s\"", html.EscapeString(r.prefix), seg.Code[1:])
+	}
+
+	if !seg.HasBackground && seg.Style == 0 {
+		if class != "" {
+			return "<span" + class + ">"
+		}
+		if !seg.Code.IsHex() {
+			return r.decimalSpan(string(seg.Code))
+		}
+		return r.hexSpan(seg.Color)
+	}
+
+	// segments carrying dialect attributes always spell out every property
+	decls := make([]string, 0, 5)
+	if seg.Code != "" && class == "" {
+		c := r.color(seg)
+		decls = append(decls, "color:"+c.CSS())
+	}
+	if seg.HasBackground {
+		decls = append(decls, "background-color:"+seg.Background.CSS())
+	}
+	decls = appendStyleDecls(decls, seg.Style)
+
+	return fmt.Sprintf("<span%s style=\"%s\">", class, strings.Join(decls, ";"))
+}
+
+// color returns the foreground color of seg, taking the basic color codes
+// from the renderer's palette and adjusting it to the minimum contrast if
+// one was set.
+func (r *Renderer) color(seg Segment) RGBColor {
+	c := r.theme.Palette.color(seg)
+	if r.minContrast > 0 {
+		bg := r.contrastBg
+		if seg.HasBackground {
+			bg = seg.Background
+		}
+		adjusted := c.EnsureContrast(bg, r.minContrast)
+		r.adjusted(c, adjusted)
+		c = adjusted
+	}
+	return c
+}
+
+// capLightness trims c to the theme's lightness bounds, in OKLCH if
+// WithOKLCH was given and in HSL otherwise. With WithMinContrast, c is
+// adjusted to the minimum contrast instead.
+func (r *Renderer) capLightness(c RGBColor) RGBColor {
+	var capped RGBColor
+	switch {
+	case r.minContrast > 0:
+		capped = c.EnsureContrast(r.contrastBg, r.minContrast)
+	case r.oklch:
+		capped = c.CapLightnessOKLCH(r.theme.MinLightness, r.theme.MaxLightness)
+	default:
+		capped = c.CapLightness(r.theme.MinLightness, r.theme.MaxLightness)
+	}
+	r.adjusted(c, capped)
+	return capped
+}
+
+// canonicalSpan returns the opening span for a styled segment in the
+// canonical format described by WithCanonical.
+func (r *Renderer) canonicalSpan(seg Segment) string {
+	var fg, bg *RGBColor
+	if seg.Code != "" {
+		c := r.color(seg)
+		if r.background == ForegroundOnly && !seg.HasBackground && seg.Code.IsHex() {
+			c = r.capLightness(c)
+		}
+		fg = &c
+	}
+	if seg.HasBackground {
+		bg = &seg.Background
+	} else if fg != nil && r.background != ForegroundOnly {
+		contrast := fg.readableForeground()
+		if r.background == BackgroundOnly {
+			fg, bg = &contrast, fg
+		} else {
+			bg = &contrast
+		}
+	}
+
+	decls := make([]string, 0, 5)
+	if fg != nil {
+		decls = append(decls, "color:"+fg.Hex())
+	}
+	if bg != nil {
+		decls = append(decls, "background-color:"+bg.Hex())
+	}
+	decls = appendStyleDecls(decls, seg.Style)
+
+	return fmt.Sprintf("<span style=\"%s\">", strings.Join(decls, ";"))
+}
+
+// appendStyleDecls appends the CSS declarations for a set of formatting
+// attributes to decls.
+func appendStyleDecls(decls []string, style Style) []string {
+	if style.Has(Bold) {
+		decls = append(decls, "font-weight:bold")
+	}
+	if style.Has(Italic) {
+		decls = append(decls, "font-style:italic")
+	}
+	if style&(Underline|Blink) != 0 {
+		decorations := make([]string, 0, 2)
+		if style.Has(Underline) {
+			decorations = append(decorations, "underline")
+		}
+		if style.Has(Blink) {
+			decorations = append(decorations, "blink")
+		}
+		decls = append(decls, "text-decoration:"+strings.Join(decorations, " "))
+	}
+	return decls
+}
+
+// decimalSpan returns the opening span for a code of the form ^n
+func (r *Renderer) decimalSpan(code string) string {
+	c := r.theme.Palette[code[1]-'0']
+	if r.background != ForegroundOnly {
+		return r.backgroundSpan(c)
+	}
+	if r.minContrast > 0 {
+		adjusted := c.EnsureContrast(r.contrastBg, r.minContrast)
+		r.adjusted(c, adjusted)
+		return adjusted.SpanStr()
+	}
+	if r.theme.Palette == XonoticPalette {
+		return decimalSpans[code]
+	}
+	return c.SpanStr()
+}
+
+// hexSpan returns the opening span for a color given by a code of the form
+// ^xrgb. In the foreground-only mode the lightness is capped to the theme's
+// bounds so the text stays readable on the page.
+func (r *Renderer) hexSpan(c RGBColor) string {
+	if r.background == ForegroundOnly {
+		c = r.capLightness(c)
+		return c.SpanStr()
+	}
+	return r.backgroundSpan(c)
+}
+
+// backgroundSpan returns the opening span for c in one of the background
+// modes.
+func (r *Renderer) backgroundSpan(c RGBColor) string {
+	contrast := c.readableForeground()
+	if r.background == BackgroundOnly {
+		return fmt.Sprintf("<span style=\"background-color:%s;color:%s\">", c.CSS(), contrast.CSS())
+	}
+	return fmt.Sprintf("<span style=\"color:%s;background-color:%s\">", c.CSS(), contrast.CSS())
+}
+
+// readableForeground returns black or white, whichever is easier to read
+// when placed on top of c.
+func (c *RGBColor) readableForeground() RGBColor {
+	// perceived brightness, weighted for the eye's sensitivity to each channel
+	if 0.299*c.R+0.587*c.G+0.114*c.B > 0.5 {
+		return RGBColor{0, 0, 0}
+	}
+	return RGBColor{1, 1, 1}
+}
@@ -0,0 +1,295 @@
+package qstr
+
+import (
+	"html/template"
+	"testing"
+)
+
+func TestHTML(t *testing.T) {
+	nick := QStr("^x444Anti^5body")
+	expected := template.HTML("<span style=\"color:rgb(127,127,127)\">Anti<span style='color:rgb(51,255,255)'>body</span></span>")
+	received := nick.HTML()
+
+	if received != expected {
+		t.Errorf("Incorrect HTML value returned. Expected: %v, Got: %v.", expected, received)
+	}
+}
+
+func TestHTMLRepeatedCodes(t *testing.T) {
+	var repeatedList = []struct {
+		Input    QStr
+		Expected template.HTML
+	}{
+		{"^1Anti^1body", "<span style='color:rgb(255,0,0)'>Anti<span style='color:rgb(255,0,0)'>body</span></span>"},
+		{"^1^2^3Antibody", "<span style='color:rgb(255,255,0)'>Antibody</span>"},
+		{"^1A^2^1nti^x444^x444body", "<span style='color:rgb(255,0,0)'>A<span style='color:rgb(255,0,0)'>nti<span style=\"color:rgb(127,127,127)\">body</span></span></span>"},
+		{"Antibody^1", "Antibody"},
+		{"^1^^1Anti", "<span style='color:rgb(255,0,0)'>^1Anti</span>"},
+	}
+
+	for _, v := range repeatedList {
+		received := v.Input.HTML()
+		if received != v.Expected {
+			t.Errorf("Incorrect HTML value returned for %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestHTMLBackground(t *testing.T) {
+	nick := QStr("^1Anti^7body")
+
+	expectedList := map[BackgroundMode]template.HTML{
+		BackgroundOnly:          "<span style=\"background-color:rgb(255,0,0);color:rgb(255,255,255)\">Anti<span style=\"background-color:rgb(255,255,255);color:rgb(0,0,0)\">body</span></span>",
+		ForegroundAndBackground: "<span style=\"color:rgb(255,0,0);background-color:rgb(255,255,255)\">Anti<span style=\"color:rgb(255,255,255);background-color:rgb(0,0,0)\">body</span></span>",
+	}
+
+	for mode, expected := range expectedList {
+		received := nick.HTML(WithBackground(mode))
+		if received != expected {
+			t.Errorf("Incorrect HTML value returned for mode %v. Expected: %v, Got: %v.", mode, expected, received)
+		}
+	}
+}
+
+func TestHTMLFlatSpans(t *testing.T) {
+	var flatList = []struct {
+		Input    QStr
+		Opts     []Option
+		Expected template.HTML
+	}{
+		{"^x444Anti^5body", nil, "<span style=\"color:rgb(127,127,127)\">Anti</span><span style='color:rgb(51,255,255)'>body</span>"},
+		{"A^1n^2t^7i", nil, "A<span style='color:rgb(255,0,0)'>n</span><span style='color:rgb(51,255,0)'>t</span><span style='color:rgb(255,255,255)'>i</span>"},
+		{"^1go to http://x.org ^2now", []Option{WithLinks()}, "<span style='color:rgb(255,0,0)'>go to <a href=\"http://x.org\" rel=\"nofollow\">http://x.org</a> </span><span style='color:rgb(51,255,0)'>now</span>"},
+	}
+
+	for _, v := range flatList {
+		received := v.Input.HTML(append(v.Opts, WithFlatSpans())...)
+		if received != v.Expected {
+			t.Errorf("Incorrect flat HTML value returned for %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestHTMLTheme(t *testing.T) {
+	nick := QStr("^x444Anti^xFF8body")
+
+	var themeList = []struct {
+		Theme    Theme
+		Expected template.HTML
+	}{
+		{DarkTheme, "<span style=\"color:rgb(127,127,127)\">Anti<span style=\"color:rgb(255,255,136)\">body</span></span>"},
+		{LightTheme, "<span style=\"color:rgb(68,68,68)\">Anti<span style=\"color:rgb(254,255,0)\">body</span></span>"},
+	}
+
+	for _, v := range themeList {
+		received := nick.HTML(WithTheme(v.Theme))
+		if received != v.Expected {
+			t.Errorf("Incorrect HTML value returned for theme %+v. Expected: %v, Got: %v.", v.Theme, v.Expected, received)
+		}
+	}
+}
+
+func TestHTMLReplacement(t *testing.T) {
+	nick := QStr("^1Antibody")
+
+	var replacementList = []struct {
+		Options  []Option
+		Expected template.HTML
+	}{
+		{[]Option{WithDecodeKey(XonoticDecodeKey)}, "<span style='color:rgb(255,0,0)'>AntiAbody</span>"},
+		{[]Option{WithDecodeKey(XonoticDecodeKey), WithReplacement("?")}, "<span style='color:rgb(255,0,0)'>AntiA?body</span>"},
+		{[]Option{WithReplacement("�")}, "<span style='color:rgb(255,0,0)'>Anti��body</span>"},
+	}
+
+	for _, v := range replacementList {
+		received := nick.HTML(v.Options...)
+		if received != v.Expected {
+			t.Errorf("Incorrect HTML value returned. Expected: %v, Got: %v.", v.Expected, received)
+		}
+	}
+}
+
+func TestHTMLTitle(t *testing.T) {
+	nick := QStr("^1\"Anti\"^x444body")
+
+	expected := template.HTML("<span title=\"&#34;Anti&#34;body\"><span style='color:rgb(255,0,0)'>&#34;Anti&#34;<span style=\"color:rgb(127,127,127)\">body</span></span></span>")
+	if received := nick.HTML(WithTitle()); received != expected {
+		t.Errorf("Incorrect HTML value returned. Expected: %v, Got: %v.", expected, received)
+	}
+
+	expected = template.HTML("<bdi title=\"&#34;Anti&#34;body\"><span style='color:rgb(255,0,0)'>&#34;Anti&#34;<span style=\"color:rgb(127,127,127)\">body</span></span></bdi>")
+	if received := nick.HTML(WithTitle(), WithBidiIsolation()); received != expected {
+		t.Errorf("Incorrect HTML value returned. Expected: %v, Got: %v.", expected, received)
+	}
+}
+
+func TestHTMLRawDataAndTag(t *testing.T) {
+	nick := QStr("^1<Anti>^x444body")
+
+	var tagList = []struct {
+		Opts     []Option
+		Expected template.HTML
+	}{
+		{[]Option{WithRawData()}, "<span data-qstr-raw=\"^1&lt;Anti&gt;^x444body\"><span style='color:rgb(255,0,0)'>&lt;Anti&gt;<span style=\"color:rgb(127,127,127)\">body</span></span></span>"},
+		{[]Option{WithTag("font")}, "<font style='color:rgb(255,0,0)'>&lt;Anti&gt;<font style=\"color:rgb(127,127,127)\">body</font></font>"},
+		{[]Option{WithTag("font"), WithTitle(), WithFlatSpans()}, "<font title=\"&lt;Anti&gt;body\"><font style='color:rgb(255,0,0)'>&lt;Anti&gt;</font><font style=\"color:rgb(127,127,127)\">body</font></font>"},
+		{[]Option{WithTag("b><script")}, "<span style='color:rgb(255,0,0)'>&lt;Anti&gt;<span style=\"color:rgb(127,127,127)\">body</span></span>"},
+		{[]Option{WithTag("script")}, "<span style='color:rgb(255,0,0)'>&lt;Anti&gt;<span style=\"color:rgb(127,127,127)\">body</span></span>"},
+		{[]Option{WithTag("STYLE")}, "<span style='color:rgb(255,0,0)'>&lt;Anti&gt;<span style=\"color:rgb(127,127,127)\">body</span></span>"},
+		{[]Option{WithTag("textarea")}, "<span style='color:rgb(255,0,0)'>&lt;Anti&gt;<span style=\"color:rgb(127,127,127)\">body</span></span>"},
+		{[]Option{WithTag("title")}, "<span style='color:rgb(255,0,0)'>&lt;Anti&gt;<span style=\"color:rgb(127,127,127)\">body</span></span>"},
+		{[]Option{WithTag("div")}, "<span style='color:rgb(255,0,0)'>&lt;Anti&gt;<span style=\"color:rgb(127,127,127)\">body</span></span>"},
+		{[]Option{WithTag("EM")}, "<em style='color:rgb(255,0,0)'>&lt;Anti&gt;<em style=\"color:rgb(127,127,127)\">body</em></em>"},
+	}
+
+	for _, v := range tagList {
+		if received := nick.HTML(v.Opts...); received != v.Expected {
+			t.Errorf("Incorrect HTML value returned. Expected: %v, Got: %v.", v.Expected, received)
+		}
+	}
+}
+
+func TestHTMLCopyData(t *testing.T) {
+	nick := QStr("^1Anti\ue062ody")
+
+	expected := template.HTML("<span data-copy=\"Antibody\"><span style='color:rgb(255,0,0)'>Anti<span data-glyph=\"b\">\ue062</span>ody</span></span>")
+	if received := nick.HTML(WithCopyData(CopyStripped)); received != expected {
+		t.Errorf("Incorrect HTML value returned. Expected: %v, Got: %v.", expected, received)
+	}
+
+	expected = template.HTML("<span data-copy=\"^1Anti\ue062ody\"><span style='color:rgb(255,0,0)'>Anti<span data-glyph=\"b\">b</span>ody</span></span>")
+	if received := nick.HTML(WithCopyData(CopyRaw), WithDecodeKey(XonoticDecodeKey)); received != expected {
+		t.Errorf("Incorrect HTML value returned. Expected: %v, Got: %v.", expected, received)
+	}
+}
+
+func TestHTMLCanonical(t *testing.T) {
+	var canonicalList = []struct {
+		Input    QStr
+		Options  []Option
+		Expected template.HTML
+	}{
+		{
+			"^x444Anti^5body",
+			nil,
+			"<span style=\"color:#808080\">Anti<span style=\"color:#33ffff\">body</span></span>",
+		},
+		{
+			"^1Anti^7body",
+			[]Option{WithBackground(BackgroundOnly)},
+			"<span style=\"color:#ffffff;background-color:#ff0000\">Anti<span style=\"color:#000000;background-color:#ffffff\">body</span></span>",
+		},
+		{
+			"^bF00Anti^iBody",
+			[]Option{WithDialect(&Dialect{Codes: []ExtCode{BackgroundHexCode("b"), StyleCode("i", Italic)}})},
+			"<span style=\"background-color:#ff0000\">Anti<span style=\"background-color:#ff0000;font-style:italic\">Body</span></span>",
+		},
+	}
+
+	for _, v := range canonicalList {
+		received := v.Input.HTML(append(v.Options, WithCanonical())...)
+		if received != v.Expected {
+			t.Errorf("Incorrect canonical HTML for %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestRendererOptions(t *testing.T) {
+	palette := XonoticPalette
+	palette[1] = NewRGBColorFrom255(200, 0, 0)
+
+	var optionList = []struct {
+		Input    QStr
+		Options  []Option
+		Expected template.HTML
+	}{
+		{
+			"^1Anti^2body",
+			[]Option{WithPalette(palette)},
+			"<span style=\"color:rgb(200,0,0)\">Anti<span style=\"color:rgb(51,255,0)\">body</span></span>",
+		},
+		{
+			"^x000Antibody",
+			[]Option{WithLightnessBounds(0.25, 0.75)},
+			"<span style=\"color:rgb(63,63,63)\">Antibody</span>",
+		},
+		{
+			"^1Anti^x444body",
+			[]Option{WithClassPrefix("qc")},
+			"<span class=\"qc1\">Anti<span style=\"color:rgb(127,127,127)\">body</span></span>",
+		},
+		{
+			"^1<b>",
+			[]Option{WithEscaping(nil)},
+			"<span style='color:rgb(255,0,0)'><b></span>",
+		},
+	}
+
+	for _, v := range optionList {
+		received := v.Input.HTML(v.Options...)
+		if received != v.Expected {
+			t.Errorf("Incorrect HTML for %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestHTMLClassFunc(t *testing.T) {
+	clanTag := func(seg Segment, index int) string {
+		if index == 0 {
+			return "clan"
+		}
+		return ""
+	}
+
+	var classList = []struct {
+		Input    QStr
+		Options  []Option
+		Expected template.HTML
+	}{
+		{
+			"^1[X]^x444Anti^5body",
+			nil,
+			"<span class=\"clan\" style='color:rgb(255,0,0)'>[X]</span><span style=\"color:rgb(127,127,127)\">Anti</span><span style='color:rgb(51,255,255)'>body</span>",
+		},
+		{
+			"[X] ^5Antibody",
+			nil,
+			"<span class=\"clan\">[X] </span><span style='color:rgb(51,255,255)'>Antibody</span>",
+		},
+		{
+			"^1[X]Antibody",
+			[]Option{WithClassPrefix("q-")},
+			"<span class=\"clan q-1\">[X]Antibody</span>",
+		},
+	}
+
+	for _, v := range classList {
+		received := v.Input.HTML(append(v.Options, WithClassFunc(clanTag))...)
+		if received != v.Expected {
+			t.Errorf("Incorrect HTML for %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestMinContrast(t *testing.T) {
+	white := RGBColor{1, 1, 1}
+	opt := WithMinContrast(white, ContrastAA)
+
+	nick := QStr("^3Anti^x444bo^0dy")
+	for _, c := range nick.Styled(opt) {
+		if ratio := ContrastRatio(c.Foreground, white); ratio < ContrastAA {
+			t.Errorf("Incorrect contrast of %c. Expected: at least %v, Got: %v.", c.Rune, ContrastAA, ratio)
+		}
+	}
+
+	dark := QStr("^x444body")
+	expected := template.HTML("<span style=\"color:rgb(68,68,68)\">body</span>")
+	if received := dark.HTML(opt); received != expected {
+		t.Errorf("Incorrect HTML value returned for %v. Expected: %v, Got: %v.", dark, expected, received)
+	}
+
+	yellow := QStr("^3body")
+	if received := yellow.HTML(opt); received == yellow.HTML() {
+		t.Errorf("Incorrect HTML value returned for %v. Expected a color other than in %v.", yellow, received)
+	}
+}
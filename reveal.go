@@ -0,0 +1,45 @@
+package qstr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// whitespace characters and the markers used to reveal them
+var revealMarkers = map[rune]string{
+	' ':  "·",
+	'\t': "→",
+	'\n': "↵",
+	'\r': "␍",
+}
+
+// reveal makes whitespace, invisible characters, and glyphs without a known
+// mapping visible by replacing them with markers.
+func reveal(text string) string {
+	var b strings.Builder
+	for _, c := range text {
+		if m, ok := revealMarkers[c]; ok {
+			b.WriteString(m)
+		} else if hiddenRune(c) {
+			fmt.Fprintf(&b, "\\u%04x", c)
+		} else {
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// hiddenRune reports whether c would not show up as a visible glyph: control
+// and format characters (which include zero-width and bidi marks), other
+// blank spaces, and private-use glyphs missing from the Xonotic decode key.
+func hiddenRune(c rune) bool {
+	if unicode.IsControl(c) || unicode.Is(unicode.Cf, c) || unicode.IsSpace(c) {
+		return true
+	}
+	if unicode.Is(unicode.Co, c) {
+		_, ok := XonoticDecodeKey[c]
+		return !ok
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package qstr
+
+import (
+	"html/template"
+	"testing"
+)
+
+func TestReveal(t *testing.T) {
+	nick := QStr("^1A n\ttibo\u200bdy\ue100")
+	expected := template.HTML("<span style='color:rgb(255,0,0)'>A·n→tibo\\u200bdy\\ue100</span>")
+	received := nick.HTML(WithReveal())
+
+	if received != expected {
+		t.Errorf("Incorrect HTML value returned. Expected: %v, Got: %v.", expected, received)
+	}
+
+	// mapped glyphs are left alone
+	nick = QStr("\ue0ff")
+	expected = template.HTML("\ue0ff")
+	if received = nick.HTML(WithReveal()); received != expected {
+		t.Errorf("Incorrect HTML value returned. Expected: %v, Got: %v.", expected, received)
+	}
+}
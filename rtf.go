@@ -0,0 +1,93 @@
+package qstr
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// RTF returns s as a Rich Text Format document, for exports opened in word
+// processors. Options may be given to alter the output; see Renderer.
+func (s *QStr) RTF(opts ...Option) string {
+	return NewRenderer(opts...).RTF(*s)
+}
+
+// RTF returns s as a minimal Rich Text Format document holding a color table
+// and one group per segment. Hex colors are capped to the theme's lightness
+// bounds, as in HTML output, so pass a light theme for printed pages, and
+// bold, italic, and underlined text from dialect codes is marked as such.
+// Text without a color uses the reader's default color. Characters outside
+// ASCII are written as \u escapes.
+func (r *Renderer) RTF(s QStr) string {
+	var colors []RGBColor
+	index := make(map[RGBColor]int)
+	var body strings.Builder
+	for _, seg := range r.dialect.Tokenize(s) {
+		text := r.text(seg.Text)
+		if text == "" {
+			continue
+		}
+
+		var words []string
+		if seg.Code != "" {
+			c := r.color(seg)
+			if seg.Code.IsHex() {
+				c = r.capLightness(c)
+			}
+			if _, ok := index[c]; !ok {
+				colors = append(colors, c)
+				// entry 0 of the table is the default color
+				index[c] = len(colors)
+			}
+			words = append(words, `\cf`+strconv.Itoa(index[c]))
+		}
+		for _, w := range []struct {
+			style Style
+			word  string
+		}{{Bold, `\b`}, {Italic, `\i`}, {Underline, `\ul`}} {
+			if seg.Style.Has(w.style) {
+				words = append(words, w.word)
+			}
+		}
+
+		if len(words) == 0 {
+			writeRTFText(&body, text)
+			continue
+		}
+		body.WriteString("{" + strings.Join(words, "") + " ")
+		writeRTFText(&body, text)
+		body.WriteString("}")
+	}
+
+	var b strings.Builder
+	b.WriteString(`{\rtf1\ansi\deff0{\colortbl;`)
+	for _, c := range colors {
+		b.WriteString(`\red` + strconv.Itoa(to255(c.R)) + `\green` + strconv.Itoa(to255(c.G)) + `\blue` + strconv.Itoa(to255(c.B)) + ";")
+	}
+	b.WriteString("}\n")
+	b.WriteString(body.String())
+	b.WriteString("}")
+	return b.String()
+}
+
+// writeRTFText writes text to b with the characters RTF treats specially
+// escaped
+func writeRTFText(b *strings.Builder, text string) {
+	for _, c := range text {
+		switch {
+		case c == '\\' || c == '{' || c == '}':
+			b.WriteByte('\\')
+			b.WriteRune(c)
+		case c == '\n':
+			b.WriteString(`\line `)
+		case c < 0x80:
+			b.WriteRune(c)
+		default:
+			// \u takes a signed 16-bit value, followed by a fallback
+			// character for readers that don't know it
+			for _, u := range utf16.Encode([]rune{c}) {
+				b.WriteString(`\u` + strconv.Itoa(int(int16(u))) + "?")
+			}
+		}
+	}
+}
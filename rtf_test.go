@@ -0,0 +1,24 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestRTF(t *testing.T) {
+	var rtfList = []struct {
+		Input    QStr
+		Options  []Option
+		Expected string
+	}{
+		{"^1Anti^x444body^1!", nil, "{\\rtf1\\ansi\\deff0{\\colortbl;\\red255\\green0\\blue0;\\red128\\green128\\blue128;}\n{\\cf1 Anti}{\\cf2 body}{\\cf1 !}}"},
+		{"{x}\\ ^^", nil, "{\\rtf1\\ansi\\deff0{\\colortbl;}\n\\{x\\}\\\\ ^}"},
+		{"é日😀", nil, "{\\rtf1\\ansi\\deff0{\\colortbl;}\n\\u233?\\u26085?\\u-10179?\\u-8704?}"},
+		{"^bAnti^1body", []Option{WithDialect(&Dialect{Codes: []ExtCode{StyleCode("b", Bold)}})}, "{\\rtf1\\ansi\\deff0{\\colortbl;\\red255\\green0\\blue0;}\n{\\b Anti}{\\cf1\\b body}}"},
+	}
+
+	for _, v := range rtfList {
+		if received := v.Input.RTF(v.Options...); received != v.Expected {
+			t.Errorf("Incorrect RTF for %q. Expected: %q, Got: %q.", v.Input, v.Expected, received)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package qstr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrForbiddenChar is returned by Sanitize under RejectForbidden when s holds
+// a forbidden character.
+var ErrForbiddenChar = errors.New("qstr: forbidden character")
+
+// ForbiddenPolicy selects what Sanitize does with forbidden characters.
+type ForbiddenPolicy int
+
+const (
+	// StripForbidden removes forbidden characters.
+	StripForbidden ForbiddenPolicy = iota
+
+	// ReplaceForbidden replaces each forbidden character, or each byte of
+	// invalid UTF-8, with U+FFFD.
+	ReplaceForbidden
+
+	// RejectForbidden returns ErrForbiddenChar for the first forbidden
+	// character.
+	RejectForbidden
+)
+
+// forbidden reports whether c may not appear in a name
+func forbidden(c rune) bool {
+	return c < 0x20 || c == 0x7f
+}
+
+// SanitizeOption adds characters for Sanitize to treat as forbidden.
+type SanitizeOption func(*sanitizeConfig)
+
+type sanitizeConfig struct {
+	c1, zeroWidth, bidi, glyphs bool
+}
+
+// ForbidC1 forbids the C1 control characters, U+0080 to U+009F.
+func ForbidC1() SanitizeOption {
+	return func(c *sanitizeConfig) {
+		c.c1 = true
+	}
+}
+
+// ForbidZeroWidth forbids zero-width characters, such as the zero-width
+// space and joiners, the word joiner, and the byte order mark, which players
+// use to make a name look like another. Forbidding the zero-width joiner
+// also breaks up emoji sequences.
+func ForbidZeroWidth() SanitizeOption {
+	return func(c *sanitizeConfig) {
+		c.zeroWidth = true
+	}
+}
+
+// ForbidBidi forbids the explicit bidirectional formatting characters and
+// marks, which can reorder the text around a name in HTML layouts.
+func ForbidBidi() SanitizeOption {
+	return func(c *sanitizeConfig) {
+		c.bidi = true
+	}
+}
+
+// ForbidUnassignedGlyphs forbids the private-use characters that are not
+// glyphs of the Xonotic font, which show up as boxes or not at all.
+func ForbidUnassignedGlyphs() SanitizeOption {
+	return func(c *sanitizeConfig) {
+		c.glyphs = true
+	}
+}
+
+// ForbidInvisible forbids all the characters of ForbidC1, ForbidZeroWidth,
+// ForbidBidi, and ForbidUnassignedGlyphs.
+func ForbidInvisible() SanitizeOption {
+	return func(c *sanitizeConfig) {
+		*c = sanitizeConfig{c1: true, zeroWidth: true, bidi: true, glyphs: true}
+	}
+}
+
+// forbidden reports whether r may not appear in a name under c
+func (c *sanitizeConfig) forbidden(r rune) bool {
+	switch {
+	case forbidden(r):
+		return true
+	case c.c1 && 0x80 <= r && r <= 0x9f:
+		return true
+	case c.zeroWidth && (('\u200b' <= r && r <= '\u200d') || r == '\u2060' || r == '\ufeff' || r == '\u180e'):
+		return true
+	case c.bidi && (isBidiControl(r) || r == '\u200e' || r == '\u200f' || r == '\u061c'):
+		return true
+	case c.glyphs && unicode.Is(unicode.Co, r):
+		_, ok := XonoticDecodeKey[r]
+		return !ok
+	}
+	return false
+}
+
+// clean reports whether text holds no forbidden characters under c and no
+// invalid UTF-8.
+func (c *sanitizeConfig) clean(text string) bool {
+	for i := 0; i < len(text); {
+		r, n := utf8.DecodeRuneInString(text[i:])
+		if c.forbidden(r) || (r == utf8.RuneError && n == 1) {
+			return false
+		}
+		i += n
+	}
+	return true
+}
+
+// Sanitize handles the characters that the game protocol never delivers
+// intact and that must not reach HTML attributes or database parameters:
+// NUL and the other C0 control characters, including newlines and tabs, DEL,
+// and bytes that aren't valid UTF-8, as can show up in names read from raw
+// packets. What happens to them is chosen by policy. All other characters
+// and the colors of the text are left alone. Values holding forbidden
+// characters are rebuilt from their segments, escaping carets as needed, so
+// that removing a character never joins a caret and a digit into a new
+// code; redundant codes may be dropped in the process. Under StripForbidden
+// and ReplaceForbidden the error is always nil. Options may be given to
+// forbid more characters, such as invisible ones, which are then handled
+// the same way.
+func (s *QStr) Sanitize(policy ForbiddenPolicy, opts ...SanitizeOption) (QStr, error) {
+	c := &sanitizeConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	raw := string(*s)
+
+	// most names are clean, so avoid copying them
+	if c.clean(raw) {
+		return *s, nil
+	}
+
+	if policy == RejectForbidden {
+		for i := 0; i < len(raw); {
+			r, n := utf8.DecodeRuneInString(raw[i:])
+			if c.forbidden(r) || (r == utf8.RuneError && n == 1) {
+				return "", fmt.Errorf("%w: %q at offset %d", ErrForbiddenChar, raw[i:i+n], i)
+			}
+			i += n
+		}
+	}
+
+	segments, _ := sanitizeSegments(DarkPlaces.Tokenize(*s), c, policy)
+	return joinSegments(segments), nil
+}
+
+// sanitizeSegments handles the forbidden characters of the text of segments
+// under policy. Under RejectForbidden, no segments are left if any are
+// found.
+func sanitizeSegments(segments []Segment, c *sanitizeConfig, policy ForbiddenPolicy) ([]Segment, bool) {
+	changed := false
+	for i, seg := range segments {
+		if c.clean(seg.Text) {
+			continue
+		}
+		if policy == RejectForbidden {
+			return segments[:0], true
+		}
+
+		var b strings.Builder
+		b.Grow(len(seg.Text))
+		for j := 0; j < len(seg.Text); {
+			r, n := utf8.DecodeRuneInString(seg.Text[j:])
+			switch {
+			case !c.forbidden(r) && (r != utf8.RuneError || n > 1):
+				b.WriteString(seg.Text[j : j+n])
+			case policy == ReplaceForbidden:
+				b.WriteRune(utf8.RuneError)
+			}
+			j += n
+		}
+		segments[i].Text = b.String()
+		changed = true
+	}
+	return segments, changed
+}
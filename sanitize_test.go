@@ -0,0 +1,67 @@
+package qstr
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	var sanitizeList = []struct {
+		Input    QStr
+		Strip    QStr
+		Replace  QStr
+		Rejected bool
+	}{
+		{"^1Anti^x444body", "^1Anti^x444body", "^1Anti^x444body", false},
+		{"^1Anti\x00body", "^1Antibody", "^1Anti�body", true},
+		{"a\nb\tc\x7f", "abc", "a�b�c�", true},
+		{"bad\xff\xfe", "bad", "bad��", true},
+		{"� 日本", "� 日本", "� 日本", false},
+		{"", "", "", false},
+		{"^\x001abc", "^^1abc", "^\ufffd1abc", true},
+	}
+
+	for _, v := range sanitizeList {
+		if received, err := v.Input.Sanitize(StripForbidden); received != v.Strip || err != nil {
+			t.Errorf("Incorrect stripping of %q. Expected: %q, Got: %q (%v).", v.Input, v.Strip, received, err)
+		}
+		if received, err := v.Input.Sanitize(ReplaceForbidden); received != v.Replace || err != nil {
+			t.Errorf("Incorrect replacing in %q. Expected: %q, Got: %q (%v).", v.Input, v.Replace, received, err)
+		}
+
+		received, err := v.Input.Sanitize(RejectForbidden)
+		if v.Rejected && !errors.Is(err, ErrForbiddenChar) {
+			t.Errorf("Incorrect error for %q. Expected: %v, Got: %v.", v.Input, ErrForbiddenChar, err)
+		}
+		if !v.Rejected && (err != nil || received != v.Input) {
+			t.Errorf("Incorrect rejecting of %q. Expected: %q, Got: %q (%v).", v.Input, v.Input, received, err)
+		}
+	}
+}
+
+func TestSanitizeOptions(t *testing.T) {
+	var sanitizeList = []struct {
+		Input    QStr
+		Opts     []SanitizeOption
+		Expected QStr
+	}{
+		{"^1A\u0085nti", nil, "^1A\u0085nti"},
+		{"^1A\u0085nti", []SanitizeOption{ForbidC1()}, "^1Anti"},
+		{"^1An\u200bti^2bo\ufeffdy", []SanitizeOption{ForbidZeroWidth()}, "^1Anti^2body"},
+		{"\u202eydobitnA\u202c", []SanitizeOption{ForbidBidi()}, "ydobitnA"},
+		{"\u200fAnti", []SanitizeOption{ForbidZeroWidth()}, "\u200fAnti"},
+		{"\uf8ffAnti\ue041", []SanitizeOption{ForbidUnassignedGlyphs()}, "Anti\ue041"},
+		{"^1\u0085\u200b\u202e\uf8ffAnti", []SanitizeOption{ForbidInvisible()}, "^1Anti"},
+	}
+
+	for _, v := range sanitizeList {
+		if received, err := v.Input.Sanitize(StripForbidden, v.Opts...); received != v.Expected || err != nil {
+			t.Errorf("Incorrect sanitizing of %q. Expected: %q, Got: %q (%v).", v.Input, v.Expected, received, err)
+		}
+	}
+
+	s := QStr("A\u200bnti")
+	if _, err := s.Sanitize(RejectForbidden, ForbidZeroWidth()); !errors.Is(err, ErrForbiddenChar) {
+		t.Errorf("Incorrect error for %q. Expected: %v, Got: %v.", s, ErrForbiddenChar, err)
+	}
+}
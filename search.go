@@ -0,0 +1,77 @@
+package qstr
+
+import (
+	"strings"
+)
+
+// Contains reports whether substr is within the visible text of s.
+func (s *QStr) Contains(substr string) bool {
+	return strings.Contains(s.Stripped(), substr)
+}
+
+// HasPrefix reports whether the visible text of s begins with prefix.
+func (s *QStr) HasPrefix(prefix string) bool {
+	return strings.HasPrefix(s.Stripped(), prefix)
+}
+
+// HasSuffix reports whether the visible text of s ends with suffix.
+func (s *QStr) HasSuffix(suffix string) bool {
+	return strings.HasSuffix(s.Stripped(), suffix)
+}
+
+// Index returns the offset in s, codes included, of the first instance of
+// substr in the visible text of s, or -1 if substr is not present.
+func (s *QStr) Index(substr string) int {
+	start, _ := s.IndexRange(substr)
+	return start
+}
+
+// IndexRange returns the range of s, codes included, covering the first
+// instance of substr in the visible text of s, so that s[start:end] holds
+// the match along with any codes within it. Both are -1 if substr is not
+// present. An empty substr is found at the start of the visible text.
+func (s *QStr) IndexRange(substr string) (start, end int) {
+	raw := string(*s)
+	offsets := visibleOffsets(raw)
+	text := s.Stripped()
+	i := strings.Index(text, substr)
+	if i < 0 {
+		return -1, -1
+	}
+	if substr == "" {
+		return offsets[i], offsets[i]
+	}
+
+	last := offsets[i+len(substr)-1]
+	end = last + 1
+	if strings.HasPrefix(raw[last:], "^^") {
+		end++
+	}
+	return offsets[i], end
+}
+
+// visibleOffsets returns the offset in raw of each byte of its visible text,
+// followed by the offset where the codes after the last byte end. A caret
+// shown by a ^^ escape is placed at the start of the escape.
+func visibleOffsets(raw string) []int {
+	offsets := make([]int, 0, len(raw)+1)
+	for i := 0; i < len(raw); {
+		if raw[i] != '^' {
+			offsets = append(offsets, i)
+			i++
+			continue
+		}
+		if i+1 < len(raw) && raw[i+1] == '^' {
+			offsets = append(offsets, i)
+			i += 2
+			continue
+		}
+		if n := colorCodeLen(raw[i+1:]); n > 0 {
+			i += 1 + n
+			continue
+		}
+		offsets = append(offsets, i)
+		i++
+	}
+	return append(offsets, len(raw))
+}
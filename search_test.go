@@ -0,0 +1,44 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	var searchList = []struct {
+		Input      QStr
+		Substr     string
+		Start, End int
+	}{
+		{"^1Anti^x4afbody", "ibody", 5, 15},
+		{"^1Anti^x4afbody", "Anti", 2, 6},
+		{"^1Anti^x4afbody", "x4af", -1, -1},
+		{"^1A^^1b^2c", "^1b", 3, 7},
+		{"^1A^^1b^2c", "A^", 2, 5},
+		{"^1Anti", "", 2, 2},
+		{"", "", 0, 0},
+	}
+
+	for _, v := range searchList {
+		start, end := v.Input.IndexRange(v.Substr)
+		if start != v.Start || end != v.End {
+			t.Errorf("Incorrect range of %q in %q. Expected: [%v, %v), Got: [%v, %v).", v.Substr, v.Input, v.Start, v.End, start, end)
+		}
+		if index := v.Input.Index(v.Substr); index != v.Start {
+			t.Errorf("Incorrect index of %q in %q. Expected: %v, Got: %v.", v.Substr, v.Input, v.Start, index)
+		}
+		if contains := v.Input.Contains(v.Substr); contains != (v.Start >= 0) {
+			t.Errorf("Incorrect containment of %q in %q. Expected: %v, Got: %v.", v.Substr, v.Input, v.Start >= 0, contains)
+		}
+	}
+}
+
+func TestHasPrefixSuffix(t *testing.T) {
+	nick := QStr("^1Anti^x4afbody^7")
+	if !nick.HasPrefix("Anti") || nick.HasPrefix("^1") {
+		t.Errorf("Incorrect prefix check of %q.", nick)
+	}
+	if !nick.HasSuffix("ibody") || nick.HasSuffix("^7") {
+		t.Errorf("Incorrect suffix check of %q.", nick)
+	}
+}
@@ -0,0 +1,95 @@
+package qstr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// the character introducing Minecraft-style formatting codes
+const sectionSign = '§'
+
+// sectionColors holds the 16 colors of the Minecraft-style §N codes
+var sectionColors = [16]RGBColor{
+	NewRGBColorFrom255(0, 0, 0),
+	NewRGBColorFrom255(0, 0, 170),
+	NewRGBColorFrom255(0, 170, 0),
+	NewRGBColorFrom255(0, 170, 170),
+	NewRGBColorFrom255(170, 0, 0),
+	NewRGBColorFrom255(170, 0, 170),
+	NewRGBColorFrom255(255, 170, 0),
+	NewRGBColorFrom255(170, 170, 170),
+	NewRGBColorFrom255(85, 85, 85),
+	NewRGBColorFrom255(85, 85, 255),
+	NewRGBColorFrom255(85, 255, 85),
+	NewRGBColorFrom255(85, 255, 255),
+	NewRGBColorFrom255(255, 85, 85),
+	NewRGBColorFrom255(255, 85, 255),
+	NewRGBColorFrom255(255, 255, 85),
+	NewRGBColorFrom255(255, 255, 255),
+}
+
+// SectionCodes returns s with its color codes converted into Minecraft-style
+// §N codes, each color mapped onto the nearest of the 16 available. Text
+// returning to the default color is preceded by §r. Options may be given to
+// take the colors of the basic codes from another palette; see WithPalette.
+func (s *QStr) SectionCodes(opts ...Option) string {
+	palette := NewRenderer(opts...).theme.Palette
+	var b strings.Builder
+	colored := false
+	for _, seg := range DarkPlaces.Tokenize(*s) {
+		if seg.Code != "" {
+			fmt.Fprintf(&b, "%c%x", sectionSign, nearestColor(palette.color(seg), sectionColors[:]))
+			colored = true
+		} else if colored {
+			fmt.Fprintf(&b, "%cr", sectionSign)
+			colored = false
+		}
+		b.WriteString(seg.Text)
+	}
+	return b.String()
+}
+
+// FromSectionCodes converts the Minecraft-style §N codes in s into the
+// nearest of the basic ^0 through ^9 color codes. §r returns to the default
+// color, and the formatting codes §k through §o are dropped. Carets in the
+// text are escaped where they would otherwise start a code. Options may be
+// given to match colors against another palette; see WithPalette.
+func FromSectionCodes(s string, opts ...Option) QStr {
+	palette := NewRenderer(opts...).theme.Palette
+	var b strings.Builder
+	// literal text is escaped as a whole once it is known whether a code
+	// follows, so that its carets can't form codes
+	var literal strings.Builder
+	code := func(code string) {
+		b.WriteString(DarkPlaces.escape(literal.String(), true))
+		literal.Reset()
+		b.WriteString(code)
+	}
+	for i := 0; i < len(s); {
+		c, size := utf8.DecodeRuneInString(s[i:])
+		if c != sectionSign || i+size >= len(s) {
+			literal.WriteString(s[i : i+size])
+			i += size
+			continue
+		}
+
+		letter := s[i+size]
+		switch {
+		case isHexDigit(letter):
+			n, _ := strconv.ParseUint(string(letter), 16, 8)
+			code(fmt.Sprintf("^%d", nearestColor(sectionColors[n], palette[:])))
+		case letter == 'r' || letter == 'R':
+			code(resetCode)
+		case ('k' <= letter && letter <= 'o') || ('K' <= letter && letter <= 'O'):
+		default:
+			literal.WriteString(s[i : i+size])
+			i += size
+			continue
+		}
+		i += size + 1
+	}
+	b.WriteString(DarkPlaces.escape(literal.String(), false))
+	return QStr(b.String())
+}
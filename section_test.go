@@ -0,0 +1,56 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestSectionCodes(t *testing.T) {
+	var sectionList = []struct {
+		Input    QStr
+		Expected string
+	}{
+		{"^1Anti^x444body", "§4Anti§8body"},
+		{"^7Anti^3body", "§fAnti§ebody"},
+		{"plain^4blue^7", "plain§9blue"},
+		{"^^1Anti", "^1Anti"},
+	}
+
+	for _, v := range sectionList {
+		received := v.Input.SectionCodes()
+		if received != v.Expected {
+			t.Errorf("Incorrect section codes for %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+
+	nick := QStr("^4Anti^7body")
+	if received, expected := nick.SectionCodes(WithPalette(Quake3Palette)), "§1Anti§fbody"; received != expected {
+		t.Errorf("Incorrect section codes for %v with the Quake 3 palette. Expected: %v, Got: %v.", nick, expected, received)
+	}
+}
+
+func TestFromSectionCodes(t *testing.T) {
+	var sectionList = []struct {
+		Input    string
+		Expected QStr
+	}{
+		{"§4Anti§Fbody", "^1Anti^7body"},
+		{"§l§4bold§r plain", "^1bold^7 plain"},
+		{"§zodd §", "§zodd §"},
+		{"§aé", "^2é"},
+		{"^1Anti§4^", "^^1Anti^1^"},
+		{"§\x12Anti§\x0fbody", "§\x12Anti§\x0fbody"},
+		{"§LAnti§Rbody", "Anti^7body"},
+	}
+
+	for _, v := range sectionList {
+		received := FromSectionCodes(v.Input)
+		if received != v.Expected {
+			t.Errorf("Incorrect conversion of %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+
+	text := "§3Anti§cbody"
+	if received, expected := FromSectionCodes(text, WithPalette(Quake3Palette)), QStr("^5Anti^1body"); received != expected {
+		t.Errorf("Incorrect conversion of %v with the Quake 3 palette. Expected: %q, Got: %q.", text, expected, received)
+	}
+}
@@ -0,0 +1,170 @@
+package qstr
+
+import (
+	"iter"
+	"strings"
+)
+
+// Segment is a run of text within a QStr that shares the same color and
+// attributes.
+type Segment struct {
+	// Text is the visible text of the segment, without any color codes
+	Text string
+
+	// Code is the color code in effect for the segment, such as "^1" or
+	// "^x4af". It is always written with a ^, whatever the dialect's caret,
+	// and is empty if no color code has been seen yet.
+	Code Code
+
+	// Color is the color given by Code
+	Color RGBColor
+
+	// Background is the background color set by a dialect extension code.
+	// It is only meaningful when HasBackground is true.
+	Background    RGBColor
+	HasBackground bool
+
+	// Style holds the formatting attributes set by dialect extension codes
+	Style Style
+}
+
+// Style is a set of formatting attributes applied to a Segment.
+type Style uint8
+
+// The formatting attributes a Style may hold
+const (
+	Bold Style = 1 << iota
+	Italic
+	Underline
+	Blink
+)
+
+// Has reports whether all of the attributes in flags are set.
+func (s Style) Has(flags Style) bool {
+	return s&flags == flags
+}
+
+// Tokenize breaks s into its colored segments, so applications can build
+// their own renderers without re-implementing the color code grammar. Only
+// the basic ^N and ^xNNN codes are recognized; use Dialect.Tokenize for
+// other dialects. Text before the first color code has an empty Code.
+func (s *QStr) Tokenize() []Segment {
+	return DarkPlaces.Tokenize(*s)
+}
+
+// Segments returns an iterator over the colored segments of s, as returned
+// by Tokenize. Segments are produced one at a time as s is scanned, so
+// stopping early skips the rest of the string.
+func (s *QStr) Segments() iter.Seq[Segment] {
+	return DarkPlaces.Segments(*s)
+}
+
+// styled reports whether the segment carries any color or attribute.
+func (seg *Segment) styled() bool {
+	return seg.Code != "" || seg.HasBackground || seg.Style != 0
+}
+
+// segmentsText returns the visible text of segments
+func segmentsText(segments []Segment) string {
+	var b strings.Builder
+	for _, seg := range segments {
+		b.WriteString(seg.Text)
+	}
+	return b.String()
+}
+
+// joinSegments reassembles segments into a QStr in the default dialect.
+func joinSegments(segments []Segment) QStr {
+	return DarkPlaces.Join(segments)
+}
+
+// splitSegments splits segments so that a new segment begins at each of the
+// given byte offsets into the visible text. Offsets must be sorted.
+func splitSegments(segments []Segment, offsets []int) []Segment {
+	res := make([]Segment, 0, len(segments)+len(offsets))
+	pos := 0
+	for _, seg := range segments {
+		end := pos + len(seg.Text)
+		for len(offsets) > 0 && offsets[0] <= pos {
+			offsets = offsets[1:]
+		}
+		for len(offsets) > 0 && offsets[0] < end {
+			n := offsets[0] - pos
+			head := seg
+			head.Text = seg.Text[:n]
+			res = append(res, head)
+			seg.Text = seg.Text[n:]
+			pos += n
+			offsets = offsets[1:]
+		}
+		res = append(res, seg)
+		pos = end
+	}
+	return res
+}
+
+// sliceSegments returns the segments covering the visible characters in the
+// range [start, end). Characters are counted in grapheme clusters, so an
+// emoji sequence or a letter with combining marks is never split apart.
+func sliceSegments(segments []Segment, start, end int) []Segment {
+	res := make([]Segment, 0, len(segments))
+	pos := 0
+	for _, seg := range segments {
+		clusters := graphemes(seg.Text)
+		segStart, segEnd := pos, pos+len(clusters)
+		pos = segEnd
+
+		lo, hi := max(start, segStart), min(end, segEnd)
+		if lo >= hi {
+			continue
+		}
+		seg.Text = strings.Join(clusters[lo-segStart:hi-segStart], "")
+		res = append(res, seg)
+	}
+	return res
+}
+
+// byteRangeSegments returns the segments covering the bytes of the visible
+// text in the range [start, end).
+func byteRangeSegments(segments []Segment, start, end int) []Segment {
+	res := make([]Segment, 0, len(segments))
+	pos := 0
+	for _, seg := range segments {
+		segStart, segEnd := pos, pos+len(seg.Text)
+		pos = segEnd
+
+		lo, hi := max(start, segStart), min(end, segEnd)
+		if lo >= hi {
+			continue
+		}
+		seg.Text = seg.Text[lo-segStart : hi-segStart]
+		res = append(res, seg)
+	}
+	return res
+}
+
+// segmentAt returns the segment holding the byte of the visible text at
+// offset off, or the last segment if off is past the end.
+func segmentAt(segments []Segment, off int) Segment {
+	pos := 0
+	for _, seg := range segments {
+		pos += len(seg.Text)
+		if off < pos {
+			return seg
+		}
+	}
+	if len(segments) == 0 {
+		return Segment{}
+	}
+	return segments[len(segments)-1]
+}
+
+// visibleLen returns the number of visible characters in segments, counted
+// in grapheme clusters.
+func visibleLen(segments []Segment) int {
+	n := 0
+	for _, seg := range segments {
+		n += graphemeCount(seg.Text)
+	}
+	return n
+}
@@ -0,0 +1,43 @@
+package qstr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQStrTokenize(t *testing.T) {
+	nick := QStr("A^1nti^x444bo^^dy^7")
+	expected := []Segment{
+		{Text: "A"},
+		{Text: "nti", Code: "^1", Color: RGBColor{1, 0, 0}},
+		{Text: "bo^dy", Code: "^x444", Color: HexToRGB("4", "4", "4")},
+	}
+	received := nick.Tokenize()
+
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("Incorrect tokenization of %v. Expected: %+v, Got: %+v.", nick, expected, received)
+	}
+}
+
+func TestQStrSegments(t *testing.T) {
+	nick := QStr("A^1nti^x444bo^^dy^7")
+	var received []Segment
+	for seg := range nick.Segments() {
+		received = append(received, seg)
+	}
+	if expected := nick.Tokenize(); !reflect.DeepEqual(received, expected) {
+		t.Errorf("Incorrect segments of %v. Expected: %+v, Got: %+v.", nick, expected, received)
+	}
+
+	// stopping early must not yield any further segments
+	received = nil
+	for seg := range nick.Segments() {
+		received = append(received, seg)
+		if len(received) == 2 {
+			break
+		}
+	}
+	if len(received) != 2 || received[1].Text != "nti" {
+		t.Errorf("Incorrect segments after break of %v. Got: %+v.", nick, received)
+	}
+}
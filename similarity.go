@@ -0,0 +1,85 @@
+package qstr
+
+// the common prefix length and scaling factor of the Winkler adjustment
+const (
+	winklerPrefix = 4
+	winklerScale  = 0.1
+)
+
+// Similarity returns how alike the names a and b are, from 0 for nothing in
+// common to 1 for the same name. The Jaro-Winkler similarity of their
+// visible text is used, ignoring colors, case, glyph choice, and invisible
+// characters, so a player restyling their name still scores close to 1.
+func Similarity(a, b QStr) float64 {
+	return jaroWinkler([]rune(normalizedKey(a)), []rune(normalizedKey(b)))
+}
+
+// FindClosest returns the index of the candidate most similar to target,
+// along with its Similarity. Ties go to the earliest candidate. The index is
+// -1 if there are no candidates.
+func FindClosest(target QStr, candidates []QStr) (int, float64) {
+	key := []rune(normalizedKey(target))
+	best, bestScore := -1, 0.0
+	for i, c := range candidates {
+		score := jaroWinkler(key, []rune(normalizedKey(c)))
+		if best < 0 || score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best, bestScore
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of a and b
+func jaroWinkler(a, b []rune) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	// characters match when equal and no further apart than window
+	window := max(len(a), len(b))/2 - 1
+	window = max(window, 0)
+	matchedA := make([]bool, len(a))
+	matchedB := make([]bool, len(b))
+	matches := 0
+	for i := range a {
+		lo, hi := max(0, i-window), min(len(b), i+window+1)
+		for j := lo; j < hi; j++ {
+			if !matchedB[j] && a[i] == b[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	// half the matched characters that are out of order
+	transpositions := 0
+	j := 0
+	for i := range a {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if a[i] != b[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(winklerPrefix, len(a), len(b)) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*winklerScale*(1-jaro)
+}
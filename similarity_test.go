@@ -0,0 +1,40 @@
+package qstr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSimilarity(t *testing.T) {
+	var similarityList = []struct {
+		A, B     QStr
+		Expected float64
+	}{
+		{"^1Antibody", "^x4afantibody", 1},
+		{"MARTHA", "MARHTA", 0.961},
+		{"DIXON", "DICKSONX", 0.813},
+		{"^1abc", "^2xyz", 0},
+		{"", "", 1},
+		{"", "^1a", 0},
+	}
+
+	for _, v := range similarityList {
+		received := Similarity(v.A, v.B)
+		if math.Abs(received-v.Expected) > 0.001 {
+			t.Errorf("Incorrect similarity of %q and %q. Expected: %v, Got: %v.", v.A, v.B, v.Expected, received)
+		}
+		if reverse := Similarity(v.B, v.A); math.Abs(reverse-received) > 1e-9 {
+			t.Errorf("Incorrect similarity of %q and %q. Expected: %v, Got: %v.", v.B, v.A, received, reverse)
+		}
+	}
+}
+
+func TestFindClosest(t *testing.T) {
+	candidates := []QStr{"^2Someone", "^1Anti^7body", "^x444Antibodies"}
+	if i, score := FindClosest("^x4afANTIBODY", candidates); i != 1 || score != 1 {
+		t.Errorf("Incorrect closest match. Expected: %v (%v), Got: %v (%v).", 1, 1.0, i, score)
+	}
+	if i, _ := FindClosest("^1Antibody", nil); i != -1 {
+		t.Errorf("Incorrect closest match without candidates. Expected: %v, Got: %v.", -1, i)
+	}
+}
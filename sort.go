@@ -0,0 +1,52 @@
+package qstr
+
+import (
+	"sort"
+	"strings"
+)
+
+// sortKey returns the text s is ordered by: its visible text with Xonotic
+// glyphs decoded and case folded.
+func sortKey(s QStr) string {
+	c := compareConfig{key: XonoticDecodeKey}
+	return strings.ToLower(c.text(s))
+}
+
+// Compare returns an integer comparing the visible text of a and b, with
+// Xonotic glyphs decoded and case ignored. The result is 0 if they compare
+// equally, -1 if a sorts before b, and +1 otherwise. The text is ordered
+// by code point; use the collation subpackage for the rules of a language.
+func Compare(a, b QStr) int {
+	return strings.Compare(sortKey(a), sortKey(b))
+}
+
+// SortSlice sorts nicks in place in the order given by Compare. Values that
+// compare equally keep their original order, and every value keeps its
+// color codes.
+func SortSlice(nicks []QStr) {
+	keys := make([]string, len(nicks))
+	for i, nick := range nicks {
+		keys[i] = sortKey(nick)
+	}
+
+	sort.Stable(byKey{nicks: nicks, keys: keys})
+}
+
+// byKey sorts nicks by their precomputed sort keys
+type byKey struct {
+	nicks []QStr
+	keys  []string
+}
+
+func (s byKey) Len() int {
+	return len(s.nicks)
+}
+
+func (s byKey) Less(i, j int) bool {
+	return s.keys[i] < s.keys[j]
+}
+
+func (s byKey) Swap(i, j int) {
+	s.nicks[i], s.nicks[j] = s.nicks[j], s.nicks[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
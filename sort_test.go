@@ -0,0 +1,34 @@
+package qstr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	var compareList = []struct {
+		A, B     QStr
+		Expected int
+	}{
+		{"^1Foo", "^x400foo", 0},
+		{"^x400Zed", "^1alpha", 1},
+		{"alpha", "^xfffBeta", -1},
+		{"\ue06fmega", "^2Omega", 0},
+	}
+
+	for _, v := range compareList {
+		if received := Compare(v.A, v.B); received != v.Expected {
+			t.Errorf("Incorrect comparison of %q and %q. Expected: %v, Got: %v.", v.A, v.B, v.Expected, received)
+		}
+	}
+}
+
+func TestSortSlice(t *testing.T) {
+	nicks := []QStr{"^x400Zed", "^1alpha", "^xfffBeta", "^2ALPHA", "carol"}
+	expected := []QStr{"^1alpha", "^2ALPHA", "^xfffBeta", "carol", "^x400Zed"}
+	SortSlice(nicks)
+
+	if !reflect.DeepEqual(nicks, expected) {
+		t.Errorf("Incorrect sort order. Expected: %v, Got: %v.", expected, nicks)
+	}
+}
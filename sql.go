@@ -0,0 +1,43 @@
+package qstr
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Value stores s as its raw string. It implements driver.Valuer.
+func (s QStr) Value() (driver.Value, error) {
+	return string(s), nil
+}
+
+// Scan reads a QStr from a string or []byte column. NULL reads as the empty
+// string. It implements sql.Scanner.
+func (s *QStr) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		*s = QStr(v)
+	case []byte:
+		*s = QStr(v)
+	case nil:
+		*s = ""
+	default:
+		return fmt.Errorf("qstr: cannot scan %T into QStr", src)
+	}
+	return nil
+}
+
+// NormalizedSQL is a QStr that is stored in normalized form, without
+// redundant color codes, so that equal-looking names compare equal in the
+// database. See Normalize.
+type NormalizedSQL QStr
+
+// Value stores s normalized. It implements driver.Valuer.
+func (s NormalizedSQL) Value() (driver.Value, error) {
+	q := QStr(s)
+	return string(q.Normalize()), nil
+}
+
+// Scan reads a QStr as QStr.Scan does. It implements sql.Scanner.
+func (s *NormalizedSQL) Scan(src interface{}) error {
+	return (*QStr)(s).Scan(src)
+}
@@ -0,0 +1,51 @@
+package qstr
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+var (
+	_ driver.Valuer = QStr("")
+	_ sql.Scanner   = (*QStr)(nil)
+	_ driver.Valuer = NormalizedSQL("")
+	_ sql.Scanner   = (*NormalizedSQL)(nil)
+)
+
+func TestSQLValue(t *testing.T) {
+	nick := QStr("^1^1Anti^1body")
+	if v, err := nick.Value(); err != nil || v != "^1^1Anti^1body" {
+		t.Errorf("Incorrect value of %v. Expected: %v, Got: %v (%v).", nick, "^1^1Anti^1body", v, err)
+	}
+	if v, err := NormalizedSQL(nick).Value(); err != nil || v != "^1Antibody" {
+		t.Errorf("Incorrect normalized value of %v. Expected: %v, Got: %v (%v).", nick, "^1Antibody", v, err)
+	}
+}
+
+func TestSQLScan(t *testing.T) {
+	var scanList = []struct {
+		Src      interface{}
+		Expected QStr
+	}{
+		{"^1Anti", "^1Anti"},
+		{[]byte("^x4afbody"), "^x4afbody"},
+		{nil, ""},
+	}
+
+	for _, v := range scanList {
+		s := QStr("old")
+		if err := s.Scan(v.Src); err != nil || s != v.Expected {
+			t.Errorf("Incorrect scan of %v. Expected: %v, Got: %v (%v).", v.Src, v.Expected, s, err)
+		}
+		n := NormalizedSQL("old")
+		if err := n.Scan(v.Src); err != nil || QStr(n) != v.Expected {
+			t.Errorf("Incorrect scan of %v. Expected: %v, Got: %v (%v).", v.Src, v.Expected, n, err)
+		}
+	}
+
+	var s QStr
+	if err := s.Scan(42); err == nil {
+		t.Errorf("Incorrect error scanning 42. Expected an error, Got: nil.")
+	}
+}
@@ -0,0 +1,287 @@
+package qstr
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// the longest code recognized when streaming, and so the number of bytes held
+// back after a caret when the dialect has extension codes, whose length can't
+// be known until they are complete
+const streamHold = 16
+
+// StreamWriter converts the color codes of text written to it on the fly and
+// passes the result on to an underlying writer. Codes split across calls to
+// Write are handled. Since console output is line-oriented, the color is
+// reset at every newline. Extension codes longer than 16 bytes are not
+// recognized. Call Close once done to flush the remaining output.
+type StreamWriter struct {
+	w io.Writer
+	r *Renderer
+
+	// how segments are opened, closed, and written
+	open  func(seg Segment) string
+	close string
+	text  func(b *strings.Builder, text string)
+
+	// input held back until it can be converted, the current state, and
+	// whether the state changed since it was last written
+	pending []byte
+	state   Segment
+	dirty   bool
+	styled  bool
+
+	err error
+}
+
+// NewHTMLWriter returns a StreamWriter converting color codes into HTML
+// spans, as Renderer.HTML does, and writing the result to w. Wrapper
+// elements and links are not supported when streaming.
+func NewHTMLWriter(w io.Writer, opts ...Option) *StreamWriter {
+	r := NewRenderer(opts...)
+	return &StreamWriter{
+		w: w,
+		r: r,
+		open: func(seg Segment) string {
+			return r.retag(r.openSpan(seg))
+		},
+		close: "</" + r.tag + ">",
+		text: func(b *strings.Builder, text string) {
+			r.writeText(b, text)
+		},
+	}
+}
+
+// NewANSIWriter returns a StreamWriter converting color codes into ANSI SGR
+// escape sequences, as Renderer.ANSI does, and writing the result to w.
+func NewANSIWriter(w io.Writer, opts ...Option) *StreamWriter {
+	r := NewRenderer(opts...)
+	return &StreamWriter{
+		w: w,
+		r: r,
+		open: func(seg Segment) string {
+			return r.sgr(seg, false)
+		},
+		close: ansiReset,
+		text: func(b *strings.Builder, text string) {
+			b.WriteString(r.terminalText(text))
+		},
+	}
+}
+
+// Write converts p and writes the result to the underlying writer. Input
+// that might be the start of a code is held back until the next call.
+func (s *StreamWriter) Write(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	buf := append(s.pending, p...)
+	var b strings.Builder
+	n := s.convert(&b, buf, false)
+	s.pending = append(s.pending[:0:0], buf[n:]...)
+
+	if err := s.emit(b.String()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close converts any input held back, closes whatever is left open, and
+// writes the result. It does not close the underlying writer.
+func (s *StreamWriter) Close() error {
+	if s.err != nil {
+		return s.err
+	}
+
+	var b strings.Builder
+	s.convert(&b, s.pending, true)
+	s.pending = nil
+	if s.styled {
+		b.WriteString(s.close)
+		s.styled = false
+	}
+	return s.emit(b.String())
+}
+
+// emit writes out to the underlying writer, remembering any error
+func (s *StreamWriter) emit(out string) error {
+	if out == "" {
+		return nil
+	}
+	if _, err := io.WriteString(s.w, out); err != nil {
+		s.err = err
+	}
+	return s.err
+}
+
+// convert converts as much of buf as possible into b, returning the number
+// of bytes consumed. Unless final is true, trailing input that might be an
+// incomplete code or character is left unconsumed.
+func (s *StreamWriter) convert(b *strings.Builder, buf []byte, final bool) int {
+	caret := []byte(s.r.dialect.caret())
+	start := 0
+	flush := func(end int) {
+		s.writeText(b, string(buf[start:end]))
+	}
+
+	i := 0
+	for i < len(buf) {
+		if buf[i] == '\n' {
+			flush(i)
+			if s.styled {
+				b.WriteString(s.close)
+				s.styled = false
+			}
+			s.text(b, "\n")
+			s.state = Segment{}
+			s.dirty = false
+			i++
+			start = i
+			continue
+		}
+
+		if !bytes.HasPrefix(buf[i:], caret) {
+			i++
+			continue
+		}
+
+		rest := string(buf[i+len(caret) : min(i+len(caret)+streamHold, len(buf))])
+		if !final && s.incomplete(rest) {
+			flush(i)
+			return i
+		}
+
+		if bytes.HasPrefix([]byte(rest), caret) {
+			// a doubled caret stands for a literal one
+			flush(i + len(caret))
+			i += 2 * len(caret)
+			start = i
+			continue
+		}
+
+		if n := colorCodeLen(rest); n > 0 {
+			flush(i)
+			s.state.Code = Code("^" + rest[:n])
+			s.state.Color = s.state.Code.Color(&XonoticPalette)
+			s.dirty = true
+			i += len(caret) + n
+			start = i
+			continue
+		}
+
+		if n := s.r.dialect.applyExtCode(rest, &s.state, func() { flush(i) }); n > 0 {
+			s.dirty = true
+			i += len(caret) + n
+			start = i
+			continue
+		}
+
+		i++
+	}
+
+	end := len(buf)
+	if !final {
+		end -= incompleteRuneLen(buf[start:])
+	}
+	flush(end)
+	return end
+}
+
+// incomplete reports whether rest, the input following a caret, might still
+// turn into a code once more input arrives.
+func (s *StreamWriter) incomplete(rest string) bool {
+	if len(s.r.dialect.Codes) > 0 && len(rest) < streamHold {
+		return true
+	}
+	if len(rest) == 0 {
+		return true
+	}
+	if rest[0] != 'x' || len(rest) >= 4 {
+		return false
+	}
+	for j := 1; j < len(rest); j++ {
+		if !isHexDigit(rest[j]) {
+			return false
+		}
+	}
+	return true
+}
+
+// writeText writes text in the current state, opening a new span first if
+// the state changed.
+func (s *StreamWriter) writeText(b *strings.Builder, text string) {
+	if text == "" {
+		return
+	}
+	if s.dirty {
+		if s.styled {
+			b.WriteString(s.close)
+		}
+		s.styled = s.state.styled()
+		if s.styled {
+			b.WriteString(s.open(s.state))
+		}
+		s.dirty = false
+	}
+	s.text(b, text)
+}
+
+// incompleteRuneLen returns the length of the incomplete UTF-8 sequence at
+// the end of p, if any.
+func incompleteRuneLen(p []byte) int {
+	for n := 1; n < utf8.UTFMax && n <= len(p); n++ {
+		c := p[len(p)-n]
+		if utf8.RuneStart(c) {
+			if !utf8.FullRune(p[len(p)-n:]) {
+				return n
+			}
+			return 0
+		}
+	}
+	return 0
+}
+
+// strippingReader removes color codes from the data read from src
+type strippingReader struct {
+	src io.Reader
+	sw  *StreamWriter
+	buf []byte
+	out bytes.Buffer
+	err error
+}
+
+// NewStrippingReader returns a reader that removes all color codes from the
+// data read from r, as Stripped does. Codes that straddle the boundaries of
+// reads from r are handled, so large logs can be stripped without loading
+// them into memory.
+func NewStrippingReader(r io.Reader) io.Reader {
+	s := &strippingReader{src: r, buf: make([]byte, 32*1024)}
+	s.sw = &StreamWriter{
+		w:    &s.out,
+		r:    NewRenderer(),
+		open: func(Segment) string { return "" },
+		text: func(b *strings.Builder, text string) {
+			b.WriteString(text)
+		},
+	}
+	return s
+}
+
+// Read reads stripped data into p.
+func (s *strippingReader) Read(p []byte) (int, error) {
+	for s.out.Len() == 0 && s.err == nil {
+		n, err := s.src.Read(s.buf)
+		s.sw.Write(s.buf[:n])
+		if err == io.EOF {
+			s.sw.Close()
+		}
+		s.err = err
+	}
+	if s.out.Len() > 0 {
+		return s.out.Read(p)
+	}
+	return 0, s.err
+}
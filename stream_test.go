@@ -0,0 +1,68 @@
+package qstr
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestHTMLWriter(t *testing.T) {
+	var b bytes.Buffer
+	w := NewHTMLWriter(&b)
+	for _, chunk := range []string{"^1An", "ti^", "x4", "44bo<", "dy\nplain ^", "5\xc3", "\xa9^", ""} {
+		if _, err := w.Write([]byte(chunk)); err != nil {
+			t.Fatalf("Unexpected error: %v.", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Unexpected error: %v.", err)
+	}
+
+	expected := "<span style='color:rgb(255,0,0)'>Anti</span><span style=\"color:rgb(127,127,127)\">bo&lt;dy</span>\nplain <span style='color:rgb(51,255,255)'>é^</span>"
+	if received := b.String(); received != expected {
+		t.Errorf("Incorrect streamed HTML. Expected: %v, Got: %v.", expected, received)
+	}
+}
+
+func TestANSIWriter(t *testing.T) {
+	var b bytes.Buffer
+	w := NewANSIWriter(&b, WithColorDepth(Color16))
+	for _, c := range []byte("^1Anti^7body^x") {
+		if _, err := w.Write([]byte{c}); err != nil {
+			t.Fatalf("Unexpected error: %v.", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Unexpected error: %v.", err)
+	}
+
+	expected := "\x1b[91mAnti\x1b[0m\x1b[97mbody^x\x1b[0m"
+	if received := b.String(); received != expected {
+		t.Errorf("Incorrect streamed ANSI. Expected: %q, Got: %q.", expected, received)
+	}
+}
+
+// oneByteReader returns the data of r one byte at a time
+type oneByteReader struct {
+	r io.Reader
+}
+
+func (o oneByteReader) Read(p []byte) (int, error) {
+	return o.r.Read(p[:min(len(p), 1)])
+}
+
+func TestStrippingReader(t *testing.T) {
+	input := "^1Anti^x444body\n^xZZZ ^^2caret é^x12"
+	expected := "Antibody\n^xZZZ ^2caret é^x12"
+
+	for _, src := range []io.Reader{strings.NewReader(input), oneByteReader{strings.NewReader(input)}} {
+		received, err := io.ReadAll(NewStrippingReader(src))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v.", err)
+		}
+		if string(received) != expected {
+			t.Errorf("Incorrect stripped output. Expected: %q, Got: %q.", expected, received)
+		}
+	}
+}
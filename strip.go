@@ -0,0 +1,169 @@
+package qstr
+
+import (
+	"strings"
+)
+
+// StripOption adjusts what Strip removes.
+type StripOption func(*stripConfig)
+
+type stripConfig struct {
+	keepGlyphs  bool
+	keepEscapes bool
+
+	// lightness filters; with neither set every code is stripped
+	above, below       float64
+	hasAbove, hasBelow bool
+}
+
+// KeepGlyphs leaves font glyphs as they are rather than translating them to
+// the characters they stand for.
+func KeepGlyphs() StripOption {
+	return func(c *stripConfig) {
+		c.keepGlyphs = true
+	}
+}
+
+// KeepEscapes leaves ^^ escapes as they are rather than replacing them with
+// the caret they stand for, and escapes lone carets, so the result can be
+// read back as a QStr.
+func KeepEscapes() StripOption {
+	return func(c *stripConfig) {
+		c.keepEscapes = true
+	}
+}
+
+// StripLighterThan only strips the codes whose color has an HSL lightness
+// above l. Other codes are kept.
+func StripLighterThan(l float64) StripOption {
+	return func(c *stripConfig) {
+		c.above, c.hasAbove = l, true
+	}
+}
+
+// StripDarkerThan only strips the codes whose color has an HSL lightness
+// below l. Other codes are kept. Combined with StripLighterThan, codes
+// matching either are stripped.
+func StripDarkerThan(l float64) StripOption {
+	return func(c *stripConfig) {
+		c.below, c.hasBelow = l, true
+	}
+}
+
+// strips reports whether code is to be stripped under c
+func (c *stripConfig) strips(code string) bool {
+	if !c.hasAbove && !c.hasBelow {
+		return true
+	}
+	rgb := ColorCodeToColorRGB(code)
+	l := rgb.HSL().L
+	return (c.hasAbove && l > c.above) || (c.hasBelow && l < c.below)
+}
+
+// Strip returns the text of s with color codes removed, ^^ escapes replaced
+// by carets, and font glyphs translated with XonoticDecodeKey, adjusted by
+// opts. Codes kept by StripLighterThan or StripDarkerThan are written as
+// they are, and escapes are then kept too so the codes still read as codes.
+func (s *QStr) Strip(opts ...StripOption) string {
+	c := &stripConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.hasAbove || c.hasBelow {
+		c.keepEscapes = true
+	}
+
+	raw := string(*s)
+	var b strings.Builder
+	b.Grow(len(raw))
+	text := func(t string) {
+		if !c.keepGlyphs {
+			t = decodeString(t)
+			if c.keepEscapes {
+				// glyphs may decode into carets
+				t = string(Escape(t))
+			}
+		}
+		b.WriteString(t)
+	}
+	for i := 0; i < len(raw); {
+		next := strings.IndexByte(raw[i:], '^')
+		if next < 0 {
+			text(raw[i:])
+			break
+		}
+		text(raw[i : i+next])
+		i += next
+
+		rest := raw[i+1:]
+		switch n := colorCodeLen(rest); {
+		case strings.HasPrefix(rest, "^"):
+			if c.keepEscapes {
+				b.WriteString("^^")
+			} else {
+				b.WriteByte('^')
+			}
+			i += 2
+		case n > 0:
+			if code := raw[i : i+1+n]; !c.strips(code) {
+				b.WriteString(code)
+			}
+			i += 1 + n
+		default:
+			// a lone caret; escaped if the result is read back as a QStr,
+			// as the text after it may now start with a code
+			if c.keepEscapes {
+				b.WriteString("^^")
+			} else {
+				b.WriteByte('^')
+			}
+			i++
+		}
+	}
+	return b.String()
+}
+
+// StripDecimal returns s without its ^N codes, keeping ^xNNN codes.
+func (s *QStr) StripDecimal() QStr {
+	return stripCodes(*s, 1)
+}
+
+// StripHex returns s without its ^xNNN codes, keeping ^N codes such as the
+// basic team colors.
+func (s *QStr) StripHex() QStr {
+	return stripCodes(*s, 4)
+}
+
+// stripCodes returns s without the codes of length n, caret excluded. Lone
+// carets are escaped, so that removing a code can't join them to the text
+// after it to form a new code.
+func stripCodes(s QStr, n int) QStr {
+	raw := string(s)
+	var b strings.Builder
+	b.Grow(len(raw))
+	for i := 0; i < len(raw); {
+		next := strings.IndexByte(raw[i:], '^')
+		if next < 0 {
+			b.WriteString(raw[i:])
+			break
+		}
+		b.WriteString(raw[i : i+next])
+		i += next
+
+		rest := raw[i+1:]
+		switch l := colorCodeLen(rest); {
+		case strings.HasPrefix(rest, "^"):
+			b.WriteString("^^")
+			i += 2
+		case l > 0:
+			if l != n {
+				b.WriteString(raw[i : i+1+l])
+			}
+			i += 1 + l
+		default:
+			b.WriteString("^^")
+			i++
+		}
+	}
+	return QStr(b.String())
+}
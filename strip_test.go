@@ -0,0 +1,47 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestStrip(t *testing.T) {
+	var stripList = []struct {
+		Input    QStr
+		Opts     []StripOption
+		Expected string
+	}{
+		{"^1\ue041nti^x4afbody ^^1", nil, "Antibody ^1"},
+		{"^1\ue041nti", []StripOption{KeepGlyphs()}, "\ue041nti"},
+		{"^1a^^1", []StripOption{KeepEscapes()}, "a^^1"},
+		{"^1a^x111b^7c^^", []StripOption{StripLighterThan(0.6)}, "^1a^x111bc^^"},
+		{"^1a^x111b^7c", []StripOption{StripDarkerThan(0.1)}, "^1ab^7c"},
+		{"^1a^x111b^7c", []StripOption{StripDarkerThan(0.1), StripLighterThan(0.6)}, "^1abc"},
+		{"a^", nil, "a^"},
+		{"^x1^22b", []StripOption{KeepEscapes()}, "^^x12b"},
+		{"^x1^22b^x444c", []StripOption{StripLighterThan(0.4)}, "^^x12b^x444c"},
+		{"\ue05e1abc", nil, "^1abc"},
+		{"\ue05e1abc", []StripOption{KeepEscapes()}, "^^1abc"},
+		{"^1a\ue05e2b", []StripOption{StripLighterThan(0.6)}, "^1a^^2b"},
+	}
+
+	for _, v := range stripList {
+		if received := v.Input.Strip(v.Opts...); received != v.Expected {
+			t.Errorf("Incorrect stripping of %q. Expected: %q, Got: %q.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestStripCodeTypes(t *testing.T) {
+	s := QStr("^1Anti^x4afbo^^2dy^2")
+	if received, expected := s.StripDecimal(), QStr("Anti^x4afbo^^2dy"); received != expected {
+		t.Errorf("Incorrect StripDecimal. Expected: %q, Got: %q.", expected, received)
+	}
+	if received, expected := s.StripHex(), QStr("^1Antibo^^2dy^2"); received != expected {
+		t.Errorf("Incorrect StripHex. Expected: %q, Got: %q.", expected, received)
+	}
+
+	s = QStr("^x1^22b^")
+	if received, expected := s.StripDecimal(), QStr("^^x12b^^"); received != expected {
+		t.Errorf("Incorrect StripDecimal. Expected: %q, Got: %q.", expected, received)
+	}
+}
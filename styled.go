@@ -0,0 +1,68 @@
+package qstr
+
+import (
+	"unicode/utf8"
+)
+
+// StyledRune is a single character cell of a QStr along with its colors,
+// for painting terminal UIs cell by cell.
+type StyledRune struct {
+	// Rune is the character shown in the cell, and Combining holds any
+	// combining characters of its grapheme cluster that follow it.
+	Rune      rune
+	Combining []rune
+
+	// Width is the number of terminal cells the character takes up.
+	Width int
+
+	// Foreground is the text color.
+	Foreground RGBColor
+
+	// Background is the background color set by a dialect extension code.
+	// It is only meaningful when HasBackground is true.
+	Background    RGBColor
+	HasBackground bool
+
+	// Style holds the formatting attributes set by dialect extension codes
+	Style Style
+}
+
+// Styled returns the visible characters of s along with their colors. Options
+// may be given to alter the output; see Renderer.
+func (s *QStr) Styled(opts ...Option) []StyledRune {
+	return NewRenderer(opts...).Styled(*s)
+}
+
+// Styled returns the visible characters of s, one per grapheme cluster, along
+// with their colors. Text without a color is given ^7's color, and hex colors
+// are capped to the theme's lightness bounds, as in HTML output. Widths are
+// measured with the renderer's WidthFunc.
+func (r *Renderer) Styled(s QStr) []StyledRune {
+	var res []StyledRune
+	for _, seg := range r.dialect.Tokenize(s) {
+		fg := r.color(Segment{Code: resetCode})
+		if seg.Code != "" {
+			fg = r.color(seg)
+			if seg.Code.IsHex() {
+				fg = r.capLightness(fg)
+			}
+		}
+
+		for _, g := range graphemes(r.text(seg.Text)) {
+			c, size := utf8.DecodeRuneInString(g)
+			sr := StyledRune{
+				Rune:          c,
+				Width:         r.width(g),
+				Foreground:    fg,
+				Background:    seg.Background,
+				HasBackground: seg.HasBackground,
+				Style:         seg.Style,
+			}
+			if size < len(g) {
+				sr.Combining = []rune(g[size:])
+			}
+			res = append(res, sr)
+		}
+	}
+	return res
+}
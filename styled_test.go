@@ -0,0 +1,23 @@
+package qstr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStyled(t *testing.T) {
+	s := QStr("a^1e\u0301^x444日")
+	expected := []StyledRune{
+		{Rune: 'a', Width: 1, Foreground: RGBColor{1, 1, 1}},
+		{Rune: 'e', Combining: []rune{'\u0301'}, Width: 1, Foreground: RGBColor{1, 0, 0}},
+		{Rune: '日', Width: 2, Foreground: RGBColor{0.5, 0.5, 0.5}},
+	}
+	if received := s.Styled(); !reflect.DeepEqual(received, expected) {
+		t.Errorf("Incorrect styled runes. Expected: %+v, Got: %+v.", expected, received)
+	}
+
+	empty := QStr("^1^2")
+	if received := empty.Styled(); len(received) != 0 {
+		t.Errorf("Incorrect styled runes. Expected: %v, Got: %+v.", "none", received)
+	}
+}
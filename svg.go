@@ -0,0 +1,86 @@
+package qstr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"math"
+)
+
+// ErrBadFontSize is returned by SVG when the font size is not positive.
+var ErrBadFontSize = errors.New("qstr: font size must be positive")
+
+// SVGOption configures the output of SVG.
+type SVGOption func(*svgConfig)
+
+type svgConfig struct {
+	family string
+	size   float64
+	theme  Theme
+}
+
+// SVGFontFamily sets the CSS font family of the text. The default is
+// monospace.
+func SVGFontFamily(family string) SVGOption {
+	return func(c *svgConfig) {
+		c.family = family
+	}
+}
+
+// SVGFontSize sets the font size of the text in pixels. The default is 16.
+func SVGFontSize(size float64) SVGOption {
+	return func(c *svgConfig) {
+		c.size = size
+	}
+}
+
+// SVGTheme sets the theme the colors are adapted to. The default is the one
+// returned by DefaultTheme.
+func SVGTheme(theme Theme) SVGOption {
+	return func(c *svgConfig) {
+		c.theme = theme
+	}
+}
+
+// SVG renders s as a standalone SVG document holding a single <text> element,
+// with each segment in a <tspan> filled with its color. Text without a color
+// is filled with ^7's color. Hex colors are capped to the theme's lightness
+// bounds, as in HTML output. The document is sized for a monospaced font,
+// measuring the text with CellWidth.
+func SVG(s QStr, opts ...SVGOption) ([]byte, error) {
+	c := svgConfig{family: "monospace", size: 16, theme: DefaultTheme()}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.size <= 0 || math.IsNaN(c.size) {
+		return nil, ErrBadFontSize
+	}
+
+	segments := DarkPlaces.Tokenize(s)
+	cells := 0
+	for _, seg := range segments {
+		cells += textWidth(seg.Text, CellWidth)
+	}
+
+	// monospaced glyphs are about 0.6em wide
+	width := math.Ceil(float64(cells) * c.size * 0.6)
+	height := math.Ceil(c.size * 1.25)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%g\" height=\"%g\" viewBox=\"0 0 %g %g\">", width, height, width, height)
+	fmt.Fprintf(&b, "<text x=\"0\" y=\"%g\" font-family=\"%s\" font-size=\"%g\" xml:space=\"preserve\">", c.size, html.EscapeString(c.family), c.size)
+	for _, seg := range segments {
+		fill := c.theme.Palette[7]
+		if seg.Code != "" {
+			fill = c.theme.Palette.color(seg)
+			if seg.Code.IsHex() {
+				fill = fill.CapLightness(c.theme.MinLightness, c.theme.MaxLightness)
+			}
+		}
+		fmt.Fprintf(&b, "<tspan fill=\"%s\">%s</tspan>", fill.Hex(), html.EscapeString(seg.Text))
+	}
+	b.WriteString("</text></svg>")
+
+	return b.Bytes(), nil
+}
@@ -0,0 +1,38 @@
+package qstr
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSVG(t *testing.T) {
+	received, err := SVG("^1Anti^x444body <3", SVGFontFamily("Xolonium"), SVGFontSize(20))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v.", err)
+	}
+
+	expected := "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"132\" height=\"25\" viewBox=\"0 0 132 25\">" +
+		"<text x=\"0\" y=\"20\" font-family=\"Xolonium\" font-size=\"20\" xml:space=\"preserve\">" +
+		"<tspan fill=\"#ff0000\">Anti</tspan><tspan fill=\"#808080\">body &lt;3</tspan></text></svg>"
+	if string(received) != expected {
+		t.Errorf("Incorrect SVG. Expected: %v, Got: %s.", expected, received)
+	}
+}
+
+func TestSVGDefaults(t *testing.T) {
+	received, err := SVG("Anti")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v.", err)
+	}
+
+	expected := "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"39\" height=\"20\" viewBox=\"0 0 39 20\">" +
+		"<text x=\"0\" y=\"16\" font-family=\"monospace\" font-size=\"16\" xml:space=\"preserve\">" +
+		"<tspan fill=\"#ffffff\">Anti</tspan></text></svg>"
+	if string(received) != expected {
+		t.Errorf("Incorrect SVG. Expected: %v, Got: %s.", expected, received)
+	}
+
+	if _, err := SVG("Anti", SVGFontSize(0)); !errors.Is(err, ErrBadFontSize) {
+		t.Errorf("Incorrect error. Expected: %v, Got: %v.", ErrBadFontSize, err)
+	}
+}
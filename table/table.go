@@ -0,0 +1,189 @@
+// Package table lays out rows of colored QStr cells as fixed-width tables,
+// such as scoreboards, for terminals or web pages. Cell widths are measured
+// in terminal cells, so color codes take up no room and wide characters
+// count double.
+package table
+
+import (
+	"html"
+	"html/template"
+	"strings"
+
+	"github.com/antzucaro/qstr"
+)
+
+// Align is the alignment of the cells of a column. Values other than the
+// ones below align cells to the left.
+type Align int
+
+const (
+	// Left aligns cells to the left of the column. This is the default.
+	Left Align = iota
+
+	// Right aligns cells to the right, as suits numbers.
+	Right
+
+	// Center centers cells in the column.
+	Center
+)
+
+// Ellipsis marks cells cut down to a column's MaxWidth.
+const Ellipsis = "…"
+
+// Column describes a column of a Table.
+type Column struct {
+	// Header is shown above the column. A table whose columns all have
+	// empty headers has no header row.
+	Header qstr.QStr
+
+	Align Align
+
+	// MaxWidth is the widest a cell may be, in terminal cells. Longer
+	// cells are truncated and end in Ellipsis. Zero means no limit.
+	MaxWidth int
+}
+
+// Table is a list of rows laid out in columns.
+type Table struct {
+	Columns []Column
+
+	// Separator is placed between the cells of a row. The default is two
+	// spaces.
+	Separator string
+
+	rows [][]qstr.QStr
+}
+
+// New returns a Table with the given columns.
+func New(columns ...Column) *Table {
+	return &Table{Columns: columns, Separator: "  "}
+}
+
+// AddRow appends a row of cells, one per column. Missing cells are left
+// empty and extra cells are dropped.
+func (t *Table) AddRow(cells ...qstr.QStr) {
+	row := make([]qstr.QStr, len(t.Columns))
+	copy(row, cells)
+	t.rows = append(t.rows, row)
+}
+
+// hasHeader reports whether any column has a header
+func (t *Table) hasHeader() bool {
+	for _, c := range t.Columns {
+		if c.Header != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// cells returns the rows of the table, header row first if there is one,
+// with every cell truncated to its column's MaxWidth.
+func (t *Table) cells() [][]qstr.QStr {
+	rows := make([][]qstr.QStr, 0, len(t.rows)+1)
+	if t.hasHeader() {
+		header := make([]qstr.QStr, len(t.Columns))
+		for i, c := range t.Columns {
+			header[i] = c.Header
+		}
+		rows = append(rows, header)
+	}
+	for _, row := range t.rows {
+		rows = append(rows, append([]qstr.QStr(nil), row...))
+	}
+
+	for _, row := range rows {
+		for i, c := range t.Columns {
+			if c.MaxWidth > 0 {
+				row[i] = row[i].TruncateWidth(c.MaxWidth, Ellipsis)
+			}
+		}
+	}
+	return rows
+}
+
+// Lines lays the table out as one QStr per row, header row first, with
+// every cell padded to the width of its column. Colors never carry over
+// from one cell into the next.
+func (t *Table) Lines() []qstr.QStr {
+	rows := t.cells()
+	widths := make([]int, len(t.Columns))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], cell.Width())
+		}
+	}
+
+	lines := make([]qstr.QStr, 0, len(rows))
+	for _, row := range rows {
+		padded := make([]qstr.QStr, len(row))
+		for i, cell := range row {
+			switch t.Columns[i].Align {
+			case Right:
+				padded[i] = cell.PadLeft(widths[i])
+			case Center:
+				padded[i] = cell.Center(widths[i])
+			default:
+				padded[i] = cell.PadRight(widths[i])
+			}
+		}
+		line := qstr.Join(padded, qstr.Escape(t.Separator))
+		lines = append(lines, qstr.QStr(strings.TrimRight(string(line), " ")))
+	}
+	return lines
+}
+
+// ANSI returns the table for a terminal, one line per row, with colors
+// written as ANSI escape sequences. Options may be given to alter the
+// output; see qstr.Renderer.
+func (t *Table) ANSI(opts ...qstr.Option) string {
+	r := qstr.NewRenderer(opts...)
+	var b strings.Builder
+	for _, line := range t.Lines() {
+		b.WriteString(r.ANSI(line))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// HTML returns the table as an HTML table element, with the header row in
+// a thead element and each cell rendered as qstr.Renderer.HTML does. Cells
+// are truncated to their column's MaxWidth, but the browser lays out the
+// columns. Options may be given to alter the output; see qstr.Renderer.
+func (t *Table) HTML(opts ...qstr.Option) template.HTML {
+	r := qstr.NewRenderer(opts...)
+	rows := t.cells()
+
+	var b strings.Builder
+	b.WriteString("<table>")
+	if t.hasHeader() {
+		b.WriteString("<thead>")
+		t.writeRow(&b, r, rows[0], "th")
+		b.WriteString("</thead>")
+		rows = rows[1:]
+	}
+	b.WriteString("<tbody>")
+	for _, row := range rows {
+		t.writeRow(&b, r, row, "td")
+	}
+	b.WriteString("</tbody></table>")
+	return template.HTML(b.String())
+}
+
+// the text-align value of each alignment
+var alignNames = []string{"left", "right", "center"}
+
+// writeRow writes a tr element holding row's cells as elements named tag
+func (t *Table) writeRow(b *strings.Builder, r *qstr.Renderer, row []qstr.QStr, tag string) {
+	b.WriteString("<tr>")
+	for i, cell := range row {
+		b.WriteString("<" + tag)
+		if a := t.Columns[i].Align; a > Left && int(a) < len(alignNames) {
+			b.WriteString(" style=\"text-align:" + html.EscapeString(alignNames[a]) + "\"")
+		}
+		b.WriteString(">")
+		b.WriteString(string(r.HTML(cell)))
+		b.WriteString("</" + tag + ">")
+	}
+	b.WriteString("</tr>")
+}
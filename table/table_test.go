@@ -0,0 +1,67 @@
+package table
+
+import (
+	"html/template"
+	"reflect"
+	"testing"
+
+	"github.com/antzucaro/qstr"
+)
+
+func newScoreboard() *Table {
+	t := New(
+		Column{Header: "^3Player", MaxWidth: 8},
+		Column{Header: "Score", Align: Right},
+	)
+	t.AddRow("^1Anti^5body", "120")
+	t.AddRow("^x4afSomeoneElse", "7")
+	t.AddRow("日本")
+	return t
+}
+
+func TestLines(t *testing.T) {
+	expected := []qstr.QStr{
+		"^3Player  ^7  Score",
+		"^1Anti^5body^7    120",
+		"^x4afSomeone…^7      7",
+		"日本",
+	}
+	if received := newScoreboard().Lines(); !reflect.DeepEqual(received, expected) {
+		t.Errorf("Incorrect table lines. Expected: %q, Got: %q.", expected, received)
+	}
+
+	centered := New(Column{Align: Center}, Column{})
+	centered.AddRow("a", "^1b")
+	centered.AddRow("abc", "c")
+	expected = []qstr.QStr{" a   ^1b", "abc  c"}
+	if received := centered.Lines(); !reflect.DeepEqual(received, expected) {
+		t.Errorf("Incorrect centered lines. Expected: %q, Got: %q.", expected, received)
+	}
+}
+
+func TestANSI(t *testing.T) {
+	tbl := New(Column{}, Column{Align: Right})
+	tbl.AddRow("^1ab", "1")
+	expected := "\x1b[38;2;255;0;0mab\x1b[0;38;2;255;255;255m  1\x1b[0m\n"
+	if received := tbl.ANSI(); received != expected {
+		t.Errorf("Incorrect ANSI table. Expected: %q, Got: %q.", expected, received)
+	}
+}
+
+func TestHTML(t *testing.T) {
+	expected := template.HTML("<table><thead><tr><th><span style='color:rgb(255,255,0)'>Player</span></th><th style=\"text-align:right\">Score</th></tr></thead>" +
+		"<tbody><tr><td><span style='color:rgb(255,0,0)'>Anti<span style='color:rgb(51,255,255)'>body</span></span></td><td style=\"text-align:right\">120</td></tr>" +
+		"<tr><td><span style=\"color:rgb(68,170,255)\">Someone…</span></td><td style=\"text-align:right\">7</td></tr>" +
+		"<tr><td>日本</td><td style=\"text-align:right\"></td></tr></tbody></table>")
+	if received := newScoreboard().HTML(); received != expected {
+		t.Errorf("Incorrect HTML table. Expected: %v, Got: %v.", expected, received)
+	}
+
+	// unknown alignments fall back to the left
+	tbl := New(Column{Align: Align(7)}, Column{Align: Align(-1)})
+	tbl.AddRow("a", "b")
+	expected = template.HTML("<table><tbody><tr><td>a</td><td>b</td></tr></tbody></table>")
+	if received := tbl.HTML(); received != expected {
+		t.Errorf("Incorrect HTML table with unknown alignments. Expected: %v, Got: %v.", expected, received)
+	}
+}
@@ -0,0 +1,562 @@
+package qstr
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// the code that restores the engine's default text color
+const resetCode = "^7"
+
+// Repeat returns n copies of s. Each copy after the first starts with the
+// color that was in effect at the start of s, so the color s ends with does
+// not bleed into the next copy. Repeat returns an empty QStr if n <= 0.
+func (s *QStr) Repeat(n int) QStr {
+	if n <= 0 {
+		return ""
+	}
+
+	raw := string(*s)
+	// a caret left unfinished at the end of a copy would combine with the
+	// start of the next one
+	head := escapeUnfinished(raw)
+	if basicCodeLen(raw) > 0 || len(findCodes(raw)) == 0 {
+		// the color state is the same at the start of every copy
+		return QStr(strings.Repeat(head, n-1) + raw)
+	}
+
+	// s starts out uncolored but changes color later on, so reset
+	// back to the default color between copies
+	var b strings.Builder
+	b.Grow(n*len(head) + (n-1)*len(resetCode))
+	for i := 1; i < n; i++ {
+		b.WriteString(head)
+		b.WriteString(resetCode)
+	}
+	b.WriteString(raw)
+	return QStr(b.String())
+}
+
+// AbbreviateMiddle shortens s to at most maxVisible visible characters by
+// replacing the middle of its text with an ellipsis. The start and end of
+// the text keep their colors, so a clan tag at either end stays intact.
+func (s *QStr) AbbreviateMiddle(maxVisible int) QStr {
+	segments := DarkPlaces.Tokenize(*s)
+	n := visibleLen(segments)
+	if n <= maxVisible {
+		return *s
+	}
+	if maxVisible <= 0 {
+		return ""
+	}
+
+	// the ellipsis takes up one of the visible characters
+	head := maxVisible / 2
+	tail := maxVisible - 1 - head
+
+	kept := sliceSegments(segments, 0, head)
+	if len(kept) > 0 {
+		kept[len(kept)-1].Text += "…"
+	} else {
+		kept = append(kept, Segment{Text: "…"})
+	}
+	kept = append(kept, sliceSegments(segments, n-tail, n)...)
+
+	return joinSegments(kept)
+}
+
+// VisibleLen returns the number of visible characters in s, ignoring color
+// codes. Characters are counted in grapheme clusters, so an emoji sequence
+// such as 👨\u200d👩\u200d👧 or a letter with combining accents counts as one.
+func (s *QStr) VisibleLen() int {
+	return visibleLen(DarkPlaces.Tokenize(*s))
+}
+
+// Width returns the number of terminal cells the visible text of s takes up,
+// ignoring color codes. Wide characters such as CJK ideographs and emoji
+// take up two cells and combining marks none. Options may be given to alter
+// the measurement; see WithWidthFunc.
+func (s *QStr) Width(opts ...Option) int {
+	return NewRenderer(opts...).Width(*s)
+}
+
+// PadLeft right-aligns s in a field of the given width by prepending
+// spaces. Width is measured in terminal cells as in Width, so color codes
+// take up no room. s is returned unchanged if it is already at least width
+// cells wide.
+func (s *QStr) PadLeft(width int) QStr {
+	n := width - s.Width()
+	if n <= 0 {
+		return *s
+	}
+	return QStr(strings.Repeat(" ", n)) + *s
+}
+
+// PadRight left-aligns s in a field of the given width by appending spaces,
+// measured as in PadLeft.
+func (s *QStr) PadRight(width int) QStr {
+	n := width - s.Width()
+	if n <= 0 {
+		return *s
+	}
+	return *s + QStr(strings.Repeat(" ", n))
+}
+
+// Center centers s in a field of the given width, measured as in PadLeft.
+// When the padding cannot be split evenly the extra space goes on the
+// right.
+func (s *QStr) Center(width int) QStr {
+	n := width - s.Width()
+	if n <= 0 {
+		return *s
+	}
+	return QStr(strings.Repeat(" ", n/2)) + *s + QStr(strings.Repeat(" ", n-n/2))
+}
+
+// Wrap breaks s into lines at most width terminal cells wide, measured as in
+// Width. Lines are broken at spaces where possible and inside words that are
+// too long to fit on a line of their own, and newlines in s always start a
+// new line. The spaces at a break are dropped. Each line starts with the
+// color code in effect at that point, so continuation lines keep their
+// color. If width < 1, s is returned as a single line.
+func (s *QStr) Wrap(width int) []QStr {
+	if width < 1 {
+		return []QStr{*s}
+	}
+
+	// the visible text as grapheme clusters, each with the segment it
+	// belongs to
+	type cluster struct {
+		text  string
+		width int
+		seg   int
+	}
+	segments := DarkPlaces.Tokenize(*s)
+	var clusters []cluster
+	for i, seg := range segments {
+		for _, g := range graphemes(seg.Text) {
+			clusters = append(clusters, cluster{g, CellWidth(g), i})
+		}
+	}
+	isSpace := func(i int) bool {
+		return clusters[i].text == " " || clusters[i].text == "\t"
+	}
+
+	var lines []QStr
+	for i := 0; i < len(clusters); {
+		start, w, lastSpace := i, 0, -1
+		for ; i < len(clusters) && clusters[i].text != "\n"; i++ {
+			if w+clusters[i].width > width && i > start {
+				break
+			}
+			if isSpace(i) {
+				lastSpace = i
+			}
+			w += clusters[i].width
+		}
+
+		end := i
+		switch {
+		case i == len(clusters):
+		case clusters[i].text == "\n":
+			i++
+		default:
+			// the line is full, so break at its last space unless the
+			// word that overflowed starts right after a space anyway
+			if !isSpace(i) && lastSpace > start {
+				end, i = lastSpace, lastSpace+1
+			}
+			for i < len(clusters) && isSpace(i) {
+				i++
+			}
+		}
+		for end > start && isSpace(end-1) {
+			end--
+		}
+
+		var line []Segment
+		for j := start; j < end; j++ {
+			c := clusters[j]
+			if len(line) > 0 && clusters[j-1].seg == c.seg {
+				line[len(line)-1].Text += c.text
+				continue
+			}
+			seg := segments[c.seg]
+			seg.Text = c.text
+			line = append(line, seg)
+		}
+		lines = append(lines, joinSegments(line))
+	}
+	return lines
+}
+
+// Slice returns the visible characters of s in the range [start, end),
+// counted as in VisibleLen, along with the color codes that apply to them.
+// The result starts with the color in effect at start, so it displays just
+// as that part of s does. Out of range bounds are clamped to the visible
+// text.
+func (s *QStr) Slice(start, end int) QStr {
+	segments := DarkPlaces.Tokenize(*s)
+	start, end = max(start, 0), min(end, visibleLen(segments))
+	if start >= end {
+		return ""
+	}
+	return joinSegments(sliceSegments(segments, start, end))
+}
+
+// Truncate shortens s to at most n visible characters, counted as in
+// VisibleLen. If s is cut, ellipsis is appended in the color of the last
+// retained character and counts towards n. Color codes that apply to the
+// retained text are kept and are never split, while codes that only apply
+// to the removed text are dropped. Truncate returns an empty QStr if n <= 0.
+func (s *QStr) Truncate(n int, ellipsis string) QStr {
+	segments, cut := truncateSegments(DarkPlaces.Tokenize(*s), n, ellipsis)
+	if !cut {
+		return *s
+	}
+	return joinSegments(segments)
+}
+
+// truncateSegments cuts segments down to at most n visible characters as
+// Truncate does, reporting whether they were cut.
+func truncateSegments(segments []Segment, n int, ellipsis string) ([]Segment, bool) {
+	if visibleLen(segments) <= n {
+		return segments, false
+	}
+
+	keep := n - graphemeCount(ellipsis)
+	if keep <= 0 {
+		// not even the ellipsis fits in full
+		if n <= 0 {
+			return nil, true
+		}
+		return []Segment{{Text: stringPrefix(ellipsis, n)}}, true
+	}
+
+	kept := sliceSegments(segments, 0, keep)
+	kept[len(kept)-1].Text += ellipsis
+	return kept, true
+}
+
+// TruncateWidth is like Truncate, but limits s to at most width terminal
+// cells, measured as in Width, rather than to a number of characters. Wide
+// characters such as CJK ideographs and emoji are never cut in half, so the
+// result may fall a cell short of width. Options may be given to alter the
+// measurement; see WithWidthFunc.
+func (s *QStr) TruncateWidth(width int, ellipsis string, opts ...Option) QStr {
+	wf := NewRenderer(opts...).width
+	segments := DarkPlaces.Tokenize(*s)
+	if textWidth(segmentsText(segments), wf) <= width {
+		return *s
+	}
+
+	keep := width - textWidth(ellipsis, wf)
+	if keep <= 0 {
+		// not even the ellipsis fits in full
+		return Escape(widthPrefix(ellipsis, width, wf))
+	}
+
+	n := graphemeCount(widthPrefix(segmentsText(segments), keep, wf))
+	kept := sliceSegments(segments, 0, n)
+	if len(kept) > 0 {
+		kept[len(kept)-1].Text += ellipsis
+	} else {
+		kept = append(kept, Segment{Text: ellipsis})
+	}
+	return joinSegments(kept)
+}
+
+// widthPrefix returns the longest run of whole grapheme clusters at the
+// start of text that fits in width cells
+func widthPrefix(text string, width int, wf WidthFunc) string {
+	i, w := 0, 0
+	for i < len(text) {
+		n := nextGrapheme(text[i:])
+		if w += wf(text[i : i+n]); w > width {
+			break
+		}
+		i += n
+	}
+	return text[:i]
+}
+
+// stringPrefix returns the first n grapheme clusters of text
+func stringPrefix(text string, n int) string {
+	i := 0
+	for ; n > 0 && i < len(text); n-- {
+		i += nextGrapheme(text[i:])
+	}
+	return text[:i]
+}
+
+// ToUpper returns s with its visible text mapped to upper case. Color codes
+// are left exactly as they are.
+func (s *QStr) ToUpper() QStr {
+	return s.mapText(strings.ToUpper)
+}
+
+// ToLower returns s with its visible text mapped to lower case. Color codes
+// are left exactly as they are, so ^x4F0 keeps its digits.
+func (s *QStr) ToLower() QStr {
+	return s.mapText(strings.ToLower)
+}
+
+// mapText returns s with f applied to the visible text between its color
+// codes. Carets in the result are escaped, so that mapping text can never
+// turn it into a code.
+func (s *QStr) mapText(f func(string) string) QStr {
+	raw := string(*s)
+	var b strings.Builder
+	b.Grow(len(raw))
+	prev := 0
+	for _, loc := range findCodes(raw) {
+		b.WriteString(string(Escape(f(unescape(raw[prev:loc[0]])))))
+		b.WriteString(raw[loc[0]:loc[1]])
+		prev = loc[1]
+	}
+	b.WriteString(string(Escape(f(unescape(raw[prev:])))))
+	return QStr(b.String())
+}
+
+// Split slices s into the pieces of its visible text separated by sep, as
+// strings.Split does, dropping the separators. Each piece starts with the
+// color code in effect at that point, so it displays just as that part of s
+// does.
+func (s *QStr) Split(sep string) []QStr {
+	segments := DarkPlaces.Tokenize(*s)
+	pieces := strings.Split(segmentsText(segments), sep)
+	res := make([]QStr, 0, len(pieces))
+	pos := 0
+	for _, piece := range pieces {
+		res = append(res, joinSegments(byteRangeSegments(segments, pos, pos+len(piece))))
+		pos += len(piece) + len(sep)
+	}
+	return res
+}
+
+// Fields splits s around each run of white space in its visible text, as
+// strings.Fields does. Each field starts with the color code in effect at
+// that point, as in Split.
+func (s *QStr) Fields() []QStr {
+	segments := DarkPlaces.Tokenize(*s)
+	text := segmentsText(segments)
+	var res []QStr
+	start := -1
+	for i, c := range text {
+		switch {
+		case unicode.IsSpace(c) && start >= 0:
+			res = append(res, joinSegments(byteRangeSegments(segments, start, i)))
+			start = -1
+		case !unicode.IsSpace(c) && start < 0:
+			start = i
+		}
+	}
+	if start >= 0 {
+		res = append(res, joinSegments(byteRangeSegments(segments, start, len(text))))
+	}
+	return res
+}
+
+// Replace returns s with the first n non-overlapping instances of old in
+// its visible text replaced by new, as strings.Replace does. Matches may
+// span color codes, so "noob" is found in no^1ob. The replacement takes the
+// color in effect at the start of the match, and the text after it keeps
+// its own color. If n < 0, there is no limit on the number of replacements.
+func (s *QStr) Replace(old, new string, n int) QStr {
+	segments := DarkPlaces.Tokenize(*s)
+	text := segmentsText(segments)
+
+	var matches []int
+	for start := 0; n < 0 || len(matches) < n; {
+		i := strings.Index(text[start:], old)
+		if i < 0 {
+			break
+		}
+		pos := start + i
+		matches = append(matches, pos)
+		if old != "" {
+			start = pos + len(old)
+			continue
+		}
+		// an empty old matches at every character boundary
+		if pos == len(text) {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(text[pos:])
+		start = pos + size
+	}
+	if len(matches) == 0 {
+		return *s
+	}
+
+	res := make([]Segment, 0, len(segments)+2*len(matches))
+	pos := 0
+	for _, m := range matches {
+		res = append(res, byteRangeSegments(segments, pos, m)...)
+		if new != "" {
+			seg := segmentAt(segments, m)
+			seg.Text = new
+			res = append(res, seg)
+		}
+		pos = m + len(old)
+	}
+	res = append(res, byteRangeSegments(segments, pos, len(text))...)
+	return joinSegments(res)
+}
+
+// Reverse returns s with its visible text reversed. Each character keeps the
+// color it had, so "^1ab^2cd" becomes "^2dc^1ba", and escaped carets stay
+// escaped wherever they end up. Grapheme clusters are kept whole so that
+// combining marks stay on their base characters.
+func (s *QStr) Reverse() QStr {
+	segments := DarkPlaces.Tokenize(*s)
+	res := make([]Segment, len(segments))
+	for i, seg := range segments {
+		g := graphemes(seg.Text)
+		for l, r := 0, len(g)-1; l < r; l, r = l+1, r-1 {
+			g[l], g[r] = g[r], g[l]
+		}
+		seg.Text = strings.Join(g, "")
+		res[len(segments)-1-i] = seg
+	}
+	return joinSegments(res)
+}
+
+// TrimSpace returns s without the leading and trailing white space of its
+// visible text. Codes that color the remaining text are kept, so
+// "^7   player   ^7" becomes "^7player".
+func (s *QStr) TrimSpace() QStr {
+	text := s.Stripped()
+	start := len(text) - len(strings.TrimLeftFunc(text, unicode.IsSpace))
+	end := len(strings.TrimRightFunc(text, unicode.IsSpace))
+	return s.trimVisible(start, max(start, end))
+}
+
+// TrimPrefix returns s without prefix at the start of its visible text.
+// Codes that color the remaining text are kept. s is returned unchanged if
+// its visible text does not begin with prefix.
+func (s *QStr) TrimPrefix(prefix string) QStr {
+	text := s.Stripped()
+	if !strings.HasPrefix(text, prefix) {
+		return *s
+	}
+	return s.trimVisible(len(prefix), len(text))
+}
+
+// TrimSuffix returns s without suffix at the end of its visible text. Codes
+// that color the remaining text are kept. s is returned unchanged if its
+// visible text does not end with suffix.
+func (s *QStr) TrimSuffix(suffix string) QStr {
+	text := s.Stripped()
+	if !strings.HasSuffix(text, suffix) {
+		return *s
+	}
+	return s.trimVisible(0, len(text)-len(suffix))
+}
+
+// trimVisible returns the part of s showing bytes start to end of its
+// visible text, led by the code in effect at start. Raw text outside the
+// trimmed parts is kept as it is.
+func (s *QStr) trimVisible(start, end int) QStr {
+	raw := string(*s)
+	offsets := visibleOffsets(raw)
+	if start == end {
+		return ""
+	}
+	if start == 0 && end == len(offsets)-1 {
+		return *s
+	}
+
+	rawEnd := len(raw)
+	if end < len(offsets)-1 {
+		last := offsets[end-1]
+		rawEnd = last + 1
+		if strings.HasPrefix(raw[last:], "^^") {
+			rawEnd++
+		}
+	}
+	if start == 0 {
+		return QStr(raw[:rawEnd])
+	}
+
+	rawStart := offsets[start]
+	var code string
+	if codes := findCodes(raw[:rawStart]); len(codes) > 0 {
+		last := codes[len(codes)-1]
+		code = raw[last[0]:last[1]]
+	}
+	return QStr(code + raw[rawStart:rawEnd])
+}
+
+// TruncateBytes cuts s down to at most n bytes, such as to fit the nick
+// field of a game protocol, without splitting a UTF-8 sequence, a color
+// code, or a ^^ escape. Codes left at the end, coloring nothing, are dropped.
+// s is returned unchanged if it already fits.
+func (s *QStr) TruncateBytes(n int) QStr {
+	return s.truncateBytes(n, false)
+}
+
+// TruncateBytesReset is like TruncateBytes, but if s has to be cut and the
+// kept text ends in a color other than ^7, a ^7 code is appended, within the
+// n bytes, so the color doesn't carry over into text that follows.
+func (s *QStr) TruncateBytesReset(n int) QStr {
+	return s.truncateBytes(n, true)
+}
+
+func (s *QStr) truncateBytes(n int, reset bool) QStr {
+	raw := string(*s)
+	if len(raw) <= n {
+		return *s
+	}
+
+	end, code, lone := bytePrefix(raw, n)
+	if !reset || code == "" || code == resetCode {
+		return QStr(raw[:end])
+	}
+
+	end, code, lone = bytePrefix(raw, n-len(resetCode))
+	if lone {
+		// the reset code would turn a trailing lone caret into an escape
+		end--
+	}
+	if code == "" || code == resetCode {
+		return QStr(raw[:end])
+	}
+	return QStr(raw[:end] + resetCode)
+}
+
+// bytePrefix returns the length of the longest prefix of raw of at most
+// limit bytes that ends in visible text and splits no character, code, or
+// escape, along with the code in effect at its end and whether it ends in a
+// lone caret.
+func bytePrefix(raw string, limit int) (end int, code string, lone bool) {
+	pending := ""
+	for i := 0; i < len(raw); {
+		size, isCode, isLone := 0, false, false
+		switch {
+		case strings.HasPrefix(raw[i:], "^^"):
+			size = 2
+		case basicCodeLen(raw[i:]) > 0:
+			size, isCode = basicCodeLen(raw[i:]), true
+		case raw[i] == '^':
+			size, isLone = 1, true
+		default:
+			_, size = utf8.DecodeRuneInString(raw[i:])
+		}
+		if i+size > limit {
+			break
+		}
+
+		if isCode {
+			pending = raw[i : i+size]
+		} else {
+			if pending != "" {
+				code, pending = pending, ""
+			}
+			end, lone = i+size, isLone
+		}
+		i += size
+	}
+	return end, code, lone
+}
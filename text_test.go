@@ -0,0 +1,387 @@
+package qstr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRepeat(t *testing.T) {
+	var repeatList = []struct {
+		Input    QStr
+		N        int
+		Expected QStr
+	}{
+		{"-=", 3, "-=-=-="},
+		{"^1-^4=", 2, "^1-^4=^1-^4="},
+		{"-^4=", 2, "-^4=^7-^4="},
+		{"^1-^4=", 0, ""},
+		{"^1ab^", 2, "^1ab^^^1ab^"},
+		{"-^4=^", 2, "-^4=^^^7-^4=^"},
+	}
+
+	for _, v := range repeatList {
+		received := v.Input.Repeat(v.N)
+		if received != v.Expected {
+			t.Errorf("Incorrect repetition of %q. Expected: %q, Got: %q.", v.Input, v.Expected, received)
+		}
+	}
+
+	nick := QStr("^1ab^")
+	if received := nick.Repeat(2); received.Stripped() != "ab^ab^" {
+		t.Errorf("Incorrect text of the repetition of %q. Expected: %v, Got: %v.", nick, "ab^ab^", received.Stripped())
+	}
+}
+
+func TestAbbreviateMiddle(t *testing.T) {
+	var abbreviateList = []struct {
+		Input    QStr
+		Max      int
+		Expected QStr
+	}{
+		{"Antibody", 8, "Antibody"},
+		{"Antibody", 5, "An…dy"},
+		{"^1Anti^x444body", 6, "^1Ant…^x444dy"},
+		{"^1Anti^x444body", 4, "^1An…^x444y"},
+		{"^1Anti^x444body", 1, "…"},
+		{"^1Anti^x444body", 0, ""},
+	}
+
+	for _, v := range abbreviateList {
+		received := v.Input.AbbreviateMiddle(v.Max)
+		if received != v.Expected {
+			t.Errorf("Incorrect abbreviation of %v to %v characters. Expected: %v, Got: %v.", v.Input, v.Max, v.Expected, received)
+		}
+	}
+}
+
+func TestVisibleLen(t *testing.T) {
+	var lenList = []struct {
+		Input    QStr
+		Expected int
+	}{
+		{"Antibody", 8},
+		{"^1Anti^x444body^7", 8},
+		{"^1Café café", 9},
+		{"^2\U0001f468\u200d\U0001f469\u200d\U0001f467", 1},
+	}
+
+	for _, v := range lenList {
+		received := v.Input.VisibleLen()
+		if received != v.Expected {
+			t.Errorf("Incorrect visible length of %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestWidth(t *testing.T) {
+	var widthList = []struct {
+		Input    QStr
+		Expected int
+	}{
+		{"Antibody", 8},
+		{"^1Anti^x444body^7", 8},
+		{"^1日本^x444語", 6},
+		{"^2\U0001f468\u200d\U0001f469\u200d\U0001f467 gg", 5},
+		{"e\u0301", 1},
+	}
+
+	for _, v := range widthList {
+		received := v.Input.Width()
+		if received != v.Expected {
+			t.Errorf("Incorrect width of %v. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestPad(t *testing.T) {
+	var padList = []struct {
+		Input  QStr
+		Width  int
+		Left   QStr
+		Right  QStr
+		Center QStr
+	}{
+		{"^1Anti^x444body", 10, "  ^1Anti^x444body", "^1Anti^x444body  ", " ^1Anti^x444body "},
+		{"^1ab", 5, "   ^1ab", "^1ab   ", " ^1ab  "},
+		{"^1日本", 6, "  ^1日本", "^1日本  ", " ^1日本 "},
+		{"^1Antibody", 8, "^1Antibody", "^1Antibody", "^1Antibody"},
+		{"^1Antibody", 3, "^1Antibody", "^1Antibody", "^1Antibody"},
+		{"", 2, "  ", "  ", "  "},
+	}
+
+	for _, v := range padList {
+		if received := v.Input.PadLeft(v.Width); received != v.Left {
+			t.Errorf("Incorrect left padding of %v. Expected: %q, Got: %q.", v.Input, v.Left, received)
+		}
+		if received := v.Input.PadRight(v.Width); received != v.Right {
+			t.Errorf("Incorrect right padding of %v. Expected: %q, Got: %q.", v.Input, v.Right, received)
+		}
+		if received := v.Input.Center(v.Width); received != v.Center {
+			t.Errorf("Incorrect centering of %v. Expected: %q, Got: %q.", v.Input, v.Center, received)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	var wrapList = []struct {
+		Input    QStr
+		Width    int
+		Expected []QStr
+	}{
+		{"^1hello ^2world", 5, []QStr{"^1hello", "^2world"}},
+		{"^1hello world", 8, []QStr{"^1hello", "^1world"}},
+		{"^1hello world", 11, []QStr{"^1hello world"}},
+		{"^1abcdefgh", 3, []QStr{"^1abc", "^1def", "^1gh"}},
+		{"ab^1cd ef", 4, []QStr{"ab^1cd", "^1ef"}},
+		{"ab^7 ^1cd", 2, []QStr{"ab", "^1cd"}},
+		{"one\n^3two three", 20, []QStr{"one", "^3two three"}},
+		{"a  b", 1, []QStr{"a", "b"}},
+		{"^1日本語", 4, []QStr{"^1日本", "^1語"}},
+		{"abc", 0, []QStr{"abc"}},
+		{"", 5, nil},
+	}
+
+	for _, v := range wrapList {
+		received := v.Input.Wrap(v.Width)
+		if !reflect.DeepEqual(received, v.Expected) {
+			t.Errorf("Incorrect wrapping of %v at %v. Expected: %q, Got: %q.", v.Input, v.Width, v.Expected, received)
+		}
+	}
+}
+
+func TestSlice(t *testing.T) {
+	var sliceList = []struct {
+		Input    QStr
+		Start    int
+		End      int
+		Expected QStr
+	}{
+		{"^1Anti^x444body", 0, 8, "^1Anti^x444body"},
+		{"^1Anti^x444body", 2, 6, "^1ti^x444bo"},
+		{"^1Anti^x444body", 5, 7, "^x444od"},
+		{"Anti^1body", 1, 3, "nt"},
+		{"^1Anti^x444body", -3, 2, "^1An"},
+		{"^1Anti^x444body", 6, 20, "^x444dy"},
+		{"^1Anti^x444body", 4, 4, ""},
+		{"^1Anti^x444body", 9, 12, ""},
+		{"^3e\u0301t\u00e9", 1, 2, "^3t"},
+	}
+
+	for _, v := range sliceList {
+		received := v.Input.Slice(v.Start, v.End)
+		if received != v.Expected {
+			t.Errorf("Incorrect slice [%v, %v) of %v. Expected: %v, Got: %v.", v.Start, v.End, v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	var truncateList = []struct {
+		Input    QStr
+		N        int
+		Ellipsis string
+		Expected QStr
+	}{
+		{"^1Anti^x444body", 8, "…", "^1Anti^x444body"},
+		{"^1Anti^x444body", 6, "…", "^1Anti^x444b…"},
+		{"^1Anti^x444body", 5, "...", "^1An..."},
+		{"^1Anti^x444body", 4, "", "^1Anti"},
+		{"^1Anti^x444body", 2, "...", ".."},
+		{"Anti^1body", 6, "", "Anti^1bo"},
+		{"^1Anti^7body^2", 5, "", "^1Anti^7b"},
+		{"^1Anti^x4", 5, "", "^1Anti^"},
+		{"^x4AF^x4AFAnti^1^2body", 6, "", "^x4AFAnti^2bo"},
+		{"^1Antibody", 0, "…", ""},
+		{"^1Antibody", -1, "…", ""},
+		{"^1\U0001f468\u200d\U0001f469\u200d\U0001f467\U0001f468\u200d\U0001f469\u200d\U0001f467", 1, "", "^1\U0001f468\u200d\U0001f469\u200d\U0001f467"},
+	}
+
+	for _, v := range truncateList {
+		received := v.Input.Truncate(v.N, v.Ellipsis)
+		if received != v.Expected {
+			t.Errorf("Incorrect truncation of %v to %v characters. Expected: %v, Got: %v.", v.Input, v.N, v.Expected, received)
+		}
+	}
+}
+
+func TestCaseConversion(t *testing.T) {
+	var caseList = []struct {
+		Input, Upper, Lower QStr
+	}{
+		{"^x4F0Anti^1Body", "^x4F0ANTI^1BODY", "^x4F0anti^1body"},
+		{"^^X4F0", "^^X4F0", "^^x4f0"},
+		{"a^X4F0", "A^^X4F0", "a^^x4f0"},
+		{"Straße^7", "STRAßE^7", "straße^7"},
+		{"", "", ""},
+	}
+
+	for _, v := range caseList {
+		if received := v.Input.ToUpper(); received != v.Upper {
+			t.Errorf("Incorrect upper case of %v. Expected: %v, Got: %v.", v.Input, v.Upper, received)
+		}
+		if received := v.Input.ToLower(); received != v.Lower {
+			t.Errorf("Incorrect lower case of %v. Expected: %v, Got: %v.", v.Input, v.Lower, received)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	var splitList = []struct {
+		Input    QStr
+		Sep      string
+		Expected []QStr
+	}{
+		{"^1Anti^2body: ^7gg wp", ": ", []QStr{"^1Anti^2body", "^7gg wp"}},
+		{"^1Anti^2bo: dy: gg", ": ", []QStr{"^1Anti^2bo", "^2dy", "^2gg"}},
+		{"^1a,^^b,", ",", []QStr{"^1a", "^1^b", ""}},
+		{"plain", ":", []QStr{"plain"}},
+	}
+
+	for _, v := range splitList {
+		if received := v.Input.Split(v.Sep); !reflect.DeepEqual(received, v.Expected) {
+			t.Errorf("Incorrect split of %v on %q. Expected: %q, Got: %q.", v.Input, v.Sep, v.Expected, received)
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	var fieldsList = []struct {
+		Input    QStr
+		Expected []QStr
+	}{
+		{"  ^1Anti^2body  says ^3hi ", []QStr{"^1Anti^2body", "^2says", "^3hi"}},
+		{"^1   ", nil},
+		{"one\ttwo", []QStr{"one", "two"}},
+	}
+
+	for _, v := range fieldsList {
+		if received := v.Input.Fields(); !reflect.DeepEqual(received, v.Expected) {
+			t.Errorf("Incorrect fields of %v. Expected: %q, Got: %q.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestReplace(t *testing.T) {
+	var replaceList = []struct {
+		Input    QStr
+		Old, New string
+		N        int
+		Expected QStr
+	}{
+		{"^2you no^1ob", "noob", "****", -1, "^2you ****"},
+		{"^2no^1ob ^3noob noob", "noob", "pro", 2, "^2pro^1 ^3pro noob"},
+		{"^1Anti^2body", "tibo", "", -1, "^1An^2dy"},
+		{"^1ab", "", "-", -1, "^1-a-b-"},
+		{"^1Anti", "x", "y", -1, "^1Anti"},
+	}
+
+	for _, v := range replaceList {
+		if received := v.Input.Replace(v.Old, v.New, v.N); received != v.Expected {
+			t.Errorf("Incorrect replacement of %q in %v. Expected: %v, Got: %v.", v.Old, v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestTruncateWidth(t *testing.T) {
+	var truncateList = []struct {
+		Input    QStr
+		Width    int
+		Ellipsis string
+		Expected QStr
+	}{
+		{"^1Anti^2body", 8, "…", "^1Anti^2body"},
+		{"^1Anti^2body", 6, "…", "^1Anti^2b…"},
+		{"^1日本語^2ok", 6, "…", "^1日本…"},
+		{"^1日本語^2ok", 5, "", "^1日本"},
+		{"^1👨\u200d👩\u200d👧abc", 3, "", "^1👨\u200d👩\u200d👧a"},
+		{"^1Anti", 1, "...", "."},
+		{"^1Anti", 2, "^2..", "^^2"},
+	}
+
+	for _, v := range truncateList {
+		if received := v.Input.TruncateWidth(v.Width, v.Ellipsis); received != v.Expected {
+			t.Errorf("Incorrect truncation of %v to %v cells. Expected: %v, Got: %v.", v.Input, v.Width, v.Expected, received)
+		}
+	}
+}
+
+func TestReverse(t *testing.T) {
+	var reverseList = []struct {
+		Input    QStr
+		Expected QStr
+	}{
+		{"^1ab^2cd", "^2dc^1ba"},
+		{"ab^1cd", "^1dc^7ba"},
+		{"^x4afab^^1", "^x4af1^ba"},
+		{"^1a^^", "^1^a"},
+		{"e\u0301x", "xe\u0301"},
+		{"", ""},
+	}
+
+	for _, v := range reverseList {
+		if received := v.Input.Reverse(); received != v.Expected {
+			t.Errorf("Incorrect reversal of %q. Expected: %q, Got: %q.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestTrim(t *testing.T) {
+	var trimList = []struct {
+		Input    QStr
+		Method   func(*QStr) QStr
+		Expected QStr
+	}{
+		{"^7   player   ^7", (*QStr).TrimSpace, "^7player"},
+		{"  ^1Anti ^2 body^3  ", (*QStr).TrimSpace, "^1Anti ^2 body"},
+		{"^1Anti^2body", (*QStr).TrimSpace, "^1Anti^2body"},
+		{"^1 ^2 ", (*QStr).TrimSpace, ""},
+		{"^1a^^ ", (*QStr).TrimSpace, "^1a^^"},
+		{"^1[clan]^2 nick", func(s *QStr) QStr { return s.TrimPrefix("[clan] ") }, "^2nick"},
+		{"^1[cl^2an]nick", func(s *QStr) QStr { return s.TrimPrefix("[clan]") }, "^2nick"},
+		{"^1[clan]nick", func(s *QStr) QStr { return s.TrimPrefix("nick") }, "^1[clan]nick"},
+		{"^1nick^2 [clan]^7", func(s *QStr) QStr { return s.TrimSuffix(" [clan]") }, "^1nick"},
+		{"^1nick^2 [clan]", func(s *QStr) QStr { return s.TrimSuffix("x") }, "^1nick^2 [clan]"},
+	}
+
+	for _, v := range trimList {
+		if received := v.Method(&v.Input); received != v.Expected {
+			t.Errorf("Incorrect trimming of %q. Expected: %q, Got: %q.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestTruncateBytes(t *testing.T) {
+	var truncateList = []struct {
+		Input    QStr
+		N        int
+		Reset    bool
+		Expected QStr
+	}{
+		{"^1Anti^2body", 12, false, "^1Anti^2body"},
+		{"^1Anti^2body", 8, false, "^1Anti"},
+		{"^1Anti^x4afbody", 10, false, "^1Anti"},
+		{"^1Anti^x4afbody", 12, false, "^1Anti^x4afb"},
+		{"^1日本", 4, false, ""},
+		{"^1日本", 5, false, "^1日"},
+		{"ab^^cd", 3, false, "ab"},
+		{"^1Anti^2body", 8, true, "^1Anti^7"},
+		{"^1Anti^2body", 7, true, "^1Ant^7"},
+		{"^7Anti^2body", 8, true, "^7Anti"},
+		{"Anti^2body", 6, true, "Anti"},
+		{"^1ab^cde", 7, true, "^1ab^7"},
+		{"^1Anti", 1, true, ""},
+	}
+
+	for _, v := range truncateList {
+		received := v.Input.TruncateBytes(v.N)
+		if v.Reset {
+			received = v.Input.TruncateBytesReset(v.N)
+		}
+		if received != v.Expected {
+			t.Errorf("Incorrect truncation of %q to %d bytes. Expected: %q, Got: %q.", v.Input, v.N, v.Expected, received)
+		}
+		if len(received) > v.N {
+			t.Errorf("Incorrect length of %q. Expected: at most %d, Got: %d.", received, v.N, len(received))
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package qstr
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// Theme describes the page a QStr is rendered onto: the palette for the basic
+// color codes, the background color, and the lightness bounds that hex colors
+// are capped to so they stay readable on that background.
+type Theme struct {
+	Palette    Palette
+	Background RGBColor
+
+	// MinLightness and MaxLightness are the bounds passed to CapLightness
+	MinLightness, MaxLightness float64
+}
+
+// DarkTheme is suited to pages with a dark background. It is the default
+// unless changed with SetDefaultTheme.
+var DarkTheme = Theme{
+	Palette:      XonoticPalette,
+	Background:   RGBColor{0, 0, 0},
+	MinLightness: 0.5,
+	MaxLightness: 1.0,
+}
+
+// LightTheme is suited to pages with a light background.
+var LightTheme = Theme{
+	Palette:      XonoticPalette,
+	Background:   RGBColor{1, 1, 1},
+	MinLightness: 0.0,
+	MaxLightness: 0.5,
+}
+
+// ExportSCSS writes the theme as SCSS variables, so stylesheets can share the
+// values used when rendering.
+func (t *Theme) ExportSCSS(w io.Writer) error {
+	return t.export(w, "$", ": ")
+}
+
+// ExportLESS writes the theme as LESS variables, so stylesheets can share the
+// values used when rendering.
+func (t *Theme) ExportLESS(w io.Writer) error {
+	return t.export(w, "@", ": ")
+}
+
+// export writes the theme's variables using the given variable prefix and
+// separator.
+func (t *Theme) export(w io.Writer, prefix, sep string) error {
+	if _, err := fmt.Fprintf(w, "// Generated by qstr; do not edit.\n"); err != nil {
+		return err
+	}
+
+	vars := [][2]string{
+		{"qstr-background", t.Background.Hex()},
+		{"qstr-lightness-min", percent(t.MinLightness)},
+		{"qstr-lightness-max", percent(t.MaxLightness)},
+	}
+	for i, c := range t.Palette {
+		vars = append(vars, [2]string{fmt.Sprintf("qstr-color-%d", i), c.Hex()})
+	}
+
+	for _, v := range vars {
+		if _, err := fmt.Fprintf(w, "%s%s%s%s;\n", prefix, v[0], sep, v[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// to255 converts a channel in the range [0, 1] to the nearest value in the
+// range [0, 255].
+func to255(v float64) int {
+	return int(math.Round(clamp01(v) * 255.0))
+}
+
+// percent formats a fraction in the range [0, 1] as a CSS percentage
+func percent(v float64) string {
+	return fmt.Sprintf("%g%%", math.Round(v*1000)/10)
+}
@@ -0,0 +1,40 @@
+package qstr
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExportSCSS(t *testing.T) {
+	var buf bytes.Buffer
+	if err := DarkTheme.ExportSCSS(&buf); err != nil {
+		t.Fatalf("Unexpected error exporting SCSS: %v.", err)
+	}
+	received := buf.String()
+
+	expectedLines := []string{
+		"$qstr-background: #000000;\n",
+		"$qstr-lightness-min: 50%;\n",
+		"$qstr-lightness-max: 100%;\n",
+		"$qstr-color-0: #808080;\n",
+		"$qstr-color-4: #3366ff;\n",
+	}
+	for _, expected := range expectedLines {
+		if !strings.Contains(received, expected) {
+			t.Errorf("SCSS export is missing %q. Got: %v.", expected, received)
+		}
+	}
+}
+
+func TestExportLESS(t *testing.T) {
+	var buf bytes.Buffer
+	if err := LightTheme.ExportLESS(&buf); err != nil {
+		t.Fatalf("Unexpected error exporting LESS: %v.", err)
+	}
+
+	expected := "@qstr-background: #ffffff;\n"
+	if received := buf.String(); !strings.Contains(received, expected) {
+		t.Errorf("LESS export is missing %q. Got: %v.", expected, received)
+	}
+}
@@ -0,0 +1,145 @@
+package qstr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrTextChanged is returned by Verify when two values differ in their
+// visible text.
+var ErrTextChanged = errors.New("qstr: visible text changed")
+
+// Transform is a function rewriting a QStr. Transforms can be combined with
+// Chain, If, and friends into processing policies.
+type Transform func(QStr) QStr
+
+// Chain returns a Transform applying each of ts in order.
+func Chain(ts ...Transform) Transform {
+	return func(s QStr) QStr {
+		for _, t := range ts {
+			s = t(s)
+		}
+		return s
+	}
+}
+
+// If returns a Transform applying t only to values for which cond is true.
+func If(cond func(QStr) bool, t Transform) Transform {
+	return func(s QStr) QStr {
+		if cond(s) {
+			return t(s)
+		}
+		return s
+	}
+}
+
+// Lossless returns a Transform applying t only where doing so keeps the
+// visible text unchanged. Where t would alter what players see, the value is
+// passed through untouched, so steps that should only touch colors can never
+// rewrite a name.
+func Lossless(t Transform) Transform {
+	return func(s QStr) QStr {
+		out := t(s)
+		if Verify(s, out) != nil {
+			return s
+		}
+		return out
+	}
+}
+
+// Verify returns nil if a and b show the same visible text, and an error
+// wrapping ErrTextChanged otherwise. Only color codes may differ.
+func Verify(a, b QStr) error {
+	if a.Stripped() != b.Stripped() {
+		return fmt.Errorf("%w: %q became %q", ErrTextChanged, a.Stripped(), b.Stripped())
+	}
+	return nil
+}
+
+// Limit returns a Transform cutting values down to at most n visible
+// characters, keeping the colors of the retained text.
+func Limit(n int) Transform {
+	return func(s QStr) QStr {
+		return s.Truncate(n, "")
+	}
+}
+
+// StripColors is a Transform removing all color codes.
+func StripColors(s QStr) QStr {
+	return QStr(s.Stripped())
+}
+
+// Decode returns a Transform translating glyphs using key, adjusted by opts.
+func Decode(key map[rune]rune, opts ...DecodeOption) Transform {
+	key = withDecodeOptions(key, opts)
+	return func(s QStr) QStr {
+		return s.Decode(key)
+	}
+}
+
+// Censor returns a Transform masking each of words in the visible text with
+// asterisks, even when color codes are interleaved in the word. Words are
+// matched whole and without regard to case.
+func Censor(words ...string) Transform {
+	pattern := WordsPattern(words...)
+	return func(s QStr) QStr {
+		return censor(s, pattern, '*')
+	}
+}
+
+// CapColors returns a Transform capping the lightness of every color between
+// floor and ceiling, as CapLightness does. Codes whose color changes are
+// rewritten as ^xNNN codes.
+func CapColors(floor, ceiling float64) Transform {
+	return func(s QStr) QStr {
+		return capColors(s, floor, ceiling)
+	}
+}
+
+// MapColors returns a Transform rewriting every color code through f, as
+// QStr.MapColors does.
+func MapColors(f func(RGBColor) RGBColor) Transform {
+	return func(s QStr) QStr {
+		return s.MapColors(f)
+	}
+}
+
+// CollapseWhitespace is a Transform replacing each run of whitespace in the
+// visible text with a single space and trimming it from both ends. Color
+// codes are kept.
+func CollapseWhitespace(s QStr) QStr {
+	segments := DarkPlaces.Tokenize(s)
+
+	res := make([]Segment, 0, len(segments))
+	space := true // trims leading whitespace
+	for _, seg := range segments {
+		var b strings.Builder
+		for _, c := range seg.Text {
+			if unicode.IsSpace(c) {
+				if !space {
+					b.WriteByte(' ')
+				}
+				space = true
+				continue
+			}
+			b.WriteRune(c)
+			space = false
+		}
+		if b.Len() > 0 {
+			seg.Text = b.String()
+			res = append(res, seg)
+		}
+	}
+
+	// trim trailing whitespace
+	if n := len(res); n > 0 {
+		res[n-1].Text = strings.TrimSuffix(res[n-1].Text, " ")
+		if res[n-1].Text == "" {
+			res = res[:n-1]
+		}
+	}
+
+	return joinSegments(res)
+}
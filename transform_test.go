@@ -0,0 +1,78 @@
+package qstr
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTransforms(t *testing.T) {
+	isLong := func(s QStr) bool {
+		return s.VisibleLen() > 10
+	}
+
+	policy := Chain(
+		CollapseWhitespace,
+		Censor("noob"),
+		If(isLong, Limit(10)),
+	)
+
+	var transformList = []struct {
+		Input    QStr
+		Expected QStr
+	}{
+		{"  ^1Anti  ^2body ", "^1Anti ^2body"},
+		{"^1big  no^2ob", "^1big **^2**"},
+		{"^1a very long^2 nickname", "^1a very lon"},
+		{"", ""},
+	}
+
+	for _, v := range transformList {
+		received := policy(v.Input)
+		if received != v.Expected {
+			t.Errorf("Incorrect transformation of %q. Expected: %q, Got: %q.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestStripColorsTransform(t *testing.T) {
+	expected := QStr("Antibody")
+	received := Chain(StripColors, CapColors(0.5, 1))("^x000Anti^7body")
+
+	if received != expected {
+		t.Errorf("Incorrect transformation. Expected: %v, Got: %v.", expected, received)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	if err := Verify("^1Anti^2body", "^x4AFAntibody"); err != nil {
+		t.Errorf("Incorrect verification. Expected: %v, Got: %v.", nil, err)
+	}
+
+	if err := Verify("^1Anti^2body", "^1Anti^2bodY"); !errors.Is(err, ErrTextChanged) {
+		t.Errorf("Incorrect verification. Expected: %v, Got: %v.", ErrTextChanged, err)
+	}
+
+	nick := QStr("^1Anti^2body")
+	if received := Lossless(StripColors)(nick); received != "Antibody" {
+		t.Errorf("Incorrect lossless result for %v. Expected: %v, Got: %v.", nick, "Antibody", received)
+	}
+	if received := Lossless(Limit(4))(nick); received != nick {
+		t.Errorf("Incorrect lossless result for %v. Expected: %v, Got: %v.", nick, nick, received)
+	}
+	if received := nick.Raw(); received != "^1Anti^2body" {
+		t.Errorf("Incorrect raw value. Expected: %v, Got: %v.", "^1Anti^2body", received)
+	}
+}
+
+func TestMapColorsTransform(t *testing.T) {
+	gray := func(c RGBColor) RGBColor {
+		l := (c.R + c.G + c.B) / 3
+		return RGBColor{l, l, l}
+	}
+
+	expected := QStr("^x555Anti^7body")
+	received := MapColors(gray)("^1Anti^7body")
+	if received != expected {
+		t.Errorf("Incorrect transformation. Expected: %v, Got: %v.", expected, received)
+	}
+}
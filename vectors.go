@@ -0,0 +1,113 @@
+package qstr
+
+// TestVector is a reference conversion of a QStr with the default options,
+// for checking that other implementations, such as a JavaScript frontend,
+// produce byte-for-byte the same output as this package.
+type TestVector struct {
+	// Name briefly describes what the vector covers.
+	Name string `json:"name"`
+
+	Input    QStr            `json:"input"`
+	Stripped string          `json:"stripped"`
+	HTML     string          `json:"html"`
+	ANSI     string          `json:"ansi"`
+	Segments []VectorSegment `json:"segments"`
+}
+
+// VectorSegment is a segment of a TestVector's input.
+type VectorSegment struct {
+	Text string `json:"text"`
+
+	// Code is the segment's color code in ^ form, if it has one.
+	Code Code `json:"code,omitempty"`
+
+	// Color is the color of Code as #rrggbb, with ^N colors taken from
+	// XonoticPalette.
+	Color string `json:"color,omitempty"`
+}
+
+// TestVectors returns the reference test vectors of the package. The vectors
+// only change along with the output they describe, and are returned as a
+// fresh copy that the caller may modify; they encode directly to JSON for
+// tooling written in other languages.
+func TestVectors() []TestVector {
+	return []TestVector{
+		{
+			Name:     "plain",
+			Input:    "Antibody",
+			Stripped: "Antibody",
+			HTML:     "Antibody",
+			ANSI:     "Antibody",
+			Segments: []VectorSegment{{Text: "Antibody"}},
+		},
+		{
+			Name:     "decimal codes",
+			Input:    "^1Anti^2body",
+			Stripped: "Antibody",
+			HTML:     "<span style='color:rgb(255,0,0)'>Anti<span style='color:rgb(51,255,0)'>body</span></span>",
+			ANSI:     "\x1b[38;2;255;0;0mAnti\x1b[0;38;2;51;255;0mbody\x1b[0m",
+			Segments: []VectorSegment{{"Anti", "^1", "#ff0000"}, {"body", "^2", "#33ff00"}},
+		},
+		{
+			Name:     "hex codes with lightness capping",
+			Input:    "^x4afAnti^x000body",
+			Stripped: "Antibody",
+			HTML:     "<span style=\"color:rgb(68,170,255)\">Anti<span style=\"color:rgb(127,127,127)\">body</span></span>",
+			ANSI:     "\x1b[38;2;68;170;255mAnti\x1b[0;38;2;128;128;128mbody\x1b[0m",
+			Segments: []VectorSegment{{"Anti", "^x4af", "#44aaff"}, {"body", "^x000", "#000000"}},
+		},
+		{
+			Name:     "markup is escaped",
+			Input:    "^1<b>&amp;</b>",
+			Stripped: "<b>&amp;</b>",
+			HTML:     "<span style='color:rgb(255,0,0)'>&lt;b&gt;&amp;amp;&lt;/b&gt;</span>",
+			ANSI:     "\x1b[38;2;255;0;0m<b>&amp;</b>\x1b[0m",
+			Segments: []VectorSegment{{"<b>&amp;</b>", "^1", "#ff0000"}},
+		},
+		{
+			Name:     "escaped caret",
+			Input:    "^^1caret",
+			Stripped: "^1caret",
+			HTML:     "^1caret",
+			ANSI:     "^1caret",
+			Segments: []VectorSegment{{Text: "^1caret"}},
+		},
+		{
+			Name:     "escaped caret before a code and a trailing caret",
+			Input:    "^^^1caret^",
+			Stripped: "^caret^",
+			HTML:     "^<span style='color:rgb(255,0,0)'>caret^</span>",
+			ANSI:     "^\x1b[38;2;255;0;0mcaret^\x1b[0m",
+			Segments: []VectorSegment{{Text: "^"}, {"caret^", "^1", "#ff0000"}},
+		},
+		{
+			Name:     "malformed and trailing codes",
+			Input:    "^x4gbad ^xabc",
+			Stripped: "^x4gbad ",
+			HTML:     "^x4gbad ",
+			ANSI:     "^x4gbad ",
+			Segments: []VectorSegment{{Text: "^x4gbad "}},
+		},
+		{
+			Name:     "non-ASCII text",
+			Input:    "^3ntié",
+			Stripped: "ntié",
+			HTML:     "<span style='color:rgb(255,255,0)'>ntié</span>",
+			ANSI:     "\x1b[38;2;255;255;0mntié\x1b[0m",
+			Segments: []VectorSegment{{"ntié", "^3", "#ffff00"}},
+		},
+		{
+			Name:     "codes without text",
+			Input:    "^1^2^7",
+			Segments: []VectorSegment{},
+		},
+		{
+			Name:     "uncolored text before a code",
+			Input:    "a^7b",
+			Stripped: "ab",
+			HTML:     "a<span style='color:rgb(255,255,255)'>b</span>",
+			ANSI:     "a\x1b[38;2;255;255;255mb\x1b[0m",
+			Segments: []VectorSegment{{Text: "a"}, {"b", "^7", "#ffffff"}},
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package qstr
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestTestVectors(t *testing.T) {
+	for _, v := range TestVectors() {
+		if received := v.Input.Stripped(); received != v.Stripped {
+			t.Errorf("Incorrect stripped value for %v. Expected: %q, Got: %q.", v.Name, v.Stripped, received)
+		}
+		if received := string(v.Input.HTML()); received != v.HTML {
+			t.Errorf("Incorrect HTML for %v. Expected: %q, Got: %q.", v.Name, v.HTML, received)
+		}
+		if received := v.Input.ANSI(); received != v.ANSI {
+			t.Errorf("Incorrect ANSI for %v. Expected: %q, Got: %q.", v.Name, v.ANSI, received)
+		}
+
+		segments := []VectorSegment{}
+		for _, seg := range v.Input.Tokenize() {
+			vs := VectorSegment{Text: seg.Text, Code: seg.Code}
+			if seg.Code != "" {
+				vs.Color = seg.Color.Hex()
+			}
+			segments = append(segments, vs)
+		}
+		if !reflect.DeepEqual(segments, v.Segments) {
+			t.Errorf("Incorrect segments for %v. Expected: %+v, Got: %+v.", v.Name, v.Segments, segments)
+		}
+	}
+}
+
+func TestTestVectorsJSON(t *testing.T) {
+	b, err := json.Marshal(TestVectors())
+	if err != nil {
+		t.Fatalf("Incorrect encoding of test vectors. Got error: %v.", err)
+	}
+	var received []TestVector
+	if err := json.Unmarshal(b, &received); err != nil || !reflect.DeepEqual(received, TestVectors()) {
+		t.Errorf("Incorrect JSON round trip of test vectors. Expected: %+v, Got: %+v (%v).", TestVectors(), received, err)
+	}
+
+	// callers may modify the vectors they are given
+	TestVectors()[0].Input = "changed"
+	if TestVectors()[0].Input != "Antibody" {
+		t.Errorf("Incorrect vectors after modification. Expected: Antibody, Got: %v.", TestVectors()[0].Input)
+	}
+}
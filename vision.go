@@ -0,0 +1,115 @@
+package qstr
+
+// ColorVision is a form of color blindness.
+type ColorVision int
+
+const (
+	// Protanopia is the absence of red-sensitive cones.
+	Protanopia ColorVision = iota
+
+	// Deuteranopia is the absence of green-sensitive cones, the most
+	// common form of color blindness.
+	Deuteranopia
+
+	// Tritanopia is the absence of blue-sensitive cones.
+	Tritanopia
+)
+
+// visionMatrices simulate each ColorVision in linear RGB, from Machado,
+// Oliveira and Fernandes' physiologically-based model at full severity.
+var visionMatrices = [...][3][3]float64{
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Tritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// minVisionDistance is the DeltaE below which AdjustForColorVision considers
+// two colors hard to tell apart.
+const minVisionDistance = 10.0
+
+// SimulateColorVision returns c as it appears to someone with color vision
+// v.
+func (c *RGBColor) SimulateColorVision(v ColorVision) RGBColor {
+	m := visionMatrices[v]
+	r, g, b := linearize(c.R), linearize(c.G), linearize(c.B)
+	channel := func(row [3]float64) float64 {
+		l := row[0]*r + row[1]*g + row[2]*b
+		if l < 0 {
+			l = 0
+		} else if l > 1 {
+			l = 1
+		}
+		return delinearize(l)
+	}
+	return RGBColor{channel(m[0]), channel(m[1]), channel(m[2])}
+}
+
+// SimulateColorVision returns s with every color code replaced by the
+// nearest ^xNNN code to its color as it appears to someone with color vision
+// v, for previewing how a name looks to them. See MapColors.
+func (s *QStr) SimulateColorVision(v ColorVision) QStr {
+	return s.MapColors(func(c RGBColor) RGBColor {
+		return c.SimulateColorVision(v)
+	})
+}
+
+// AdjustForColorVision returns s with colors that look distinct to most
+// readers but alike to someone with color vision v nudged apart, by
+// lightening or darkening the later of the two in OKLCH as little as needed.
+// Colors are otherwise left as they are, so the result can be shown to every
+// reader.
+func (s *QStr) AdjustForColorVision(v ColorVision) QStr {
+	var accepted []RGBColor
+	adjusted := make(map[RGBColor]RGBColor)
+
+	distinct := func(c RGBColor) bool {
+		sim := c.SimulateColorVision(v)
+		for _, o := range accepted {
+			if DeltaE(sim, o.SimulateColorVision(v)) < minVisionDistance {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, c := range s.Colors() {
+		if _, ok := adjusted[c]; ok {
+			continue
+		}
+
+		result := c
+		if !distinct(c) {
+			lch := c.OKLCH()
+			for step := 0.05; step <= 0.5; step += 0.05 {
+				lighter := OKLCHColor{min(lch.L+step, 1), lch.C, lch.H}
+				darker := OKLCHColor{max(lch.L-step, 0), lch.C, lch.H}
+				if candidate := lighter.RGB(); distinct(candidate) {
+					result = candidate
+					break
+				}
+				if candidate := darker.RGB(); distinct(candidate) {
+					result = candidate
+					break
+				}
+			}
+		}
+		adjusted[c] = result
+		accepted = append(accepted, result)
+	}
+
+	return s.MapColors(func(c RGBColor) RGBColor {
+		return adjusted[c]
+	})
+}
@@ -0,0 +1,55 @@
+package qstr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSimulateColorVision(t *testing.T) {
+	red, green := RGBColor{1, 0, 0}, RGBColor{0, 1, 0}
+	for _, v := range []ColorVision{Protanopia, Deuteranopia} {
+		r, g := red.SimulateColorVision(v), green.SimulateColorVision(v)
+		if DeltaE(r, g) >= DeltaE(red, green)/2 {
+			t.Errorf("Incorrect simulation of %v. Expected red and green to look alike, Got: %v and %v.", v, r, g)
+		}
+	}
+
+	for _, v := range []ColorVision{Protanopia, Deuteranopia, Tritanopia} {
+		gray := RGBColor{0.5, 0.5, 0.5}
+		received := gray.SimulateColorVision(v)
+		if math.Abs(received.R-0.5) > 0.01 || math.Abs(received.G-0.5) > 0.01 || math.Abs(received.B-0.5) > 0.01 {
+			t.Errorf("Incorrect simulation of %v for %v. Expected: %v, Got: %v.", v, gray, gray, received)
+		}
+	}
+
+	nick := QStr("^1Anti^7body")
+	expected := QStr("^xA80Anti^xFFFbody")
+	if received := nick.SimulateColorVision(Deuteranopia); received != expected {
+		t.Errorf("Incorrect simulation of %v. Expected: %v, Got: %v.", nick, expected, received)
+	}
+}
+
+func TestAdjustForColorVision(t *testing.T) {
+	nick := QStr("^1Red^2Green^1Red")
+	received := nick.AdjustForColorVision(Deuteranopia)
+	if received.Stripped() != nick.Stripped() {
+		t.Errorf("Incorrect text after adjusting %v. Expected: %v, Got: %v.", nick, nick.Stripped(), received.Stripped())
+	}
+
+	colors := received.Colors()
+	if colors[0] != colors[2] {
+		t.Errorf("Incorrect adjustment of %v. Expected equal colors to stay equal, Got: %v.", nick, received)
+	}
+	a, b := colors[0].SimulateColorVision(Deuteranopia), colors[1].SimulateColorVision(Deuteranopia)
+	if DeltaE(a, b) < minVisionDistance {
+		t.Errorf("Incorrect adjustment of %v. Expected distinguishable colors, Got: %v.", nick, received)
+	}
+	if received[:2] != "^1" {
+		t.Errorf("Incorrect adjustment of %v. Expected the first color kept, Got: %v.", nick, received)
+	}
+
+	distinct := QStr("^1Anti^4body")
+	if received := distinct.AdjustForColorVision(Deuteranopia); received != distinct {
+		t.Errorf("Incorrect adjustment of %v. Expected: %v, Got: %v.", distinct, distinct, received)
+	}
+}
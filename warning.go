@@ -0,0 +1,158 @@
+package qstr
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"sort"
+)
+
+// WarningKind classifies the problems reported in a Warning.
+type WarningKind int
+
+const (
+	// MalformedCode is a caret that starts but doesn't complete a color
+	// code, such as ^x4g or a trailing ^. It is shown as text.
+	MalformedCode WarningKind = iota
+
+	// ColorAdjusted is a color code whose color was changed to keep the
+	// text readable, such as a hex color capped to the theme's lightness
+	// bounds or a color adjusted for contrast with WithMinContrast.
+	ColorAdjusted
+
+	// OutputTruncated is output cut short: the value was cut at Offset by
+	// WithSizeLimit, or shown without its colors from the code at Offset on
+	// once the limit set with WithCodeLimit was reached.
+	OutputTruncated
+)
+
+// String returns a short name for the kind.
+func (k WarningKind) String() string {
+	switch k {
+	case MalformedCode:
+		return "malformed code"
+	case ColorAdjusted:
+		return "color adjusted"
+	case OutputTruncated:
+		return "output truncated"
+	}
+	return fmt.Sprintf("WarningKind(%d)", int(k))
+}
+
+// Warning is a non-fatal problem found while converting a QStr. The output
+// is still produced, but ingestion services may want to log the problem
+// rather than have it fixed silently.
+type Warning struct {
+	Kind WarningKind
+
+	// Offset is the byte offset into the raw value of the code concerned,
+	// or where the output was cut short.
+	Offset int
+
+	// Text is the raw text of the code concerned, if any.
+	Text string
+
+	// From and To are the color of the code and the color used instead,
+	// for ColorAdjusted warnings.
+	From, To RGBColor
+}
+
+// String describes the warning.
+func (w Warning) String() string {
+	switch w.Kind {
+	case ColorAdjusted:
+		return fmt.Sprintf("%s: %s at offset %d shown as %s instead of %s", w.Kind, w.Text, w.Offset, w.To.Hex(), w.From.Hex())
+	case OutputTruncated:
+		return fmt.Sprintf("%s at offset %d", w.Kind, w.Offset)
+	}
+	return fmt.Sprintf("%s: %q at offset %d", w.Kind, w.Text, w.Offset)
+}
+
+// HTMLWithWarnings returns the HTML representation of s, as HTML does, along
+// with the warnings raised while converting it.
+func (r *Renderer) HTMLWithWarnings(s QStr) (template.HTML, []Warning) {
+	w, warn := r.withWarnings()
+	h, _ := w.renderHTML(context.Background(), s, nil)
+	return h, warn.sorted()
+}
+
+// ANSIWithWarnings returns s converted for display in a terminal, as ANSI
+// does, along with the warnings raised while converting it.
+func (r *Renderer) ANSIWithWarnings(s QStr) (string, []Warning) {
+	w, warn := r.withWarnings()
+	out, _ := w.renderANSI(context.Background(), s)
+	return out, warn.sorted()
+}
+
+// withWarnings returns a copy of r that collects the warnings raised while
+// it converts a single value into the returned list. The copy bypasses the
+// cache, since a cached result would raise no warnings.
+func (r *Renderer) withWarnings() (*Renderer, *warningList) {
+	w := *r
+	w.cache = nil
+	w.warn = &warningList{adjusted: -1}
+	return &w, w.warn
+}
+
+// warningList collects the warnings raised during a single conversion
+type warningList struct {
+	warnings []Warning
+
+	// the offset and raw text of the code of the segment being converted,
+	// and the index of the ColorAdjusted warning raised for it, or -1
+	offset   int
+	code     string
+	adjusted int
+}
+
+// add appends w to the list
+func (l *warningList) add(w Warning) {
+	l.warnings = append(l.warnings, w)
+}
+
+// setCode makes the code at offset, spelled code, the current one
+func (l *warningList) setCode(offset int, code string) {
+	if offset == l.offset && code == l.code {
+		return
+	}
+	l.offset, l.code, l.adjusted = offset, code, -1
+}
+
+// sorted returns the warnings in order of offset
+func (l *warningList) sorted() []Warning {
+	sort.SliceStable(l.warnings, func(i, j int) bool {
+		return l.warnings[i].Offset < l.warnings[j].Offset
+	})
+	return l.warnings
+}
+
+// adjusted records that the color of the current code was changed from from
+// to to. Adjustments made one after another to the same code are reported
+// as one.
+func (r *Renderer) adjusted(from, to RGBColor) {
+	l := r.warn
+	if l == nil || from == to || l.code == "" {
+		return
+	}
+	if l.adjusted >= 0 {
+		l.warnings[l.adjusted].To = to
+		return
+	}
+	l.adjusted = len(l.warnings)
+	l.add(Warning{Kind: ColorAdjusted, Offset: l.offset, Text: l.code, From: from, To: to})
+}
+
+// truncated records that the value was cut short at offset
+func (r *Renderer) truncated(offset int) {
+	if r.warn != nil {
+		r.warn.add(Warning{Kind: OutputTruncated, Offset: offset})
+	}
+}
+
+// colorsDropped records that the colors were dropped from the current code
+// on
+func (r *Renderer) colorsDropped() {
+	if r.warn != nil {
+		r.truncated(r.warn.offset)
+	}
+}
@@ -0,0 +1,112 @@
+package qstr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTMLWithWarnings(t *testing.T) {
+	nick := QStr("^x000Anti^1body^x4a^xfffz^")
+	r := NewRenderer()
+
+	h, warnings := r.HTMLWithWarnings(nick)
+	if h != r.HTML(nick) {
+		t.Errorf("Incorrect HTML of %v. Expected: %v, Got: %v.", nick, r.HTML(nick), h)
+	}
+
+	expected := []struct {
+		Kind   WarningKind
+		Offset int
+		Text   string
+	}{
+		{ColorAdjusted, 0, "^x000"},
+		{MalformedCode, 15, "^x4a"},
+		{MalformedCode, 25, "^"},
+	}
+	if len(warnings) != len(expected) {
+		t.Fatalf("Incorrect warnings for %v. Expected: %v, Got: %v.", nick, expected, warnings)
+	}
+	for i, e := range expected {
+		w := warnings[i]
+		if w.Kind != e.Kind || w.Offset != e.Offset || w.Text != e.Text {
+			t.Errorf("Incorrect warning %v for %v. Expected: %v, Got: %v.", i, nick, e, w)
+		}
+	}
+
+	if w := warnings[0]; w.From != (RGBColor{}) || w.To == w.From {
+		t.Errorf("Incorrect colors in %v. Expected black to be lightened.", w)
+	}
+	expectedText := "color adjusted: ^x000 at offset 0 shown as #808080 instead of #000000"
+	if received := warnings[0].String(); received != expectedText {
+		t.Errorf("Incorrect description. Expected: %v, Got: %v.", expectedText, received)
+	}
+}
+
+func TestWarningsByFormat(t *testing.T) {
+	nick := QStr("^7Anti^x000body")
+
+	// the email profile caps the basic codes too
+	_, warnings := NewRenderer(WithEmail()).HTMLWithWarnings(nick)
+	if len(warnings) != 1 || warnings[0].Text != "^7" {
+		t.Errorf("Incorrect email warnings for %v. Expected: [^7], Got: %v.", nick, warnings)
+	}
+
+	// the background modes keep the colors as they are
+	_, warnings = NewRenderer(WithBackground(BackgroundOnly)).HTMLWithWarnings(nick)
+	if len(warnings) != 0 {
+		t.Errorf("Incorrect background mode warnings for %v. Expected: [], Got: %v.", nick, warnings)
+	}
+
+	_, warnings = NewRenderer(WithBackground(BackgroundOnly)).ANSIWithWarnings(nick)
+	if len(warnings) != 1 || warnings[0].Text != "^x000" {
+		t.Errorf("Incorrect ANSI warnings for %v. Expected: [^x000], Got: %v.", nick, warnings)
+	}
+
+	// extension codes aren't malformed
+	d := &Dialect{Codes: []ExtCode{StyleCode("xb", Bold)}}
+	_, warnings = NewRenderer(WithDialect(d)).HTMLWithWarnings("^xbAnti")
+	if len(warnings) != 0 {
+		t.Errorf("Incorrect warnings for an extension code. Expected: [], Got: %v.", warnings)
+	}
+}
+
+func TestWarningsDuringRender(t *testing.T) {
+	var warningList = []struct {
+		Input    QStr
+		Options  []Option
+		Expected []string
+	}{
+		// the size limit cuts the value, the code limit drops the colors
+		{"^1Antibody", []Option{WithSizeLimit(8)}, []string{"output truncated at offset 8"}},
+		{"^1An^2ti^3body", []Option{WithCodeLimit(2)}, []string{"output truncated at offset 8"}},
+		// contrast adjustments are reported once per code
+		{"^x000Anti^x000body", []Option{WithMinContrast(RGBColor{}, ContrastAA)}, []string{
+			"color adjusted: ^x000 at offset 0 shown as #747474 instead of #000000",
+			"color adjusted: ^x000 at offset 9 shown as #747474 instead of #000000",
+		}},
+		// links make the renderer tokenize up front
+		{"^x000go to http://x.org ^x4a^x000now", []Option{WithLinks(), WithCodeLimit(1)}, []string{
+			"color adjusted: ^x000 at offset 0 shown as #808080 instead of #000000",
+			"malformed code: \"^x4a\" at offset 24",
+			"output truncated at offset 28",
+		}},
+	}
+
+	for _, v := range warningList {
+		_, warnings := NewRenderer(v.Options...).HTMLWithWarnings(v.Input)
+		received := make([]string, 0, len(warnings))
+		for _, w := range warnings {
+			received = append(received, w.String())
+		}
+		if strings.Join(received, "\n") != strings.Join(v.Expected, "\n") {
+			t.Errorf("Incorrect warnings for %q. Expected: %q, Got: %q.", v.Input, v.Expected, received)
+		}
+	}
+
+	// segments split by extension codes share the warning of their code
+	d := &Dialect{Codes: []ExtCode{StyleCode("b", Bold)}}
+	_, warnings := NewRenderer(WithDialect(d)).ANSIWithWarnings("^x000An^bti")
+	if len(warnings) != 1 || warnings[0].Kind != ColorAdjusted {
+		t.Errorf("Incorrect warnings for a code followed by an extension code. Expected: [^x000], Got: %v.", warnings)
+	}
+}
@@ -0,0 +1,84 @@
+package qstr
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WidthFunc returns the number of fixed-width cells a grapheme cluster takes
+// up when displayed.
+type WidthFunc func(grapheme string) int
+
+// wideRanges are the code point ranges displayed two cells wide: East Asian
+// wide and fullwidth characters along with emoji presented as pictographs.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115f}, {0x231a, 0x231b}, {0x2329, 0x232a}, {0x23e9, 0x23ec},
+	{0x23f0, 0x23f0}, {0x23f3, 0x23f3}, {0x25fd, 0x25fe}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267f, 0x267f}, {0x2693, 0x2693}, {0x26a1, 0x26a1},
+	{0x26aa, 0x26ab}, {0x26bd, 0x26be}, {0x26c4, 0x26c5}, {0x26ce, 0x26ce},
+	{0x26d4, 0x26d4}, {0x26ea, 0x26ea}, {0x26f2, 0x26f3}, {0x26f5, 0x26f5},
+	{0x26fa, 0x26fa}, {0x26fd, 0x26fd}, {0x2705, 0x2705}, {0x270a, 0x270b},
+	{0x2728, 0x2728}, {0x274c, 0x274c}, {0x274e, 0x274e}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27b0, 0x27b0}, {0x27bf, 0x27bf},
+	{0x2b1b, 0x2b1c}, {0x2b50, 0x2b50}, {0x2b55, 0x2b55}, {0x2e80, 0x303e},
+	{0x3041, 0x33ff}, {0x3400, 0x4dbf}, {0x4e00, 0x9fff}, {0xa000, 0xa4cf},
+	{0xa960, 0xa97f}, {0xac00, 0xd7a3}, {0xf900, 0xfaff}, {0xfe10, 0xfe19},
+	{0xfe30, 0xfe6f}, {0xff00, 0xff60}, {0xffe0, 0xffe6}, {0x16fe0, 0x16fe4},
+	{0x17000, 0x18cff}, {0x1b000, 0x1b2ff}, {0x1f004, 0x1f004}, {0x1f0cf, 0x1f0cf},
+	{0x1f18e, 0x1f18e}, {0x1f191, 0x1f19a}, {0x1f200, 0x1f202}, {0x1f210, 0x1f23b},
+	{0x1f240, 0x1f248}, {0x1f250, 0x1f251}, {0x1f260, 0x1f265}, {0x1f300, 0x1f320},
+	{0x1f32d, 0x1f335}, {0x1f337, 0x1f37c}, {0x1f37e, 0x1f393}, {0x1f3a0, 0x1f3ca},
+	{0x1f3cf, 0x1f3d3}, {0x1f3e0, 0x1f3f0}, {0x1f3f4, 0x1f3f4}, {0x1f3f8, 0x1f43e},
+	{0x1f440, 0x1f440}, {0x1f442, 0x1f4fc}, {0x1f4ff, 0x1f53d}, {0x1f54b, 0x1f54e},
+	{0x1f550, 0x1f567}, {0x1f57a, 0x1f57a}, {0x1f595, 0x1f596}, {0x1f5a4, 0x1f5a4},
+	{0x1f5fb, 0x1f64f}, {0x1f680, 0x1f6c5}, {0x1f6cc, 0x1f6cc}, {0x1f6d0, 0x1f6d2},
+	{0x1f6d5, 0x1f6d7}, {0x1f6eb, 0x1f6ec}, {0x1f6f4, 0x1f6fc}, {0x1f7e0, 0x1f7eb},
+	{0x1f90c, 0x1f93a}, {0x1f93c, 0x1f945}, {0x1f947, 0x1f9ff}, {0x1fa70, 0x1faff},
+	{0x20000, 0x2fffd}, {0x30000, 0x3fffd},
+}
+
+// CellWidth is the default WidthFunc. It follows wcwidth: East Asian wide and
+// fullwidth characters and emoji take two cells, control characters and lone
+// combining marks take none, and everything else takes one. Xonotic glyphs
+// are measured by the character they decode to, and a variation selector
+// requesting emoji presentation widens the cluster to two cells.
+func CellWidth(grapheme string) int {
+	c, _ := utf8.DecodeRuneInString(grapheme)
+	if d, ok := XonoticDecodeKey[c]; ok {
+		c = d
+	}
+
+	switch {
+	case c == utf8.RuneError && len(grapheme) == 0:
+		return 0
+	case unicode.IsControl(c) || unicode.Is(unicode.Cf, c) || isGraphemeExtend(c):
+		return 0
+	case isWide(c) || strings.ContainsRune(grapheme, '\ufe0f'):
+		return 2
+	case isRegionalIndicator(c):
+		// a flag made up of two indicators is an emoji
+		return 2
+	}
+	return 1
+}
+
+// isWide reports whether c is displayed two cells wide
+func isWide(c rune) bool {
+	i := sort.Search(len(wideRanges), func(i int) bool {
+		return wideRanges[i][1] >= c
+	})
+	return i < len(wideRanges) && wideRanges[i][0] <= c
+}
+
+// textWidth returns the total width of text measured cluster by cluster
+func textWidth(text string, width WidthFunc) int {
+	w := 0
+	for len(text) > 0 {
+		n := nextGrapheme(text)
+		w += width(text[:n])
+		text = text[n:]
+	}
+	return w
+}
@@ -0,0 +1,47 @@
+package qstr
+
+import (
+	"testing"
+)
+
+func TestCellWidth(t *testing.T) {
+	var widthList = []struct {
+		Input    string
+		Expected int
+	}{
+		{"a", 1},
+		{"é", 1},
+		{"日", 2},
+		{"Ａ", 2},
+		{"\U0001f468\u200d\U0001f469\u200d\U0001f467", 2},
+		{"❤\ufe0f", 2},
+		{"❤", 1},
+		{"\u200b", 0},
+		{"\ue008", 2},
+		{"\ue041", 1},
+	}
+
+	for _, v := range widthList {
+		received := CellWidth(v.Input)
+		if received != v.Expected {
+			t.Errorf("Incorrect width of %q. Expected: %v, Got: %v.", v.Input, v.Expected, received)
+		}
+	}
+}
+
+func TestRendererWidth(t *testing.T) {
+	nick := QStr("^1日本^x444語 nick")
+	expected := 11
+	received := NewRenderer().Width(nick)
+
+	if received != expected {
+		t.Errorf("Incorrect width of %v. Expected: %v, Got: %v.", nick, expected, received)
+	}
+
+	// a custom width function applies to the whole string
+	expected = 8
+	received = NewRenderer(WithWidthFunc(func(string) int { return 1 })).Width(nick)
+	if received != expected {
+		t.Errorf("Incorrect width of %v. Expected: %v, Got: %v.", nick, expected, received)
+	}
+}
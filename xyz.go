@@ -0,0 +1,66 @@
+package qstr
+
+// XYZColor is a color in the CIE 1931 XYZ space relative to the D65 white
+// point, the white of sRGB, with Y scaled so that white has a Y of 1. White
+// is then X 0.95047, Y 1, Z 1.08883.
+type XYZColor struct {
+	X, Y, Z float64
+}
+
+// XYZ converts c into the XYZ space.
+func (c *RGBColor) XYZ() XYZColor {
+	r, g, b := linearize(c.R), linearize(c.G), linearize(c.B)
+	return XYZColor{
+		0.4124*r + 0.3576*g + 0.1805*b,
+		0.2126*r + 0.7152*g + 0.0722*b,
+		0.0193*r + 0.1192*g + 0.9505*b,
+	}
+}
+
+// RGB converts c into an sRGB color. Colors outside the sRGB gamut are
+// clamped to it.
+func (c *XYZColor) RGB() RGBColor {
+	r := 3.2406*c.X - 1.5372*c.Y - 0.4986*c.Z
+	g := -0.9689*c.X + 1.8758*c.Y + 0.0415*c.Z
+	b := 0.0557*c.X - 0.2040*c.Y + 1.0570*c.Z
+	return RGBColor{clamp01(delinearize(clamp01(r))), clamp01(delinearize(clamp01(g))), clamp01(delinearize(clamp01(b)))}
+}
+
+// FromXYZ returns the sRGB color of the XYZ color x, y, z, relative to the
+// D65 white point as described for XYZColor.
+func FromXYZ(x, y, z float64) RGBColor {
+	c := XYZColor{x, y, z}
+	return c.RGB()
+}
+
+// CMYKColor is a color given by the cyan, magenta, yellow, and black ink
+// coverages used in printing, each in the range [0, 1].
+type CMYKColor struct {
+	C, M, Y, K float64
+}
+
+// CMYK converts c into ink coverages with the naive device-independent
+// formula, taking as much black as possible. Print shops working from an ICC
+// profile will convert more accurately, but this gives a usable starting
+// point.
+func (c *RGBColor) CMYK() CMYKColor {
+	k := 1 - max(c.R, c.G, c.B)
+	if k == 1 {
+		return CMYKColor{0, 0, 0, 1}
+	}
+	return CMYKColor{(1 - c.R - k) / (1 - k), (1 - c.G - k) / (1 - k), (1 - c.B - k) / (1 - k), k}
+}
+
+// RGB converts c into an sRGB color with the naive formula described for
+// CMYK. Coverages are clamped to [0, 1].
+func (c *CMYKColor) RGB() RGBColor {
+	k := 1 - clamp01(c.K)
+	return RGBColor{(1 - clamp01(c.C)) * k, (1 - clamp01(c.M)) * k, (1 - clamp01(c.Y)) * k}
+}
+
+// FromCMYK returns the sRGB color of the ink coverages c, m, y, and k, as
+// CMYKColor.RGB does.
+func FromCMYK(c, m, y, k float64) RGBColor {
+	v := CMYKColor{c, m, y, k}
+	return v.RGB()
+}
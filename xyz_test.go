@@ -0,0 +1,51 @@
+package qstr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestXYZ(t *testing.T) {
+	var xyzList = []struct {
+		Input    RGBColor
+		Expected XYZColor
+	}{
+		{RGBColor{1, 1, 1}, XYZColor{0.9505, 1, 1.089}},
+		{RGBColor{0, 0, 0}, XYZColor{0, 0, 0}},
+		{RGBColor{1, 0, 0}, XYZColor{0.4124, 0.2126, 0.0193}},
+	}
+
+	for _, v := range xyzList {
+		received := v.Input.XYZ()
+		if math.Abs(received.X-v.Expected.X) > 1e-3 || math.Abs(received.Y-v.Expected.Y) > 1e-3 || math.Abs(received.Z-v.Expected.Z) > 1e-3 {
+			t.Errorf("Incorrect XYZ of %v. Expected: %+v, Got: %+v.", v.Input, v.Expected, received)
+		}
+		// the matrices are rounded, so the round trip is exact to 8 bits
+		if back := FromXYZ(received.X, received.Y, received.Z); back.Hex() != v.Input.Hex() {
+			t.Errorf("Incorrect round trip of %v. Expected: %v, Got: %v.", v.Input, v.Input, back)
+		}
+	}
+}
+
+func TestCMYK(t *testing.T) {
+	var cmykList = []struct {
+		Input    RGBColor
+		Expected CMYKColor
+	}{
+		{RGBColor{1, 1, 1}, CMYKColor{0, 0, 0, 0}},
+		{RGBColor{0, 0, 0}, CMYKColor{0, 0, 0, 1}},
+		{RGBColor{1, 0, 0}, CMYKColor{0, 1, 1, 0}},
+		{RGBColor{0.2, 0.4, 0.8}, CMYKColor{0.75, 0.5, 0, 0.2}},
+	}
+
+	for _, v := range cmykList {
+		received := v.Input.CMYK()
+		if math.Abs(received.C-v.Expected.C) > 1e-9 || math.Abs(received.M-v.Expected.M) > 1e-9 ||
+			math.Abs(received.Y-v.Expected.Y) > 1e-9 || math.Abs(received.K-v.Expected.K) > 1e-9 {
+			t.Errorf("Incorrect CMYK of %v. Expected: %+v, Got: %+v.", v.Input, v.Expected, received)
+		}
+		if back := FromCMYK(received.C, received.M, received.Y, received.K); !closeRGB(back, v.Input) {
+			t.Errorf("Incorrect round trip of %v. Expected: %v, Got: %v.", v.Input, v.Input, back)
+		}
+	}
+}